@@ -0,0 +1,71 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/aberic/lilydb/config"
+	"github.com/aberic/lilydb/engine"
+	"os"
+)
+
+// fsckStart 离线检查数据目录，存在不一致时以非零状态退出
+//
+// 用法 lily fsck -c /data/config.yaml [-repair]
+func fsckStart(args []string) {
+	var (
+		configFilepath string
+		repair         bool
+	)
+	flagSet := flag.NewFlagSet("fsck", flag.ExitOnError)
+	flagSet.StringVar(&configFilepath, "c", "", "eg: '/data/config.yaml'")
+	flagSet.BoolVar(&repair, "repair", false, "rebuild index files from the primary index")
+	_ = flagSet.Parse(args)
+
+	conf := config.InitConfig(configFilepath)
+	initLog(conf)
+	result, err := engine.Fsck(repair)
+	if nil != err {
+		fmt.Println("fsck failed:", err)
+		os.Exit(2)
+	}
+	for _, check := range result.Forms {
+		fmt.Printf("form %s(%s) in database %s: size=%d rows=%d corrupts=%v orphans=%d unscanned=%d\n",
+			check.FormName, check.FormID, check.DatabaseID, check.Size, check.Rows, check.Corrupts, len(check.Orphans), check.Size-check.Unscanned)
+		for indexID, ic := range check.Indexes {
+			fmt.Printf("  index %s(%s): records=%d tail=%d dangling=%d missing=%d rebuilt=%v\n",
+				ic.KeyStructure, indexID, ic.Records, ic.Tail, len(ic.Dangling), ic.Missing, ic.Rebuilt)
+		}
+	}
+	for _, path := range result.Unknown {
+		fmt.Println("unknown directory:", path)
+	}
+	if !result.Healthy() {
+		fmt.Println("fsck: inconsistencies found")
+		os.Exit(1)
+	}
+	fmt.Println("fsck: ok")
+}
@@ -24,9 +24,16 @@
 
 package main
 
-import "flag"
+import (
+	"flag"
+	"os"
+)
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "fsck" { // 离线检查数据目录
+		fsckStart(os.Args[2:])
+		return
+	}
 	var configFilepath string
 	flag.StringVar(&configFilepath, "c", "", "eg: '/data/config.yaml'")
 	flag.Parse()
@@ -0,0 +1,149 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	api "github.com/aberic/lilydb/connector/grpc"
+	"github.com/aberic/lilydb/engine/siam/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Selector 检索选择器，由api.Selector转换而来，序列化为json后交由引擎检索
+type Selector struct {
+	Conditions []*condition       `json:"Conditions"` // Conditions 条件查询
+	Groups     []*group           `json:"Groups"`     // Groups 逻辑条件组
+	Skip       uint32             `json:"Skip"`       // Skip 结果集跳过数量
+	Sort       *rank              `json:"Sort"`       // Sort 排序方式
+	Sorts      []*rank            `json:"Sorts"`      // Sorts 多字段排序方式
+	Limit      uint32             `json:"Limit"`      // Limit 结果集顺序数量
+	Fields     []string           `json:"Fields"`     // Fields 返回字段投影
+	Cursor     string             `json:"Cursor"`     // Cursor 上一页返回的分页游标
+	Explain    bool               `json:"Explain"`    // Explain 仅返回检索计划
+	Aggregate  *utils.Aggregation `json:"Aggregate"`  // Aggregate 聚合方式
+}
+
+// condition 条件查询
+type condition struct {
+	Param string      `json:"Param"`
+	Cond  string      `json:"Cond"`
+	Value interface{} `json:"Value"`
+}
+
+// group 逻辑条件组
+type group struct {
+	Logic      string       `json:"Logic"`
+	Conditions []*condition `json:"Conditions"`
+	Groups     []*group     `json:"Groups"`
+}
+
+// rank 排序方式
+type rank struct {
+	Param string `json:"Param"`
+	ASC   bool   `json:"Asc"`
+	Nulls string `json:"Nulls"`
+}
+
+// formatAPI 将api.Selector转换为检索选择器，条件比较对象不是合法json时返回InvalidArgument错误
+func (s *Selector) formatAPI(selector *api.Selector) (err error) {
+	if nil == selector {
+		return
+	}
+	if s.Conditions, err = s.conditions(selector.Conditions); nil != err {
+		return
+	}
+	if s.Groups, err = s.groups(selector.Groups); nil != err {
+		return
+	}
+	s.Skip = selector.Skip
+	if nil != selector.Sort {
+		s.Sort = &rank{Param: selector.Sort.Param, ASC: selector.Sort.ASC, Nulls: selector.Sort.Nulls}
+	}
+	for _, sort := range selector.Sorts {
+		s.Sorts = append(s.Sorts, &rank{Param: sort.Param, ASC: sort.ASC, Nulls: sort.Nulls})
+	}
+	s.Limit = selector.Limit
+	s.Fields = selector.Fields
+	s.Cursor = selector.Cursor
+	return
+}
+
+// formatAggregation 将api.Aggregation转换为聚合方式
+func (s *Selector) formatAggregation(aggregation *api.Aggregation) {
+	s.Aggregate = &utils.Aggregation{}
+	if nil == aggregation {
+		return
+	}
+	s.Aggregate.GroupBy = aggregation.GroupBy
+	for _, acc := range aggregation.Accumulators {
+		s.Aggregate.Accumulators = append(s.Aggregate.Accumulators, &utils.Accumulator{Name: acc.Name, Op: acc.Op, Param: acc.Param})
+	}
+}
+
+func (s *Selector) conditions(conditions []*api.Condition) ([]*condition, error) {
+	var conds []*condition
+	for _, cond := range conditions {
+		value, err := s.conditionValue(cond.Param, cond.Value)
+		if nil != err {
+			return nil, err
+		}
+		conds = append(conds, &condition{Param: cond.Param, Cond: cond.Cond, Value: value})
+	}
+	return conds, nil
+}
+
+func (s *Selector) groups(groups []*api.Group) ([]*group, error) {
+	var gs []*group
+	for _, g := range groups {
+		conds, err := s.conditions(g.Conditions)
+		if nil != err {
+			return nil, err
+		}
+		subs, err := s.groups(g.Groups)
+		if nil != err {
+			return nil, err
+		}
+		gs = append(gs, &group{Logic: g.Logic, Conditions: conds, Groups: subs})
+	}
+	return gs, nil
+}
+
+// conditionValue 以json解析条件比较对象，字符串需以json字符串形式传入，如'"lily"'
+//
+// 数字保留原文后转换为int64、uint64或float64，避免超出2^53的整数经由float64丢失精度
+func (s *Selector) conditionValue(param string, value []byte) (interface{}, error) {
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); nil != err {
+		return nil, status.Errorf(codes.InvalidArgument, "condition %s value is not json: %v", param, err)
+	}
+	if decoder.More() {
+		return nil, status.Errorf(codes.InvalidArgument, "condition %s value is not a single json value", param)
+	}
+	return utils.JSONNumber(v), nil
+}
@@ -30,7 +30,10 @@ import (
 	"github.com/aberic/lilydb/config"
 	api "github.com/aberic/lilydb/connector/grpc"
 	"github.com/aberic/lilydb/engine"
+	"github.com/aberic/lilydb/engine/comm"
 	"github.com/vmihailenco/msgpack"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gopkg.in/yaml.v3"
 )
 
@@ -38,6 +41,26 @@ import (
 type APIServer struct {
 }
 
+// rpcError 将引擎错误转换为携带gRPC状态码的错误，失败时各接口仅返回该错误，不再返回响应体
+//
+// 已携带状态码的错误原样返回，未知错误使用codes.Internal
+func rpcError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch err {
+	case comm.ErrDataNotFound, comm.ErrFormNotFoundOrSupport, comm.ErrIndexNotFound, comm.ErrKeyNotFound, comm.ErrLinkNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case comm.ErrDatabaseExist, comm.ErrFormExist, comm.ErrIndexExist:
+		return status.Error(codes.AlreadyExists, err.Error())
+	case comm.ErrCursorInvalid, comm.ErrSortNotSupport, comm.ErrJoinNotSupport, comm.ErrAggregateNotSupport:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case comm.ErrIndexProtected:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
 // GetConf 获取数据库引擎对象
 func (l *APIServer) GetConf(_ context.Context, _ *api.ReqConf) (*api.RespConf, error) {
 	return &api.RespConf{Code: api.Code_Success, Conf: config.Obtain().Conf2RPC()}, nil
@@ -57,7 +80,7 @@ func (l *APIServer) ObtainForms(_ context.Context, req *api.ReqForms) (*api.Resp
 func (l *APIServer) CreateDatabase(_ context.Context, req *api.ReqCreateDatabase) (*api.Resp, error) {
 	var err error
 	if err = engine.Obtain().NewDatabase(req.Name, req.Comment); nil != err {
-		return &api.Resp{Code: api.Code_Fail, ErrMsg: err.Error()}, err
+		return nil, rpcError(err)
 	}
 	return &api.Resp{Code: api.Code_Success}, nil
 }
@@ -65,24 +88,80 @@ func (l *APIServer) CreateDatabase(_ context.Context, req *api.ReqCreateDatabase
 // CreateForm 创建表
 func (l *APIServer) CreateForm(_ context.Context, req *api.ReqCreateForm) (*api.Resp, error) {
 	if err := engine.Obtain().NewForm(req.DatabaseName, req.Name, req.Comment, req.FormType); nil != err {
-		return &api.Resp{Code: api.Code_Fail, ErrMsg: err.Error()}, err
+		return nil, rpcError(err)
+	}
+	return &api.Resp{Code: api.Code_Success}, nil
+}
+
+// CreateKey 新建主键，已存在的数据在后台回填
+func (l *APIServer) CreateKey(_ context.Context, req *api.ReqCreateKey) (*api.Resp, error) {
+	if err := engine.Obtain().CreateIndex(req.DatabaseName, req.FormName, req.KeyStructure, true, true); nil != err {
+		return nil, rpcError(err)
+	}
+	return &api.Resp{Code: api.Code_Success}, nil
+}
+
+// CreateIndex 新建索引，已存在的数据在后台回填
+func (l *APIServer) CreateIndex(_ context.Context, req *api.ReqCreateIndex) (*api.Resp, error) {
+	if err := engine.Obtain().CreateIndex(req.DatabaseName, req.FormName, req.KeyStructure, false, req.Unique); nil != err {
+		return nil, rpcError(err)
+	}
+	return &api.Resp{Code: api.Code_Success}, nil
+}
+
+// RenameDatabase 修改数据库名
+func (l *APIServer) RenameDatabase(_ context.Context, req *api.ReqRenameDatabase) (*api.Resp, error) {
+	if err := engine.Obtain().RenameDatabase(req.Name, req.NewName); nil != err {
+		return nil, rpcError(err)
+	}
+	return &api.Resp{Code: api.Code_Success}, nil
+}
+
+// CommentDatabase 修改数据库描述
+func (l *APIServer) CommentDatabase(_ context.Context, req *api.ReqCommentDatabase) (*api.Resp, error) {
+	if err := engine.Obtain().CommentDatabase(req.Name, req.Comment); nil != err {
+		return nil, rpcError(err)
+	}
+	return &api.Resp{Code: api.Code_Success}, nil
+}
+
+// RenameForm 修改表名
+func (l *APIServer) RenameForm(_ context.Context, req *api.ReqRenameForm) (*api.Resp, error) {
+	if err := engine.Obtain().RenameForm(req.DatabaseName, req.Name, req.NewName); nil != err {
+		return nil, rpcError(err)
 	}
 	return &api.Resp{Code: api.Code_Success}, nil
 }
 
-// CreateKey 新建主键
-func (l *APIServer) CreateKey(_ context.Context, _ *api.ReqCreateKey) (*api.Resp, error) {
-	//if err := engine.Obtain().CreateKey(req.DatabaseName, req.FormName, req.KeyStructure); nil != err {
-	//	return &api.Resp{Code: api.Code_Fail, ErrMsg: err.Error()}, err
-	//}
+// CommentForm 修改表描述
+func (l *APIServer) CommentForm(_ context.Context, req *api.ReqCommentForm) (*api.Resp, error) {
+	if err := engine.Obtain().CommentForm(req.DatabaseName, req.Name, req.Comment); nil != err {
+		return nil, rpcError(err)
+	}
 	return &api.Resp{Code: api.Code_Success}, nil
 }
 
-// CreateIndex 新建索引
-func (l *APIServer) CreateIndex(_ context.Context, _ *api.ReqCreateIndex) (*api.Resp, error) {
-	//if err := engine.Obtain().CreateIndex(req.DatabaseName, req.FormName, req.KeyStructure); nil != err {
-	//	return &api.Resp{Code: api.Code_Fail, ErrMsg: err.Error()}, err
-	//}
+// DropDatabase 删除数据库
+func (l *APIServer) DropDatabase(_ context.Context, req *api.ReqDropDatabase) (*api.Resp, error) {
+	if err := engine.Obtain().DropDatabase(req.Name); nil != err {
+		return nil, rpcError(err)
+	}
+	return &api.Resp{Code: api.Code_Success}, nil
+}
+
+// DropForm 删除表
+func (l *APIServer) DropForm(_ context.Context, req *api.ReqDropForm) (*api.Resp, error) {
+	if err := engine.Obtain().DropForm(req.DatabaseName, req.Name); nil != err {
+		return nil, rpcError(err)
+	}
+	return &api.Resp{Code: api.Code_Success}, nil
+}
+
+// DropIndex 删除索引
+func (l *APIServer) DropIndex(_ context.Context, req *api.ReqDropIndex) (*api.Resp, error) {
+	if err := engine.Obtain().DropIndex(req.DatabaseName, req.FormName, req.KeyStructure); nil != err {
+		return nil, rpcError(err)
+	}
 	return &api.Resp{Code: api.Code_Success}, nil
 }
 
@@ -102,7 +181,7 @@ func (l *APIServer) Put(_ context.Context, req *api.ReqPut) (*api.RespPut, error
 	v = string(req.Value)
 PUT:
 	if hashKey, err = engine.Obtain().Put(req.DatabaseName, req.FormName, req.Key, v); nil != err {
-		return &api.RespPut{Code: api.Code_Fail, ErrMsg: err.Error()}, err
+		return nil, rpcError(err)
 	}
 	return &api.RespPut{Code: api.Code_Success, HashKey: hashKey}, nil
 }
@@ -123,7 +202,7 @@ func (l *APIServer) Set(_ context.Context, req *api.ReqSet) (*api.RespSet, error
 	v = string(req.Value)
 PUT:
 	if hashKey, err = engine.Obtain().Set(req.DatabaseName, req.FormName, req.Key, v); nil != err {
-		return &api.RespSet{Code: api.Code_Fail, ErrMsg: err.Error()}, err
+		return nil, rpcError(err)
 	}
 	return &api.RespSet{Code: api.Code_Success, HashKey: hashKey}, nil
 }
@@ -136,10 +215,10 @@ func (l *APIServer) Get(_ context.Context, req *api.ReqGet) (*api.RespGet, error
 		err  error
 	)
 	if v, err = engine.Obtain().Get(req.DatabaseName, req.FormName, req.Key); nil != err {
-		return &api.RespGet{Code: api.Code_Fail, ErrMsg: err.Error()}, err
+		return nil, rpcError(err)
 	}
 	if data, err = msgpack.Marshal(v); nil != err {
-		return &api.RespGet{Code: api.Code_Fail, ErrMsg: err.Error()}, err
+		return nil, rpcError(err)
 	}
 	return &api.RespGet{Code: api.Code_Success, Value: data}, nil
 }
@@ -147,65 +226,151 @@ func (l *APIServer) Get(_ context.Context, req *api.ReqGet) (*api.RespGet, error
 // Remove 删除数据
 func (l *APIServer) Remove(_ context.Context, req *api.ReqRemove) (*api.Resp, error) {
 	if _, err := engine.Obtain().Del(req.DatabaseName, req.FormName, req.Key); nil != err {
-		return &api.Resp{Code: api.Code_Fail, ErrMsg: err.Error()}, err
+		return nil, rpcError(err)
 	}
 	return &api.Resp{Code: api.Code_Success}, nil
 }
 
-// Insert 新增数据
-func (l *APIServer) Insert(_ context.Context, _ *api.ReqInsert) (*api.RespInsert, error) {
-	//	var (
-	//		v       interface{}
-	//		hashKey uint64
-	//		err     error
-	//	)
-	//	if err = json.Unmarshal(req.Value, &v); nil == err { // 尝试用json解析
-	//		goto PUT
-	//	}
-	//	if err = yaml.Unmarshal(req.Value, &v); nil == err { // 尝试用yaml解析
-	//		goto PUT
-	//	}
-	//	v = string(req.Value)
-	//PUT:
-	//	if hashKey, err = engine.Obtain().PutD(req.Key, v); nil != err {
-	//		return &api.RespInsert{Code: api.Code_Fail, ErrMsg: err.Error()}, err
-	//	}
-	return &api.RespInsert{Code: api.Code_Success, HashKey: 0}, nil
-}
-
-// Select 获取数据
-func (l *APIServer) Select(_ context.Context, _ *api.ReqSelect) (*api.RespSelect, error) {
-	//var (
-	//	count int32
-	//	v     interface{}
-	//	s     = &Selector{}
-	//	data  []byte
-	//	err   error
-	//)
-	//if err = s.formatAPI(req.Selector); nil != err {
-	//	return nil, err
-	//}
-	//if count, v, err = engine.Obtain().Select(req.DatabaseName, req.FormName, s); nil != err {
-	//	return &api.RespSelect{Code: api.Code_Fail, ErrMsg: err.Error()}, err
-	//}
-	//if data, err = msgpack.Marshal(v); nil != err {
-	//	return &api.RespSelect{Code: api.Code_Fail, ErrMsg: err.Error()}, err
-	//}
-	return &api.RespSelect{Code: api.Code_Success, Count: 0, Value: nil}, nil
+// Insert 新增数据，Value依次尝试用json及yaml解析，均失败时作为字符串存储
+func (l *APIServer) Insert(_ context.Context, req *api.ReqInsert) (*api.RespInsert, error) {
+	var (
+		v       interface{}
+		hashKey uint64
+		err     error
+	)
+	if err = json.Unmarshal(req.Value, &v); nil == err { // 尝试用json解析
+		goto INSERT
+	}
+	if err = yaml.Unmarshal(req.Value, &v); nil == err { // 尝试用yaml解析
+		goto INSERT
+	}
+	v = string(req.Value)
+INSERT:
+	if hashKey, err = engine.Obtain().Insert(req.DatabaseName, req.FormName, v); nil != err {
+		return nil, rpcError(err)
+	}
+	return &api.RespInsert{Code: api.Code_Success, HashKey: hashKey}, nil
+}
+
+// Select 获取数据，检索结果集合以msgpack编码，并返回下一页的分页游标
+func (l *APIServer) Select(_ context.Context, req *api.ReqSelect) (*api.RespSelect, error) {
+	var (
+		count         int32
+		v             []interface{}
+		cursor        string
+		s             = &Selector{}
+		selectorBytes []byte
+		data          []byte
+		err           error
+	)
+	if err = s.formatAPI(req.Selector); nil != err {
+		return nil, rpcError(err)
+	}
+	if selectorBytes, err = json.Marshal(s); nil != err {
+		return nil, rpcError(err)
+	}
+	if count, v, cursor, err = engine.Obtain().Page(req.DatabaseName, req.FormName, selectorBytes); nil != err {
+		return nil, rpcError(err)
+	}
+	if data, err = msgpack.Marshal(v); nil != err {
+		return nil, rpcError(err)
+	}
+	return &api.RespSelect{Code: api.Code_Success, Count: count, Value: data, Cursor: cursor}, nil
+}
+
+// SelectStream 流式获取数据，在遍历索引的过程中将每条数据以msgpack编码后单独发送，Limit为0时不限制数量
+func (l *APIServer) SelectStream(req *api.ReqSelect, stream api.LilyAPI_SelectStreamServer) error {
+	var (
+		s             = &Selector{}
+		selectorBytes []byte
+		sendErr       error
+		err           error
+	)
+	if err = s.formatAPI(req.Selector); nil != err {
+		return rpcError(err)
+	}
+	if selectorBytes, err = json.Marshal(s); nil != err {
+		return rpcError(err)
+	}
+	_, err = engine.Obtain().Stream(req.DatabaseName, req.FormName, selectorBytes, func(value interface{}) bool {
+		var data []byte
+		if data, sendErr = msgpack.Marshal(value); nil != sendErr {
+			return false
+		}
+		sendErr = stream.Send(&api.RespSelectStream{Code: api.Code_Success, Value: data})
+		return nil == sendErr // 客户端断开或取消时停止检索
+	})
+	if nil == err {
+		err = sendErr
+	}
+	if nil != err {
+		return rpcError(err)
+	}
+	return nil
+}
+
+// Aggregate 分组聚合检索结果
+func (l *APIServer) Aggregate(_ context.Context, req *api.ReqAggregate) (*api.RespAggregate, error) {
+	var (
+		count         int32
+		v             []interface{}
+		s             = &Selector{}
+		selectorBytes []byte
+		data          []byte
+		err           error
+	)
+	if err = s.formatAPI(req.Selector); nil != err {
+		return nil, rpcError(err)
+	}
+	s.formatAggregation(req.Aggregation)
+	if selectorBytes, err = json.Marshal(s); nil != err {
+		return nil, rpcError(err)
+	}
+	if count, v, err = engine.Obtain().Select(req.DatabaseName, req.FormName, selectorBytes); nil != err {
+		return nil, rpcError(err)
+	}
+	if data, err = msgpack.Marshal(v); nil != err {
+		return nil, rpcError(err)
+	}
+	return &api.RespAggregate{Code: api.Code_Success, Count: count, Value: data}, nil
+}
+
+// Explain 获取检索计划
+func (l *APIServer) Explain(_ context.Context, req *api.ReqExplain) (*api.RespExplain, error) {
+	var (
+		plan          *api.Plan
+		s             = &Selector{}
+		selectorBytes []byte
+		err           error
+	)
+	if err = s.formatAPI(req.Selector); nil != err {
+		return nil, rpcError(err)
+	}
+	if selectorBytes, err = json.Marshal(s); nil != err {
+		return nil, rpcError(err)
+	}
+	if plan, err = engine.Obtain().Explain(req.DatabaseName, req.FormName, selectorBytes); nil != err {
+		return nil, rpcError(err)
+	}
+	return &api.RespExplain{Code: api.Code_Success, Plan: plan}, nil
 }
 
 // Delete 删除数据
-func (l *APIServer) Delete(_ context.Context, _ *api.ReqDelete) (*api.RespDelete, error) {
-	//var (
-	//	s     = &Selector{}
-	//	count int32
-	//	err   error
-	//)
-	//if err = s.formatAPI(req.Selector); nil != err {
-	//	return nil, err
-	//}
-	//if count, err = engine.Obtain().Delete(req.DatabaseName, req.FormName, s); nil != err {
-	//	return &api.RespDelete{Code: api.Code_Fail, ErrMsg: err.Error()}, err
-	//}
-	return &api.RespDelete{Code: api.Code_Success, Count: 0}, nil
+func (l *APIServer) Delete(_ context.Context, req *api.ReqDelete) (*api.RespDelete, error) {
+	var (
+		count         int32
+		s             = &Selector{}
+		selectorBytes []byte
+		err           error
+	)
+	if err = s.formatAPI(req.Selector); nil != err {
+		return nil, rpcError(err)
+	}
+	if selectorBytes, err = json.Marshal(s); nil != err {
+		return nil, rpcError(err)
+	}
+	if count, err = engine.Obtain().Delete(req.DatabaseName, req.FormName, selectorBytes); nil != err {
+		return nil, rpcError(err)
+	}
+	return &api.RespDelete{Code: api.Code_Success, Count: count}, nil
 }
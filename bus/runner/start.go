@@ -29,6 +29,7 @@ import (
 	"github.com/aberic/gnomon/log"
 	"github.com/aberic/lilydb/config"
 	api "github.com/aberic/lilydb/connector/grpc"
+	"github.com/aberic/lilydb/engine"
 	"google.golang.org/grpc"
 	"net"
 	"strings"
@@ -38,6 +39,10 @@ import (
 func serverStart(configFilepath string) {
 	conf := config.InitConfig(configFilepath)
 	initLog(conf)
+	if err := engine.Lock(); nil != err { // 记录服务进程，离线修复据此判断服务是否已停止
+		panic(err)
+	}
+	engine.Obtain() // 恢复库表结构
 	rpcListener(conf)
 }
 
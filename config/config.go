@@ -35,6 +35,15 @@ import (
 	"sync"
 )
 
+const (
+	// WALSyncAlways 每次写入预写日志后立即落盘
+	WALSyncAlways = "always"
+	// WALSyncInterval 按照指定间隔定时落盘预写日志
+	WALSyncInterval = "interval"
+	// WALSyncNever 不主动落盘预写日志，由操作系统决定
+	WALSyncNever = "never"
+)
+
 var (
 	// version 版本号
 	version      = "1.0"
@@ -68,6 +77,9 @@ type Config struct {
 	Production               bool   `yaml:"Production"`               // Production 是否生产环境，在生产环境下控制台不会输出任何日志
 	LilyLockFilePath         string `yaml:"lily_lock_file_path"`      // LilyLockFilePath Lily当前进程地址存储文件地址
 	LilyBootstrapFilePath    string `yaml:"lily_bootstrap_file_path"` // LilyBootstrapFilePath Lily重启引导文件地址
+	WALSync                  string `yaml:"WALSync"`                  // WALSync 预写日志落盘策略(always/interval/never)
+	WALSyncInterval          int32  `yaml:"WALSyncInterval"`          // WALSyncInterval 预写日志定时落盘间隔（毫秒），仅在interval策略下生效
+	SortBuffer               int32  `yaml:"SortBuffer"`               // SortBuffer 排序时内存中最多保留的数据条数，超出后分批写入DataDir下的临时文件归并排序
 }
 
 // InitConfig 根据文件地址获取Config对象
@@ -146,6 +158,19 @@ func (c *Config) scanDefault() (*Config, error) {
 			return nil, errors.New("limit count or millisecond can not be zero")
 		}
 	}
+	switch c.WALSync {
+	default:
+		return nil, errors.New("wal sync must be always, interval or never")
+	case "":
+		c.WALSync = WALSyncAlways
+	case WALSyncAlways, WALSyncInterval, WALSyncNever:
+	}
+	if c.WALSyncInterval < 1 {
+		c.WALSyncInterval = 100
+	}
+	if c.SortBuffer < 1 {
+		c.SortBuffer = 10000
+	}
 	c.LilyLockFilePath = filepath.Join(c.RootDir, "lily.lock")
 	c.LilyBootstrapFilePath = filepath.Join(c.DataDir, "lily.sync")
 	return c, nil
@@ -186,6 +211,9 @@ func (c *Config) Conf2RPC() *api.Config {
 		LimitIntervalMicrosecond: c.LimitIntervalMicrosecond,
 		LilyLockFilePath:         c.LilyLockFilePath,
 		LilyBootstrapFilePath:    c.LilyBootstrapFilePath,
+		WALSync:                  c.WALSync,
+		WALSyncInterval:          c.WALSyncInterval,
+		SortBuffer:               c.SortBuffer,
 	}
 }
 
@@ -205,4 +233,7 @@ func (c *Config) RPC2Config(conf *api.Config) {
 	c.LimitIntervalMicrosecond = conf.LimitIntervalMicrosecond
 	c.LilyLockFilePath = conf.LilyLockFilePath
 	c.LilyBootstrapFilePath = conf.LilyBootstrapFilePath
+	c.WALSync = conf.WALSync
+	c.WALSyncInterval = conf.WALSyncInterval
+	c.SortBuffer = conf.SortBuffer
 }
@@ -57,6 +57,34 @@ type Form interface {
 	Comment() string        // Comment 获取表描述
 	FormType() api.FormType // FormType 获取表类型
 	Indexes() map[string]*api.Index
+	// Rename 修改表名，表唯一ID不变
+	//
+	// name 新表名称
+	Rename(name string)
+	// SetComment 修改表描述
+	//
+	// comment 表描述
+	SetComment(comment string)
+	// SetSync 设置表结构变化后同步引导文件的方法
+	//
+	// sync 同步引导文件方法
+	SetSync(sync func() error)
+	// CreateIndex 在线新建索引，并在后台以已存在的数据回填，回填完成前不参与检索
+	//
+	// keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'，组合索引的各字段名称间通过','分隔，如'tenant,createdAt'
+	//
+	// primary 是否主键
+	//
+	// unique 是否唯一索引，非唯一索引的同一key可对应多行数据
+	//
+	// built 回填结束回调，err为空表示回填完成，回填失败时索引将被删除
+	CreateIndex(keyStructure string, primary, unique bool, built func(err error)) error
+	// DropIndex 删除索引，默认主键不可删除
+	//
+	// keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'
+	DropIndex(keyStructure string) error
+	// Drop 删除表内所有数据及索引
+	Drop() error
 	// Insert 新增数据
 	//
 	// value 插入数据对象
@@ -107,6 +135,28 @@ type Form interface {
 	//
 	// return err 检索错误信息，如果有
 	Select(selectorBytes []byte) (count int32, values []interface{}, err error)
+	// Page 根据条件分页检索，按所用索引中的位置续查，不受两次检索之间新增或删除数据的影响
+	//
+	// selectorBytes 选择器字节数组，自定义转换策略，可包含上一页返回的分页游标Cursor
+	//
+	// return count 检索结果总条数
+	//
+	// return values 检索结果集合
+	//
+	// return cursor 下一页的分页游标，已无更多数据时为空
+	//
+	// return err 检索错误信息，如果有
+	Page(selectorBytes []byte) (count int32, values []interface{}, cursor string, err error)
+	// Stream 根据条件流式检索，检索结果在遍历索引的过程中逐条交由handler处理，不在内存中保留全部结果
+	//
+	// selectorBytes 选择器字节数组，自定义转换策略，Limit为0时不限制数量
+	//
+	// handler 检索结果处理方法，返回false时停止检索
+	//
+	// return count 停止前已检索的结果条数
+	//
+	// return err 检索错误信息，如果有
+	Stream(selectorBytes []byte, handler func(value interface{}) bool) (count int32, err error)
 	// Delete 根据条件删除
 	//
 	// selectorBytes 选择器字节数组，自定义转换策略
@@ -115,4 +165,10 @@ type Form interface {
 	//
 	// return err 删除错误信息，如果有
 	Delete(selectorBytes []byte) (count int32, err error)
+	// Compact 压缩表数据文件，仅保留存活数据并重写索引文件
+	Compact() error
+	// Verify 校验表数据
+	//
+	// 返回 损坏数据在文件中的起始位置集合
+	Verify() ([]int64, error)
 }
@@ -21,7 +21,7 @@ var _ = math.Inf
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 // Conf 数据库引擎对象
 type Config struct {
@@ -52,10 +52,16 @@ type Config struct {
 	// LilyLockFilePath Lily当前进程地址存储文件地址
 	LilyLockFilePath string `protobuf:"bytes,13,opt,name=LilyLockFilePath,proto3" json:"LilyLockFilePath,omitempty"`
 	// LilyBootstrapFilePath Lily重启引导文件地址
-	LilyBootstrapFilePath string   `protobuf:"bytes,14,opt,name=LilyBootstrapFilePath,proto3" json:"LilyBootstrapFilePath,omitempty"`
-	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
-	XXX_unrecognized      []byte   `json:"-"`
-	XXX_sizecache         int32    `json:"-"`
+	LilyBootstrapFilePath string `protobuf:"bytes,14,opt,name=LilyBootstrapFilePath,proto3" json:"LilyBootstrapFilePath,omitempty"`
+	// WALSync 预写日志落盘策略(always/interval/never)
+	WALSync string `protobuf:"bytes,15,opt,name=WALSync,proto3" json:"WALSync,omitempty"`
+	// WALSyncInterval 预写日志定时落盘间隔（毫秒），仅在interval策略下生效
+	WALSyncInterval int32 `protobuf:"varint,16,opt,name=WALSyncInterval,proto3" json:"WALSyncInterval,omitempty"`
+	// SortBuffer 排序时内存中最多保留的数据条数，超出后分批写入DataDir下的临时文件归并排序
+	SortBuffer           int32    `protobuf:"varint,17,opt,name=SortBuffer,proto3" json:"SortBuffer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Config) Reset()         { *m = Config{} }
@@ -181,6 +187,27 @@ func (m *Config) GetLilyBootstrapFilePath() string {
 	return ""
 }
 
+func (m *Config) GetWALSync() string {
+	if m != nil {
+		return m.WALSync
+	}
+	return ""
+}
+
+func (m *Config) GetWALSyncInterval() int32 {
+	if m != nil {
+		return m.WALSyncInterval
+	}
+	return 0
+}
+
+func (m *Config) GetSortBuffer() int32 {
+	if m != nil {
+		return m.SortBuffer
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Config)(nil), "api.Config")
 }
@@ -188,26 +215,29 @@ func init() {
 func init() { proto.RegisterFile("connector/grpc/config.proto", fileDescriptor_511b956008f11c76) }
 
 var fileDescriptor_511b956008f11c76 = []byte{
-	// 336 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xd1, 0x4b, 0x02, 0x41,
-	0x10, 0xc6, 0xb9, 0x4e, 0x4f, 0x1d, 0x33, 0x6c, 0xa9, 0x58, 0x08, 0x4a, 0xa2, 0x07, 0x89, 0x38,
-	0x1f, 0xea, 0xa9, 0x47, 0x95, 0x20, 0x3a, 0x49, 0x4e, 0xff, 0x81, 0x75, 0xdd, 0x6c, 0x69, 0xdb,
-	0x39, 0xd6, 0x4d, 0xf0, 0x5f, 0xef, 0x29, 0x76, 0xce, 0xa4, 0xb2, 0xde, 0xe6, 0xfb, 0x7d, 0x33,
-	0x3b, 0xb3, 0xf0, 0xc1, 0xa9, 0x44, 0x6b, 0x95, 0xf4, 0xe8, 0x7a, 0x0b, 0x57, 0xc8, 0x9e, 0x44,
-	0xfb, 0xac, 0x17, 0x69, 0xe1, 0xd0, 0x23, 0x8b, 0x45, 0xa1, 0x2f, 0x3e, 0x62, 0x48, 0x06, 0x44,
-	0x19, 0x83, 0xca, 0x18, 0x9d, 0xe7, 0x51, 0x27, 0xea, 0x36, 0x72, 0xaa, 0x19, 0x87, 0x5a, 0x8e,
-	0xe8, 0x87, 0xda, 0xf1, 0x3d, 0xc2, 0x5f, 0x32, 0x38, 0x43, 0xe1, 0x45, 0x70, 0xe2, 0xd2, 0xd9,
-	0x48, 0x76, 0x02, 0x49, 0x86, 0x8b, 0x60, 0x54, 0xc8, 0xd8, 0x28, 0x76, 0x09, 0xad, 0x4c, 0xbf,
-	0x69, 0xff, 0x54, 0x28, 0x7b, 0xaf, 0x8d, 0xe2, 0xd5, 0x4e, 0xd4, 0xad, 0xe6, 0x3f, 0x21, 0x6b,
-	0x43, 0x3c, 0xcd, 0x26, 0x3c, 0xe9, 0x44, 0xdd, 0x7a, 0x1e, 0x4a, 0x76, 0x05, 0xed, 0x69, 0x36,
-	0x99, 0x28, 0xb7, 0x52, 0xee, 0x51, 0xad, 0x69, 0xb4, 0x46, 0x2f, 0xef, 0x70, 0x76, 0x0d, 0x87,
-	0x5b, 0x36, 0x50, 0xce, 0x53, 0x73, 0x9d, 0x9a, 0x77, 0x0d, 0x76, 0x04, 0x55, 0x5a, 0xce, 0x1b,
-	0xb4, 0xad, 0x14, 0x61, 0x1f, 0x15, 0x23, 0x6d, 0x8c, 0x5e, 0x2a, 0x89, 0x76, 0xce, 0x81, 0x4e,
-	0xdd, 0xe1, 0xec, 0x0c, 0x80, 0xd8, 0x00, 0xdf, 0xad, 0xe7, 0x4d, 0xea, 0xfa, 0x46, 0xd8, 0x1d,
-	0x70, 0x52, 0x0f, 0xd6, 0x2b, 0xb7, 0x12, 0x66, 0xa4, 0xa5, 0xc3, 0xcd, 0x9b, 0xfb, 0xd4, 0xfd,
-	0xaf, 0x5f, 0xde, 0x61, 0xd6, 0x19, 0xca, 0xd7, 0x70, 0xed, 0x58, 0xf8, 0x17, 0xde, 0x2a, 0xff,
-	0xfd, 0x9b, 0xb3, 0x5b, 0x38, 0x0e, 0xac, 0x8f, 0xe8, 0x97, 0xde, 0x89, 0x62, 0x3b, 0x70, 0x40,
-	0x03, 0x7f, 0x9b, 0xfd, 0x14, 0xce, 0xa5, 0x4d, 0xc5, 0x4c, 0x39, 0x2d, 0x53, 0xa3, 0xcd, 0x7a,
-	0x3e, 0x4b, 0xb7, 0x89, 0x49, 0x43, 0x62, 0xfa, 0xcd, 0x32, 0x1c, 0xe3, 0x90, 0x98, 0x59, 0x42,
-	0xc1, 0xb9, 0xf9, 0x0c, 0x00, 0x00, 0xff, 0xff, 0xa1, 0x75, 0xd3, 0x58, 0x57, 0x02, 0x00, 0x00,
+	// 381 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0x51, 0x8b, 0xda, 0x40,
+	0x10, 0xc7, 0x49, 0xd5, 0xa8, 0x63, 0xad, 0xba, 0xb4, 0x65, 0xa1, 0xd0, 0x4a, 0xe9, 0x43, 0x28,
+	0x25, 0x3e, 0xb4, 0x4f, 0x7d, 0x6b, 0x94, 0x42, 0x69, 0xe4, 0x24, 0x11, 0xee, 0x39, 0xae, 0xab,
+	0xb7, 0x5c, 0x6e, 0x27, 0xac, 0xab, 0x90, 0x2f, 0x7b, 0x9f, 0xe5, 0xd8, 0x49, 0xf4, 0xee, 0xf4,
+	0xee, 0x6d, 0xfe, 0xbf, 0xff, 0xcc, 0xce, 0xec, 0xee, 0xc0, 0x27, 0x81, 0x5a, 0x4b, 0x61, 0xd1,
+	0x4c, 0xb6, 0xa6, 0x10, 0x13, 0x81, 0x7a, 0xa3, 0xb6, 0x61, 0x61, 0xd0, 0x22, 0x6b, 0x64, 0x85,
+	0xfa, 0x7a, 0xdf, 0x04, 0x7f, 0x4a, 0x94, 0x31, 0x68, 0x2e, 0xd0, 0x58, 0xee, 0x8d, 0xbd, 0xa0,
+	0x9b, 0x50, 0xcc, 0x38, 0xb4, 0x13, 0x44, 0x3b, 0x53, 0x86, 0xbf, 0x21, 0x7c, 0x94, 0xce, 0x99,
+	0x65, 0x36, 0x73, 0x4e, 0xa3, 0x72, 0x6a, 0xc9, 0x3e, 0x82, 0x1f, 0xe3, 0xd6, 0x19, 0x4d, 0x32,
+	0x6a, 0xc5, 0xbe, 0x41, 0x3f, 0x56, 0x77, 0xca, 0x5e, 0x15, 0x52, 0xff, 0x55, 0xb9, 0xe4, 0xad,
+	0xb1, 0x17, 0xb4, 0x92, 0xe7, 0x90, 0x0d, 0xa1, 0xb1, 0x8c, 0x53, 0xee, 0x8f, 0xbd, 0xa0, 0x93,
+	0xb8, 0x90, 0x7d, 0x87, 0xe1, 0x32, 0x4e, 0x53, 0x69, 0x0e, 0xd2, 0xfc, 0x97, 0x25, 0x95, 0xb6,
+	0xe9, 0xe4, 0x0b, 0xce, 0x7e, 0xc0, 0xe8, 0xc4, 0xa6, 0xd2, 0x58, 0x4a, 0xee, 0x50, 0xf2, 0xa5,
+	0xc1, 0xde, 0x43, 0x8b, 0x9a, 0xf3, 0x2e, 0x75, 0xab, 0x84, 0xeb, 0x47, 0xc1, 0x5c, 0xe5, 0xb9,
+	0xda, 0x49, 0x81, 0x7a, 0xcd, 0x81, 0x46, 0xbd, 0xe0, 0xec, 0x33, 0x00, 0xb1, 0x29, 0xee, 0xb5,
+	0xe5, 0x3d, 0xca, 0x7a, 0x42, 0xd8, 0x6f, 0xe0, 0xa4, 0xfe, 0x69, 0x2b, 0xcd, 0x21, 0xcb, 0xe7,
+	0x4a, 0x18, 0xac, 0xcf, 0x7c, 0x4b, 0xd9, 0xaf, 0xfa, 0xd5, 0x1c, 0x79, 0x19, 0xa3, 0xb8, 0x75,
+	0xd3, 0x2e, 0x32, 0x7b, 0xc3, 0xfb, 0xd5, 0xbd, 0xcf, 0x39, 0xfb, 0x05, 0x1f, 0x1c, 0x8b, 0x10,
+	0xed, 0xce, 0x9a, 0xac, 0x38, 0x15, 0xbc, 0xa3, 0x82, 0x97, 0x4d, 0xf7, 0x87, 0xd7, 0x7f, 0xe2,
+	0xb4, 0xd4, 0x82, 0x0f, 0xaa, 0x3f, 0xac, 0x25, 0x0b, 0x60, 0x50, 0x87, 0xc7, 0xc9, 0xf8, 0x90,
+	0xc6, 0x3d, 0xc7, 0xee, 0x05, 0x52, 0x34, 0x36, 0xda, 0x6f, 0x36, 0xd2, 0xf0, 0x51, 0xf5, 0x02,
+	0x8f, 0x24, 0x0a, 0xe1, 0x8b, 0xd0, 0x61, 0xb6, 0x92, 0x46, 0x89, 0x30, 0x57, 0x79, 0xb9, 0x5e,
+	0x85, 0xa7, 0xad, 0x0c, 0xdd, 0x56, 0x46, 0xbd, 0x6a, 0x01, 0x17, 0x6e, 0x2b, 0x57, 0x3e, 0x2d,
+	0xe7, 0xcf, 0x87, 0x01, 0x00, 0x1a, 0xbe, 0x2c, 0x67, 0xbb, 0x02, 0x00, 0x00,
 }
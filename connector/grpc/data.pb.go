@@ -21,7 +21,7 @@ var _ = math.Inf
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 // FormType 表类型
 type FormType int32
@@ -244,7 +244,15 @@ type Index struct {
 	// Primary 是否主键
 	Primary bool `protobuf:"varint,2,opt,name=Primary,proto3" json:"Primary,omitempty"`
 	// KeyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'
-	KeyStructure         string   `protobuf:"bytes,3,opt,name=KeyStructure,proto3" json:"KeyStructure,omitempty"`
+	KeyStructure string `protobuf:"bytes,3,opt,name=KeyStructure,proto3" json:"KeyStructure,omitempty"`
+	// Building 是否正在回填已存在的数据，回填完成前不参与检索
+	Building bool `protobuf:"varint,4,opt,name=Building,proto3" json:"Building,omitempty"`
+	// Done 已回填数据行数
+	Done uint64 `protobuf:"varint,5,opt,name=Done,proto3" json:"Done,omitempty"`
+	// Total 需回填数据行数
+	Total uint64 `protobuf:"varint,6,opt,name=Total,proto3" json:"Total,omitempty"`
+	// Unique 是否唯一索引，主键总是唯一索引，非唯一索引的同一key可对应多行数据
+	Unique               bool     `protobuf:"varint,7,opt,name=Unique,proto3" json:"Unique,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -296,6 +304,34 @@ func (m *Index) GetKeyStructure() string {
 	return ""
 }
 
+func (m *Index) GetBuilding() bool {
+	if m != nil {
+		return m.Building
+	}
+	return false
+}
+
+func (m *Index) GetDone() uint64 {
+	if m != nil {
+		return m.Done
+	}
+	return 0
+}
+
+func (m *Index) GetTotal() uint64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *Index) GetUnique() bool {
+	if m != nil {
+		return m.Unique
+	}
+	return false
+}
+
 // Selector 检索选择器
 type Selector struct {
 	// Conditions 条件查询
@@ -305,7 +341,15 @@ type Selector struct {
 	// Sort 排序方式
 	Sort *Sort `protobuf:"bytes,3,opt,name=Sort,proto3" json:"Sort,omitempty"`
 	// Limit 结果集顺序数量
-	Limit                uint32   `protobuf:"varint,4,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	Limit uint32 `protobuf:"varint,4,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	// Groups 逻辑条件组，各组之间及与Conditions之间为与关系
+	Groups []*Group `protobuf:"bytes,5,rep,name=Groups,proto3" json:"Groups,omitempty"`
+	// Fields 返回字段投影，由对象结构层级字段通过'.'组成，以'-'开头表示去除该字段，存在非去除字段时仅返回这些字段
+	Fields []string `protobuf:"bytes,6,rep,name=Fields,proto3" json:"Fields,omitempty"`
+	// Cursor 上一页返回的分页游标，存在时自游标位置之后继续检索
+	Cursor string `protobuf:"bytes,7,opt,name=Cursor,proto3" json:"Cursor,omitempty"`
+	// Sorts 多字段排序方式，按先后顺序依次比较，存在时替代Sort
+	Sorts                []*Sort  `protobuf:"bytes,8,rep,name=Sorts,proto3" json:"Sorts,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -364,6 +408,93 @@ func (m *Selector) GetLimit() uint32 {
 	return 0
 }
 
+func (m *Selector) GetGroups() []*Group {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
+}
+
+func (m *Selector) GetFields() []string {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+func (m *Selector) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+func (m *Selector) GetSorts() []*Sort {
+	if m != nil {
+		return m.Sorts
+	}
+	return nil
+}
+
+// Group 逻辑条件组，可嵌套组成如'(status eq "open" OR status eq "pending") AND NOT owner eq ""'的条件树
+type Group struct {
+	// Logic 逻辑关系 and/or/not，默认为and，not表示组内条件及子条件组全部满足的结果取反
+	Logic string `protobuf:"bytes,1,opt,name=Logic,proto3" json:"Logic,omitempty"`
+	// Conditions 组内条件
+	Conditions []*Condition `protobuf:"bytes,2,rep,name=Conditions,proto3" json:"Conditions,omitempty"`
+	// Groups 子条件组
+	Groups               []*Group `protobuf:"bytes,3,rep,name=Groups,proto3" json:"Groups,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Group) Reset()         { *m = Group{} }
+func (m *Group) String() string { return proto.CompactTextString(m) }
+func (*Group) ProtoMessage()    {}
+func (*Group) Descriptor() ([]byte, []int) {
+	return fileDescriptor_43e42cbf821258b1, []int{5}
+}
+
+func (m *Group) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Group.Unmarshal(m, b)
+}
+func (m *Group) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Group.Marshal(b, m, deterministic)
+}
+func (m *Group) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Group.Merge(m, src)
+}
+func (m *Group) XXX_Size() int {
+	return xxx_messageInfo_Group.Size(m)
+}
+func (m *Group) XXX_DiscardUnknown() {
+	xxx_messageInfo_Group.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Group proto.InternalMessageInfo
+
+func (m *Group) GetLogic() string {
+	if m != nil {
+		return m.Logic
+	}
+	return ""
+}
+
+func (m *Group) GetConditions() []*Condition {
+	if m != nil {
+		return m.Conditions
+	}
+	return nil
+}
+
+func (m *Group) GetGroups() []*Group {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
+}
+
 // Condition 条件查询
 type Condition struct {
 	// Param 参数名，由对象结构层级字段通过'.'组成，如
@@ -379,9 +510,12 @@ type Condition struct {
 	//
 	// key可取'i','in.s'
 	Param string `protobuf:"bytes,1,opt,name=Param,proto3" json:"Param,omitempty"`
-	// Cond 条件 gt/lt/eq/dif 大于/小于/等于/不等
+	// Cond 条件 gt/lt/ge/le/eq/dif 大于/小于/大于等于/小于等于/等于/不等；
+	// in/nin 属于/不属于Value数组；between 介于Value数组的两个值之间，包含边界；
+	// prefix/contains/regex 字符串以Value开头/包含Value/匹配Value正则表达式；
+	// exists/null Value为true时表示字段存在/字段不存在或值为空，为false时取反
 	Cond string `protobuf:"bytes,2,opt,name=Cond,proto3" json:"Cond,omitempty"`
-	// Value 比较对象，支持int、string、float和bool
+	// Value 以json编码的比较对象，支持int、string、float和bool，in/nin/between为数组，字符串需带引号，如"lily"
 	Value                []byte   `protobuf:"bytes,3,opt,name=Value,proto3" json:"Value,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -392,7 +526,7 @@ func (m *Condition) Reset()         { *m = Condition{} }
 func (m *Condition) String() string { return proto.CompactTextString(m) }
 func (*Condition) ProtoMessage()    {}
 func (*Condition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_43e42cbf821258b1, []int{5}
+	return fileDescriptor_43e42cbf821258b1, []int{6}
 }
 
 func (m *Condition) XXX_Unmarshal(b []byte) error {
@@ -450,7 +584,9 @@ type Sort struct {
 	// key可取'i','in.s'
 	Param string `protobuf:"bytes,1,opt,name=Param,proto3" json:"Param,omitempty"`
 	// ASC 是否升序
-	ASC                  bool     `protobuf:"varint,2,opt,name=ASC,proto3" json:"ASC,omitempty"`
+	ASC bool `protobuf:"varint,2,opt,name=ASC,proto3" json:"ASC,omitempty"`
+	// Nulls 字段不存在或值为空的数据排在最前first或最后last，默认视为最小值，即升序时在前、倒序时在后
+	Nulls                string   `protobuf:"bytes,3,opt,name=Nulls,proto3" json:"Nulls,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -460,7 +596,7 @@ func (m *Sort) Reset()         { *m = Sort{} }
 func (m *Sort) String() string { return proto.CompactTextString(m) }
 func (*Sort) ProtoMessage()    {}
 func (*Sort) Descriptor() ([]byte, []int) {
-	return fileDescriptor_43e42cbf821258b1, []int{6}
+	return fileDescriptor_43e42cbf821258b1, []int{7}
 }
 
 func (m *Sort) XXX_Unmarshal(b []byte) error {
@@ -495,6 +631,294 @@ func (m *Sort) GetASC() bool {
 	return false
 }
 
+func (m *Sort) GetNulls() string {
+	if m != nil {
+		return m.Nulls
+	}
+	return ""
+}
+
+// Aggregation 聚合方式，按分组字段对检索结果分组后计算各累加器
+type Aggregation struct {
+	// GroupBy 分组字段，由对象结构层级字段通过'.'组成，为空时所有检索结果为一组
+	GroupBy []string `protobuf:"bytes,1,rep,name=GroupBy,proto3" json:"GroupBy,omitempty"`
+	// Accumulators 累加器集合
+	Accumulators         []*Accumulator `protobuf:"bytes,2,rep,name=Accumulators,proto3" json:"Accumulators,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Aggregation) Reset()         { *m = Aggregation{} }
+func (m *Aggregation) String() string { return proto.CompactTextString(m) }
+func (*Aggregation) ProtoMessage()    {}
+func (*Aggregation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_43e42cbf821258b1, []int{8}
+}
+
+func (m *Aggregation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Aggregation.Unmarshal(m, b)
+}
+func (m *Aggregation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Aggregation.Marshal(b, m, deterministic)
+}
+func (m *Aggregation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Aggregation.Merge(m, src)
+}
+func (m *Aggregation) XXX_Size() int {
+	return xxx_messageInfo_Aggregation.Size(m)
+}
+func (m *Aggregation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Aggregation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Aggregation proto.InternalMessageInfo
+
+func (m *Aggregation) GetGroupBy() []string {
+	if m != nil {
+		return m.GroupBy
+	}
+	return nil
+}
+
+func (m *Aggregation) GetAccumulators() []*Accumulator {
+	if m != nil {
+		return m.Accumulators
+	}
+	return nil
+}
+
+// Accumulator 聚合累加器
+type Accumulator struct {
+	// Name 结果中的字段名称，为空时取'Op_Param'，Param中的'.'替换为'_'
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	// Op 累加方式 count/sum/avg/min/max/distinct，distinct为不为空的不同值数量
+	Op string `protobuf:"bytes,2,opt,name=Op,proto3" json:"Op,omitempty"`
+	// Param 被累加字段，由对象结构层级字段通过'.'组成，仅count可为空，为空时统计行数
+	Param                string   `protobuf:"bytes,3,opt,name=Param,proto3" json:"Param,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Accumulator) Reset()         { *m = Accumulator{} }
+func (m *Accumulator) String() string { return proto.CompactTextString(m) }
+func (*Accumulator) ProtoMessage()    {}
+func (*Accumulator) Descriptor() ([]byte, []int) {
+	return fileDescriptor_43e42cbf821258b1, []int{9}
+}
+
+func (m *Accumulator) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Accumulator.Unmarshal(m, b)
+}
+func (m *Accumulator) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Accumulator.Marshal(b, m, deterministic)
+}
+func (m *Accumulator) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Accumulator.Merge(m, src)
+}
+func (m *Accumulator) XXX_Size() int {
+	return xxx_messageInfo_Accumulator.Size(m)
+}
+func (m *Accumulator) XXX_DiscardUnknown() {
+	xxx_messageInfo_Accumulator.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Accumulator proto.InternalMessageInfo
+
+func (m *Accumulator) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Accumulator) GetOp() string {
+	if m != nil {
+		return m.Op
+	}
+	return ""
+}
+
+func (m *Accumulator) GetParam() string {
+	if m != nil {
+		return m.Param
+	}
+	return ""
+}
+
+// Plan 检索计划
+type Plan struct {
+	// IndexID 所选索引唯一ID
+	IndexID string `protobuf:"bytes,1,opt,name=IndexID,proto3" json:"IndexID,omitempty"`
+	// KeyStructure 所选索引字段名称
+	KeyStructure string `protobuf:"bytes,2,opt,name=KeyStructure,proto3" json:"KeyStructure,omitempty"`
+	// Asc 是否顺序遍历索引
+	Asc bool `protobuf:"varint,3,opt,name=Asc,proto3" json:"Asc,omitempty"`
+	// Pushed 下推至索引树检索的条件
+	Pushed []string `protobuf:"bytes,4,rep,name=Pushed,proto3" json:"Pushed,omitempty"`
+	// Filtered 在叶子节点中逐行过滤的条件及条件组
+	Filtered []string `protobuf:"bytes,5,rep,name=Filtered,proto3" json:"Filtered,omitempty"`
+	// EstimatedRows 估算命中行数
+	EstimatedRows int64 `protobuf:"varint,6,opt,name=EstimatedRows,proto3" json:"EstimatedRows,omitempty"`
+	// TotalRows 所选索引中的数据行数
+	TotalRows int64 `protobuf:"varint,7,opt,name=TotalRows,proto3" json:"TotalRows,omitempty"`
+	// InMemorySort 是否需在检索后全量排序，数据量超出内存预算时借助临时文件归并排序
+	InMemorySort bool `protobuf:"varint,8,opt,name=InMemorySort,proto3" json:"InMemorySort,omitempty"`
+	// Candidates 各候选索引，按选择优先级排列，首个为所选索引
+	Candidates           []*PlanCandidate `protobuf:"bytes,9,rep,name=Candidates,proto3" json:"Candidates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *Plan) Reset()         { *m = Plan{} }
+func (m *Plan) String() string { return proto.CompactTextString(m) }
+func (*Plan) ProtoMessage()    {}
+func (*Plan) Descriptor() ([]byte, []int) {
+	return fileDescriptor_43e42cbf821258b1, []int{10}
+}
+
+func (m *Plan) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Plan.Unmarshal(m, b)
+}
+func (m *Plan) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Plan.Marshal(b, m, deterministic)
+}
+func (m *Plan) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Plan.Merge(m, src)
+}
+func (m *Plan) XXX_Size() int {
+	return xxx_messageInfo_Plan.Size(m)
+}
+func (m *Plan) XXX_DiscardUnknown() {
+	xxx_messageInfo_Plan.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Plan proto.InternalMessageInfo
+
+func (m *Plan) GetIndexID() string {
+	if m != nil {
+		return m.IndexID
+	}
+	return ""
+}
+
+func (m *Plan) GetKeyStructure() string {
+	if m != nil {
+		return m.KeyStructure
+	}
+	return ""
+}
+
+func (m *Plan) GetAsc() bool {
+	if m != nil {
+		return m.Asc
+	}
+	return false
+}
+
+func (m *Plan) GetPushed() []string {
+	if m != nil {
+		return m.Pushed
+	}
+	return nil
+}
+
+func (m *Plan) GetFiltered() []string {
+	if m != nil {
+		return m.Filtered
+	}
+	return nil
+}
+
+func (m *Plan) GetEstimatedRows() int64 {
+	if m != nil {
+		return m.EstimatedRows
+	}
+	return 0
+}
+
+func (m *Plan) GetTotalRows() int64 {
+	if m != nil {
+		return m.TotalRows
+	}
+	return 0
+}
+
+func (m *Plan) GetInMemorySort() bool {
+	if m != nil {
+		return m.InMemorySort
+	}
+	return false
+}
+
+func (m *Plan) GetCandidates() []*PlanCandidate {
+	if m != nil {
+		return m.Candidates
+	}
+	return nil
+}
+
+// PlanCandidate 检索计划的候选索引
+type PlanCandidate struct {
+	// IndexID 索引唯一ID
+	IndexID string `protobuf:"bytes,1,opt,name=IndexID,proto3" json:"IndexID,omitempty"`
+	// KeyStructure 索引字段名称
+	KeyStructure string `protobuf:"bytes,2,opt,name=KeyStructure,proto3" json:"KeyStructure,omitempty"`
+	// EstimatedRows 估算命中行数
+	EstimatedRows        int64    `protobuf:"varint,3,opt,name=EstimatedRows,proto3" json:"EstimatedRows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlanCandidate) Reset()         { *m = PlanCandidate{} }
+func (m *PlanCandidate) String() string { return proto.CompactTextString(m) }
+func (*PlanCandidate) ProtoMessage()    {}
+func (*PlanCandidate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_43e42cbf821258b1, []int{11}
+}
+
+func (m *PlanCandidate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlanCandidate.Unmarshal(m, b)
+}
+func (m *PlanCandidate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlanCandidate.Marshal(b, m, deterministic)
+}
+func (m *PlanCandidate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlanCandidate.Merge(m, src)
+}
+func (m *PlanCandidate) XXX_Size() int {
+	return xxx_messageInfo_PlanCandidate.Size(m)
+}
+func (m *PlanCandidate) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlanCandidate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlanCandidate proto.InternalMessageInfo
+
+func (m *PlanCandidate) GetIndexID() string {
+	if m != nil {
+		return m.IndexID
+	}
+	return ""
+}
+
+func (m *PlanCandidate) GetKeyStructure() string {
+	if m != nil {
+		return m.KeyStructure
+	}
+	return ""
+}
+
+func (m *PlanCandidate) GetEstimatedRows() int64 {
+	if m != nil {
+		return m.EstimatedRows
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("api.FormType", FormType_name, FormType_value)
 	proto.RegisterType((*Lily)(nil), "api.Lily")
@@ -505,44 +929,71 @@ func init() {
 	proto.RegisterMapType((map[string]*Index)(nil), "api.Form.IndexesEntry")
 	proto.RegisterType((*Index)(nil), "api.Index")
 	proto.RegisterType((*Selector)(nil), "api.Selector")
+	proto.RegisterType((*Group)(nil), "api.Group")
 	proto.RegisterType((*Condition)(nil), "api.Condition")
 	proto.RegisterType((*Sort)(nil), "api.Sort")
+	proto.RegisterType((*Aggregation)(nil), "api.Aggregation")
+	proto.RegisterType((*Accumulator)(nil), "api.Accumulator")
+	proto.RegisterType((*Plan)(nil), "api.Plan")
+	proto.RegisterType((*PlanCandidate)(nil), "api.PlanCandidate")
 }
 
 func init() { proto.RegisterFile("connector/grpc/data.proto", fileDescriptor_43e42cbf821258b1) }
 
 var fileDescriptor_43e42cbf821258b1 = []byte{
-	// 507 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0xdf, 0xab, 0xd3, 0x30,
-	0x18, 0x35, 0x6b, 0xeb, 0x6d, 0xbf, 0xbb, 0x8d, 0x11, 0x44, 0xe2, 0x40, 0x56, 0xea, 0xcb, 0x14,
-	0xc9, 0x95, 0x2b, 0x88, 0xf8, 0xe6, 0xdd, 0xbc, 0x30, 0x76, 0x95, 0x91, 0xaa, 0xef, 0x59, 0x17,
-	0x24, 0xdc, 0xfe, 0x22, 0xeb, 0xc4, 0x3e, 0xfb, 0xe8, 0xdf, 0xe4, 0x1f, 0xe4, 0x7f, 0x21, 0x49,
-	0x9b, 0xae, 0xc3, 0xbd, 0xf9, 0xf6, 0x7d, 0xe7, 0x9c, 0x9c, 0xe4, 0x7c, 0x49, 0xe0, 0x49, 0x52,
-	0xe4, 0xb9, 0x48, 0xaa, 0x42, 0x5d, 0x7d, 0x53, 0x65, 0x72, 0xb5, 0xe3, 0x15, 0xa7, 0xa5, 0x2a,
-	0xaa, 0x02, 0x3b, 0xbc, 0x94, 0xd1, 0x2f, 0x04, 0xee, 0x9d, 0x4c, 0x6b, 0xfc, 0x06, 0x02, 0xcd,
-	0x6d, 0xf9, 0x5e, 0xec, 0x09, 0x0a, 0x9d, 0xf9, 0xe5, 0x35, 0xa1, 0xbc, 0x94, 0x54, 0xb3, 0x74,
-	0x69, 0xa9, 0x0f, 0x79, 0xa5, 0x6a, 0x76, 0x94, 0x4e, 0xd7, 0x30, 0x3e, 0x25, 0xf1, 0x04, 0x9c,
-	0x7b, 0x51, 0x13, 0x14, 0xa2, 0x79, 0xc0, 0x74, 0x89, 0x9f, 0x81, 0xf7, 0x9d, 0xa7, 0x07, 0x41,
-	0x06, 0x21, 0x9a, 0x5f, 0x5e, 0x8f, 0x8c, 0xaf, 0x5d, 0xc5, 0x1a, 0xee, 0xdd, 0xe0, 0x2d, 0x8a,
-	0x7e, 0x23, 0xf0, 0x2d, 0x8e, 0xc7, 0x30, 0x58, 0x2d, 0x5b, 0x9b, 0xc1, 0x6a, 0x89, 0x31, 0xb8,
-	0x9f, 0x78, 0xd6, 0x98, 0x04, 0xcc, 0xd4, 0x98, 0xc0, 0xc5, 0xa2, 0xc8, 0x32, 0x91, 0x57, 0xc4,
-	0x31, 0xb0, 0x6d, 0x31, 0x05, 0xef, 0xb6, 0x50, 0xd9, 0x9e, 0xb8, 0xbd, 0x2c, 0xd6, 0x9b, 0x1a,
-	0xaa, 0xc9, 0xd2, 0xc8, 0xa6, 0x0b, 0x80, 0x23, 0x78, 0x26, 0xc3, 0xec, 0x34, 0x43, 0x60, 0xfc,
-	0xf4, 0x8a, 0xfe, 0xf9, 0xff, 0x20, 0x70, 0x35, 0xf6, 0x9f, 0x67, 0x7f, 0x0e, 0xbe, 0x76, 0xf9,
-	0x5c, 0x97, 0x82, 0xb8, 0x21, 0x9a, 0x8f, 0xdb, 0x91, 0x59, 0x90, 0x75, 0x34, 0x7e, 0x05, 0x17,
-	0xab, 0x7c, 0x27, 0x7e, 0x88, 0x3d, 0xf1, 0x4c, 0xd0, 0xc7, 0x9d, 0x92, 0xb6, 0x44, 0x13, 0xd3,
-	0xca, 0xa6, 0xb7, 0x30, 0xec, 0x13, 0x67, 0xa2, 0x86, 0xa7, 0x51, 0xc1, 0x38, 0x9a, 0x35, 0xfd,
-	0xac, 0x5f, 0xc0, 0x33, 0xd8, 0x3f, 0x59, 0x09, 0x5c, 0x6c, 0x94, 0xcc, 0xb8, 0xaa, 0x8d, 0x81,
-	0xcf, 0x6c, 0x8b, 0x23, 0x18, 0xae, 0x45, 0x1d, 0x57, 0xea, 0x90, 0x54, 0x07, 0x25, 0xda, 0xd8,
-	0x27, 0x58, 0xf4, 0x13, 0x81, 0x1f, 0x8b, 0xd4, 0x3c, 0x59, 0x4c, 0x01, 0x16, 0x45, 0xbe, 0x93,
-	0x95, 0x2c, 0x72, 0xfb, 0x2a, 0xc7, 0xe6, 0x38, 0x1d, 0xcc, 0x7a, 0x0a, 0x3d, 0xe6, 0xf8, 0x5e,
-	0x96, 0x66, 0xdf, 0x11, 0x33, 0x35, 0x7e, 0x0a, 0x6e, 0x5c, 0xa8, 0x66, 0xc6, 0xf6, 0xde, 0x34,
-	0xc0, 0x0c, 0x8c, 0x1f, 0x81, 0x77, 0x27, 0x33, 0x59, 0x99, 0x41, 0x8f, 0x58, 0xd3, 0x44, 0x6b,
-	0x08, 0x3a, 0x5b, 0x2d, 0xd9, 0x70, 0xc5, 0xb3, 0x36, 0x63, 0xd3, 0xe8, 0xbd, 0xb4, 0xc4, 0x5e,
-	0xa9, 0xae, 0xb5, 0xf2, 0xab, 0x99, 0x9c, 0xde, 0x6c, 0xc8, 0x9a, 0x26, 0xa2, 0xd0, 0x6d, 0x75,
-	0xc6, 0x67, 0x02, 0xce, 0xfb, 0x78, 0xd1, 0x8e, 0x4a, 0x97, 0x2f, 0x66, 0xc7, 0xeb, 0xc7, 0x3e,
-	0xb8, 0xb1, 0xe4, 0xd9, 0xe4, 0x01, 0x0e, 0xc0, 0xfb, 0x68, 0x4a, 0x74, 0xf3, 0x12, 0x66, 0x49,
-	0x4e, 0xf9, 0x56, 0x28, 0x99, 0xd0, 0x54, 0xa6, 0xf5, 0x6e, 0x4b, 0xbb, 0x7f, 0x4e, 0xf5, 0x3f,
-	0xbf, 0x09, 0xf4, 0x53, 0xdf, 0xe8, 0x7f, 0xbe, 0x7d, 0x68, 0xbe, 0xfb, 0xeb, 0xbf, 0x01, 0x00,
-	0x00, 0xff, 0xff, 0xe3, 0xd0, 0xaa, 0xf2, 0x0b, 0x04, 0x00, 0x00,
+	// 851 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xcd, 0x6e, 0xe4, 0x44,
+	0x10, 0xc6, 0x3f, 0x33, 0x63, 0x57, 0x32, 0xa3, 0xa8, 0x85, 0x56, 0x4d, 0x04, 0xca, 0xc8, 0x70,
+	0x18, 0x10, 0xf2, 0xa2, 0x80, 0x10, 0xe2, 0x96, 0xcc, 0x6c, 0x56, 0x51, 0xf6, 0x67, 0xd4, 0xb3,
+	0x70, 0xe3, 0xd0, 0xb1, 0x5b, 0x43, 0x6b, 0x6d, 0xb7, 0xb7, 0x6d, 0x03, 0x7e, 0x06, 0xde, 0x83,
+	0x17, 0xe0, 0xcc, 0x03, 0xf1, 0x06, 0x1c, 0x51, 0x97, 0x7f, 0xc6, 0x26, 0x83, 0xf6, 0x90, 0x5b,
+	0x7f, 0x5f, 0x95, 0xcb, 0xf5, 0x7d, 0xae, 0x72, 0xc3, 0x47, 0x91, 0xca, 0x32, 0x11, 0x95, 0x4a,
+	0x3f, 0xdd, 0xeb, 0x3c, 0x7a, 0x1a, 0xf3, 0x92, 0x87, 0xb9, 0x56, 0xa5, 0x22, 0x0e, 0xcf, 0x65,
+	0xf0, 0xbb, 0x05, 0xee, 0x0b, 0x99, 0xd4, 0xe4, 0x5b, 0xf0, 0x4d, 0xec, 0x9e, 0x17, 0xa2, 0xa0,
+	0xd6, 0xd2, 0x59, 0x9d, 0x5c, 0xd2, 0x90, 0xe7, 0x32, 0x34, 0xd1, 0x70, 0xd3, 0x85, 0x9e, 0x65,
+	0xa5, 0xae, 0xd9, 0x21, 0xf5, 0xfc, 0x0e, 0x16, 0xe3, 0x20, 0x39, 0x03, 0xe7, 0xad, 0xa8, 0xa9,
+	0xb5, 0xb4, 0x56, 0x3e, 0x33, 0x47, 0xf2, 0x29, 0x4c, 0x7e, 0xe1, 0x49, 0x25, 0xa8, 0xbd, 0xb4,
+	0x56, 0x27, 0x97, 0x73, 0xac, 0xdb, 0x3d, 0xc5, 0x9a, 0xd8, 0xf7, 0xf6, 0x77, 0x56, 0xf0, 0x97,
+	0x05, 0x5e, 0xc7, 0x93, 0x05, 0xd8, 0xb7, 0x9b, 0xb6, 0x8c, 0x7d, 0xbb, 0x21, 0x04, 0xdc, 0x57,
+	0x3c, 0x6d, 0x8a, 0xf8, 0x0c, 0xcf, 0x84, 0xc2, 0x6c, 0xad, 0xd2, 0x54, 0x64, 0x25, 0x75, 0x90,
+	0xee, 0x20, 0x09, 0x61, 0x72, 0xa3, 0x74, 0x5a, 0x50, 0x77, 0xa0, 0xa5, 0xab, 0x1d, 0x62, 0xa8,
+	0xd1, 0xd2, 0xa4, 0x9d, 0xaf, 0x01, 0x0e, 0xe4, 0x11, 0x0d, 0x17, 0x63, 0x0d, 0x3e, 0xd6, 0x33,
+	0x4f, 0x0c, 0xfb, 0xff, 0xdb, 0x02, 0xd7, 0x70, 0x8f, 0xec, 0xfd, 0x73, 0xf0, 0x4c, 0x95, 0x37,
+	0x75, 0x2e, 0xa8, 0xbb, 0xb4, 0x56, 0x8b, 0xd6, 0xb2, 0x8e, 0x64, 0x7d, 0x98, 0x7c, 0x05, 0xb3,
+	0xdb, 0x2c, 0x16, 0xbf, 0x89, 0x82, 0x4e, 0x50, 0xe8, 0x93, 0x3e, 0x33, 0x6c, 0x03, 0x8d, 0xcc,
+	0x2e, 0xed, 0xfc, 0x06, 0x4e, 0x87, 0x81, 0x23, 0x52, 0x97, 0x63, 0xa9, 0x80, 0x15, 0xf1, 0x99,
+	0xa1, 0xd6, 0x3f, 0x2d, 0x98, 0x20, 0xf9, 0x40, 0x2c, 0x85, 0xd9, 0x56, 0xcb, 0x94, 0xeb, 0x1a,
+	0x2b, 0x78, 0xac, 0x83, 0x24, 0x80, 0xd3, 0x3b, 0x51, 0xef, 0x4a, 0x5d, 0x45, 0x65, 0xa5, 0x45,
+	0xab, 0x7b, 0xc4, 0x91, 0x73, 0xf0, 0xae, 0x2b, 0x99, 0xc4, 0x32, 0xdb, 0xa3, 0x78, 0x8f, 0xf5,
+	0xd8, 0xd8, 0xb8, 0x51, 0x99, 0xa0, 0x93, 0xa5, 0xb5, 0x72, 0x19, 0x9e, 0xc9, 0x87, 0x30, 0x79,
+	0xa3, 0x4a, 0x9e, 0xd0, 0x29, 0x92, 0x0d, 0x20, 0x4f, 0x60, 0xfa, 0x43, 0x26, 0xdf, 0x55, 0x82,
+	0xce, 0xb0, 0x46, 0x8b, 0x82, 0x7f, 0x2c, 0xf0, 0x76, 0x22, 0xc1, 0x8d, 0x20, 0x21, 0xc0, 0x5a,
+	0x65, 0xb1, 0x2c, 0xa5, 0xca, 0xba, 0xa1, 0x5f, 0xa0, 0xda, 0x9e, 0x66, 0x83, 0x0c, 0xf3, 0xfa,
+	0xdd, 0x5b, 0x99, 0xa3, 0xaa, 0x39, 0xc3, 0x33, 0xf9, 0x04, 0xdc, 0x9d, 0xd2, 0xcd, 0x27, 0xec,
+	0xc6, 0xc2, 0x10, 0x0c, 0x69, 0xd3, 0xdd, 0x0b, 0x99, 0xca, 0x12, 0xa5, 0xcc, 0x59, 0x03, 0x48,
+	0x00, 0xd3, 0xe7, 0x5a, 0x55, 0x79, 0xf7, 0xd1, 0x1a, 0x8b, 0x91, 0x62, 0x6d, 0xc4, 0x28, 0xb8,
+	0x91, 0x22, 0x89, 0x0b, 0x3a, 0x5d, 0x3a, 0x2b, 0x9f, 0xb5, 0xc8, 0xf0, 0xeb, 0x4a, 0x17, 0x4a,
+	0xa3, 0x32, 0x9f, 0xb5, 0xc8, 0x0c, 0xa8, 0x79, 0x63, 0x41, 0xbd, 0xa5, 0x33, 0xee, 0xa4, 0xe1,
+	0x83, 0x77, 0x30, 0xc1, 0xd2, 0xd8, 0x93, 0xda, 0xcb, 0xa8, 0xfd, 0x64, 0x0d, 0xf8, 0x8f, 0x19,
+	0xf6, 0x7b, 0xcd, 0x38, 0x68, 0x70, 0xfe, 0x4f, 0x43, 0x70, 0x07, 0x7e, 0xff, 0x84, 0x79, 0xed,
+	0x96, 0x6b, 0x9e, 0x76, 0xaf, 0x45, 0x60, 0x3c, 0x35, 0x29, 0xdd, 0x66, 0x98, 0xb3, 0xc9, 0xfc,
+	0x11, 0x07, 0xd0, 0x98, 0x7a, 0xca, 0x1a, 0x10, 0x6c, 0xa0, 0xb7, 0xf4, 0x48, 0x9d, 0x33, 0x70,
+	0xae, 0x76, 0xeb, 0x76, 0xe0, 0xcc, 0xd1, 0xe4, 0xbd, 0xaa, 0x92, 0xa4, 0x68, 0xa7, 0xac, 0x01,
+	0xc1, 0x4f, 0x70, 0x72, 0xb5, 0xdf, 0x6b, 0xb1, 0xe7, 0xd8, 0x14, 0x85, 0x19, 0xf6, 0x7a, 0x5d,
+	0xe3, 0xf7, 0xf7, 0x59, 0x07, 0xc9, 0x37, 0x70, 0x7a, 0x15, 0x45, 0x55, 0x5a, 0x25, 0xbc, 0x54,
+	0xba, 0x73, 0xe4, 0x0c, 0x55, 0x0e, 0x02, 0x6c, 0x94, 0x15, 0x3c, 0x87, 0x93, 0x01, 0xee, 0xf7,
+	0xde, 0x1a, 0xec, 0xfd, 0x02, 0xec, 0xd7, 0x79, 0xab, 0xd7, 0x7e, 0x9d, 0x1f, 0xf4, 0x38, 0x03,
+	0x3d, 0xc1, 0x1f, 0x36, 0xb8, 0xdb, 0x84, 0x63, 0x87, 0xb8, 0x66, 0xfd, 0x8a, 0x75, 0xf0, 0xc1,
+	0x36, 0xd9, 0x47, 0xb6, 0xc9, 0xd8, 0x52, 0x44, 0xd4, 0x69, 0x6d, 0x29, 0x22, 0x33, 0x3f, 0xdb,
+	0xaa, 0xf8, 0x59, 0xc4, 0xf8, 0x67, 0xf4, 0x59, 0x8b, 0xcc, 0xde, 0xdd, 0xc8, 0xa4, 0x14, 0x5a,
+	0xc4, 0x38, 0x95, 0x3e, 0xeb, 0x31, 0xf9, 0x0c, 0xe6, 0xcf, 0x8a, 0x52, 0xa6, 0xbc, 0x14, 0x31,
+	0x53, 0xbf, 0x16, 0xb8, 0x6b, 0x0e, 0x1b, 0x93, 0xe4, 0x63, 0xf0, 0x71, 0xf9, 0x30, 0x63, 0x86,
+	0x19, 0x07, 0xc2, 0x74, 0x7b, 0x9b, 0xbd, 0x14, 0xa9, 0xd2, 0x35, 0x2e, 0x8c, 0x87, 0x2d, 0x8d,
+	0x38, 0x72, 0x09, 0xb0, 0xe6, 0x59, 0x2c, 0x63, 0x5e, 0x8a, 0x82, 0xfa, 0xe8, 0x38, 0x41, 0xc7,
+	0x8d, 0x15, 0x7d, 0x88, 0x0d, 0xb2, 0x82, 0x02, 0xe6, 0xa3, 0xe0, 0x23, 0x0d, 0x7b, 0x20, 0xd5,
+	0x39, 0x22, 0xf5, 0x8b, 0x8b, 0xc3, 0x1f, 0x9a, 0x78, 0xe0, 0xee, 0x24, 0x4f, 0xcf, 0x3e, 0x20,
+	0x3e, 0x4c, 0x5e, 0xe2, 0xd1, 0xba, 0xfe, 0x12, 0x2e, 0xa2, 0x2c, 0xe4, 0xf7, 0x42, 0xcb, 0x28,
+	0x4c, 0x64, 0x52, 0xc7, 0xf7, 0x61, 0x7f, 0x15, 0x87, 0xe6, 0x2a, 0xbe, 0xf6, 0xcd, 0x6d, 0xb4,
+	0x35, 0x57, 0xf1, 0xfd, 0x14, 0x6f, 0xe4, 0xaf, 0xff, 0x1d, 0x00, 0x89, 0xd3, 0x16, 0x5b, 0xae,
+	0x07, 0x00, 0x00,
 }
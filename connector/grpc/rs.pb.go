@@ -18,7 +18,7 @@ var _ = math.Inf
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 // Code 响应结果码
 type Code int32
@@ -514,7 +514,9 @@ type ReqCreateIndex struct {
 	// FormName 表名称
 	FormName string `protobuf:"bytes,2,opt,name=FormName,proto3" json:"FormName,omitempty"`
 	// Comment 主键结构名，按照规范结构组成的主键字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'
-	KeyStructure         string   `protobuf:"bytes,3,opt,name=KeyStructure,proto3" json:"KeyStructure,omitempty"`
+	KeyStructure string `protobuf:"bytes,3,opt,name=KeyStructure,proto3" json:"KeyStructure,omitempty"`
+	// Unique 是否唯一索引
+	Unique               bool     `protobuf:"varint,4,opt,name=Unique,proto3" json:"Unique,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -566,6 +568,381 @@ func (m *ReqCreateIndex) GetKeyStructure() string {
 	return ""
 }
 
+func (m *ReqCreateIndex) GetUnique() bool {
+	if m != nil {
+		return m.Unique
+	}
+	return false
+}
+
+// ReqRenameDatabase 请求修改数据库名
+type ReqRenameDatabase struct {
+	// Name 数据库名称
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	// NewName 新数据库名称
+	NewName              string   `protobuf:"bytes,2,opt,name=NewName,proto3" json:"NewName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqRenameDatabase) Reset()         { *m = ReqRenameDatabase{} }
+func (m *ReqRenameDatabase) String() string { return proto.CompactTextString(m) }
+func (*ReqRenameDatabase) ProtoMessage()    {}
+func (*ReqRenameDatabase) Descriptor() ([]byte, []int) {
+	return fileDescriptor_674682bf8ffb71fc, []int{10}
+}
+
+func (m *ReqRenameDatabase) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqRenameDatabase.Unmarshal(m, b)
+}
+func (m *ReqRenameDatabase) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqRenameDatabase.Marshal(b, m, deterministic)
+}
+func (m *ReqRenameDatabase) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqRenameDatabase.Merge(m, src)
+}
+func (m *ReqRenameDatabase) XXX_Size() int {
+	return xxx_messageInfo_ReqRenameDatabase.Size(m)
+}
+func (m *ReqRenameDatabase) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqRenameDatabase.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqRenameDatabase proto.InternalMessageInfo
+
+func (m *ReqRenameDatabase) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ReqRenameDatabase) GetNewName() string {
+	if m != nil {
+		return m.NewName
+	}
+	return ""
+}
+
+// ReqCommentDatabase 请求修改数据库描述
+type ReqCommentDatabase struct {
+	// Name 数据库名称
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	// Comment 数据库描述
+	Comment              string   `protobuf:"bytes,2,opt,name=Comment,proto3" json:"Comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqCommentDatabase) Reset()         { *m = ReqCommentDatabase{} }
+func (m *ReqCommentDatabase) String() string { return proto.CompactTextString(m) }
+func (*ReqCommentDatabase) ProtoMessage()    {}
+func (*ReqCommentDatabase) Descriptor() ([]byte, []int) {
+	return fileDescriptor_674682bf8ffb71fc, []int{11}
+}
+
+func (m *ReqCommentDatabase) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqCommentDatabase.Unmarshal(m, b)
+}
+func (m *ReqCommentDatabase) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqCommentDatabase.Marshal(b, m, deterministic)
+}
+func (m *ReqCommentDatabase) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqCommentDatabase.Merge(m, src)
+}
+func (m *ReqCommentDatabase) XXX_Size() int {
+	return xxx_messageInfo_ReqCommentDatabase.Size(m)
+}
+func (m *ReqCommentDatabase) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqCommentDatabase.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqCommentDatabase proto.InternalMessageInfo
+
+func (m *ReqCommentDatabase) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ReqCommentDatabase) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+// ReqRenameForm 请求修改表名
+type ReqRenameForm struct {
+	// DatabaseName 数据库名称
+	DatabaseName string `protobuf:"bytes,1,opt,name=DatabaseName,proto3" json:"DatabaseName,omitempty"`
+	// Name 表名称
+	Name string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	// NewName 新表名称
+	NewName              string   `protobuf:"bytes,3,opt,name=NewName,proto3" json:"NewName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqRenameForm) Reset()         { *m = ReqRenameForm{} }
+func (m *ReqRenameForm) String() string { return proto.CompactTextString(m) }
+func (*ReqRenameForm) ProtoMessage()    {}
+func (*ReqRenameForm) Descriptor() ([]byte, []int) {
+	return fileDescriptor_674682bf8ffb71fc, []int{12}
+}
+
+func (m *ReqRenameForm) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqRenameForm.Unmarshal(m, b)
+}
+func (m *ReqRenameForm) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqRenameForm.Marshal(b, m, deterministic)
+}
+func (m *ReqRenameForm) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqRenameForm.Merge(m, src)
+}
+func (m *ReqRenameForm) XXX_Size() int {
+	return xxx_messageInfo_ReqRenameForm.Size(m)
+}
+func (m *ReqRenameForm) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqRenameForm.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqRenameForm proto.InternalMessageInfo
+
+func (m *ReqRenameForm) GetDatabaseName() string {
+	if m != nil {
+		return m.DatabaseName
+	}
+	return ""
+}
+
+func (m *ReqRenameForm) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ReqRenameForm) GetNewName() string {
+	if m != nil {
+		return m.NewName
+	}
+	return ""
+}
+
+// ReqCommentForm 请求修改表描述
+type ReqCommentForm struct {
+	// DatabaseName 数据库名称
+	DatabaseName string `protobuf:"bytes,1,opt,name=DatabaseName,proto3" json:"DatabaseName,omitempty"`
+	// Name 表名称
+	Name string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	// Comment 表描述
+	Comment              string   `protobuf:"bytes,3,opt,name=Comment,proto3" json:"Comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqCommentForm) Reset()         { *m = ReqCommentForm{} }
+func (m *ReqCommentForm) String() string { return proto.CompactTextString(m) }
+func (*ReqCommentForm) ProtoMessage()    {}
+func (*ReqCommentForm) Descriptor() ([]byte, []int) {
+	return fileDescriptor_674682bf8ffb71fc, []int{13}
+}
+
+func (m *ReqCommentForm) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqCommentForm.Unmarshal(m, b)
+}
+func (m *ReqCommentForm) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqCommentForm.Marshal(b, m, deterministic)
+}
+func (m *ReqCommentForm) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqCommentForm.Merge(m, src)
+}
+func (m *ReqCommentForm) XXX_Size() int {
+	return xxx_messageInfo_ReqCommentForm.Size(m)
+}
+func (m *ReqCommentForm) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqCommentForm.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqCommentForm proto.InternalMessageInfo
+
+func (m *ReqCommentForm) GetDatabaseName() string {
+	if m != nil {
+		return m.DatabaseName
+	}
+	return ""
+}
+
+func (m *ReqCommentForm) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ReqCommentForm) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+// ReqDropDatabase 请求删除数据库
+type ReqDropDatabase struct {
+	// Name 数据库名称
+	Name                 string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqDropDatabase) Reset()         { *m = ReqDropDatabase{} }
+func (m *ReqDropDatabase) String() string { return proto.CompactTextString(m) }
+func (*ReqDropDatabase) ProtoMessage()    {}
+func (*ReqDropDatabase) Descriptor() ([]byte, []int) {
+	return fileDescriptor_674682bf8ffb71fc, []int{14}
+}
+
+func (m *ReqDropDatabase) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqDropDatabase.Unmarshal(m, b)
+}
+func (m *ReqDropDatabase) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqDropDatabase.Marshal(b, m, deterministic)
+}
+func (m *ReqDropDatabase) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqDropDatabase.Merge(m, src)
+}
+func (m *ReqDropDatabase) XXX_Size() int {
+	return xxx_messageInfo_ReqDropDatabase.Size(m)
+}
+func (m *ReqDropDatabase) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqDropDatabase.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqDropDatabase proto.InternalMessageInfo
+
+func (m *ReqDropDatabase) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// ReqDropForm 请求删除表
+type ReqDropForm struct {
+	// DatabaseName 数据库名称
+	DatabaseName string `protobuf:"bytes,1,opt,name=DatabaseName,proto3" json:"DatabaseName,omitempty"`
+	// Name 表名称
+	Name                 string   `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqDropForm) Reset()         { *m = ReqDropForm{} }
+func (m *ReqDropForm) String() string { return proto.CompactTextString(m) }
+func (*ReqDropForm) ProtoMessage()    {}
+func (*ReqDropForm) Descriptor() ([]byte, []int) {
+	return fileDescriptor_674682bf8ffb71fc, []int{15}
+}
+
+func (m *ReqDropForm) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqDropForm.Unmarshal(m, b)
+}
+func (m *ReqDropForm) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqDropForm.Marshal(b, m, deterministic)
+}
+func (m *ReqDropForm) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqDropForm.Merge(m, src)
+}
+func (m *ReqDropForm) XXX_Size() int {
+	return xxx_messageInfo_ReqDropForm.Size(m)
+}
+func (m *ReqDropForm) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqDropForm.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqDropForm proto.InternalMessageInfo
+
+func (m *ReqDropForm) GetDatabaseName() string {
+	if m != nil {
+		return m.DatabaseName
+	}
+	return ""
+}
+
+func (m *ReqDropForm) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// ReqDropIndex 请求删除索引
+type ReqDropIndex struct {
+	// DatabaseName 数据库名称
+	DatabaseName string `protobuf:"bytes,1,opt,name=DatabaseName,proto3" json:"DatabaseName,omitempty"`
+	// FormName 表名称
+	FormName string `protobuf:"bytes,2,opt,name=FormName,proto3" json:"FormName,omitempty"`
+	// KeyStructure 索引结构名，按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'
+	KeyStructure         string   `protobuf:"bytes,3,opt,name=KeyStructure,proto3" json:"KeyStructure,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqDropIndex) Reset()         { *m = ReqDropIndex{} }
+func (m *ReqDropIndex) String() string { return proto.CompactTextString(m) }
+func (*ReqDropIndex) ProtoMessage()    {}
+func (*ReqDropIndex) Descriptor() ([]byte, []int) {
+	return fileDescriptor_674682bf8ffb71fc, []int{16}
+}
+
+func (m *ReqDropIndex) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqDropIndex.Unmarshal(m, b)
+}
+func (m *ReqDropIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqDropIndex.Marshal(b, m, deterministic)
+}
+func (m *ReqDropIndex) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqDropIndex.Merge(m, src)
+}
+func (m *ReqDropIndex) XXX_Size() int {
+	return xxx_messageInfo_ReqDropIndex.Size(m)
+}
+func (m *ReqDropIndex) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqDropIndex.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqDropIndex proto.InternalMessageInfo
+
+func (m *ReqDropIndex) GetDatabaseName() string {
+	if m != nil {
+		return m.DatabaseName
+	}
+	return ""
+}
+
+func (m *ReqDropIndex) GetFormName() string {
+	if m != nil {
+		return m.FormName
+	}
+	return ""
+}
+
+func (m *ReqDropIndex) GetKeyStructure() string {
+	if m != nil {
+		return m.KeyStructure
+	}
+	return ""
+}
+
 // ReqPut 新增数据
 type ReqPut struct {
 	// DatabaseName 数据库名称
@@ -585,7 +962,7 @@ func (m *ReqPut) Reset()         { *m = ReqPut{} }
 func (m *ReqPut) String() string { return proto.CompactTextString(m) }
 func (*ReqPut) ProtoMessage()    {}
 func (*ReqPut) Descriptor() ([]byte, []int) {
-	return fileDescriptor_674682bf8ffb71fc, []int{10}
+	return fileDescriptor_674682bf8ffb71fc, []int{17}
 }
 
 func (m *ReqPut) XXX_Unmarshal(b []byte) error {
@@ -651,7 +1028,7 @@ func (m *RespPut) Reset()         { *m = RespPut{} }
 func (m *RespPut) String() string { return proto.CompactTextString(m) }
 func (*RespPut) ProtoMessage()    {}
 func (*RespPut) Descriptor() ([]byte, []int) {
-	return fileDescriptor_674682bf8ffb71fc, []int{11}
+	return fileDescriptor_674682bf8ffb71fc, []int{18}
 }
 
 func (m *RespPut) XXX_Unmarshal(b []byte) error {
@@ -712,7 +1089,7 @@ func (m *ReqSet) Reset()         { *m = ReqSet{} }
 func (m *ReqSet) String() string { return proto.CompactTextString(m) }
 func (*ReqSet) ProtoMessage()    {}
 func (*ReqSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_674682bf8ffb71fc, []int{12}
+	return fileDescriptor_674682bf8ffb71fc, []int{19}
 }
 
 func (m *ReqSet) XXX_Unmarshal(b []byte) error {
@@ -778,7 +1155,7 @@ func (m *RespSet) Reset()         { *m = RespSet{} }
 func (m *RespSet) String() string { return proto.CompactTextString(m) }
 func (*RespSet) ProtoMessage()    {}
 func (*RespSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_674682bf8ffb71fc, []int{13}
+	return fileDescriptor_674682bf8ffb71fc, []int{20}
 }
 
 func (m *RespSet) XXX_Unmarshal(b []byte) error {
@@ -837,7 +1214,7 @@ func (m *ReqGet) Reset()         { *m = ReqGet{} }
 func (m *ReqGet) String() string { return proto.CompactTextString(m) }
 func (*ReqGet) ProtoMessage()    {}
 func (*ReqGet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_674682bf8ffb71fc, []int{14}
+	return fileDescriptor_674682bf8ffb71fc, []int{21}
 }
 
 func (m *ReqGet) XXX_Unmarshal(b []byte) error {
@@ -896,7 +1273,7 @@ func (m *RespGet) Reset()         { *m = RespGet{} }
 func (m *RespGet) String() string { return proto.CompactTextString(m) }
 func (*RespGet) ProtoMessage()    {}
 func (*RespGet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_674682bf8ffb71fc, []int{15}
+	return fileDescriptor_674682bf8ffb71fc, []int{22}
 }
 
 func (m *RespGet) XXX_Unmarshal(b []byte) error {
@@ -957,7 +1334,7 @@ func (m *ReqInsert) Reset()         { *m = ReqInsert{} }
 func (m *ReqInsert) String() string { return proto.CompactTextString(m) }
 func (*ReqInsert) ProtoMessage()    {}
 func (*ReqInsert) Descriptor() ([]byte, []int) {
-	return fileDescriptor_674682bf8ffb71fc, []int{16}
+	return fileDescriptor_674682bf8ffb71fc, []int{23}
 }
 
 func (m *ReqInsert) XXX_Unmarshal(b []byte) error {
@@ -1023,7 +1400,7 @@ func (m *RespInsert) Reset()         { *m = RespInsert{} }
 func (m *RespInsert) String() string { return proto.CompactTextString(m) }
 func (*RespInsert) ProtoMessage()    {}
 func (*RespInsert) Descriptor() ([]byte, []int) {
-	return fileDescriptor_674682bf8ffb71fc, []int{17}
+	return fileDescriptor_674682bf8ffb71fc, []int{24}
 }
 
 func (m *RespInsert) XXX_Unmarshal(b []byte) error {
@@ -1082,7 +1459,7 @@ func (m *ReqSelect) Reset()         { *m = ReqSelect{} }
 func (m *ReqSelect) String() string { return proto.CompactTextString(m) }
 func (*ReqSelect) ProtoMessage()    {}
 func (*ReqSelect) Descriptor() ([]byte, []int) {
-	return fileDescriptor_674682bf8ffb71fc, []int{18}
+	return fileDescriptor_674682bf8ffb71fc, []int{25}
 }
 
 func (m *ReqSelect) XXX_Unmarshal(b []byte) error {
@@ -1133,7 +1510,9 @@ type RespSelect struct {
 	// Value 获取数据结果
 	Value []byte `protobuf:"bytes,3,opt,name=Value,proto3" json:"Value,omitempty"`
 	// ErrMsg 错误信息
-	ErrMsg               string   `protobuf:"bytes,4,opt,name=ErrMsg,proto3" json:"ErrMsg,omitempty"`
+	ErrMsg string `protobuf:"bytes,4,opt,name=ErrMsg,proto3" json:"ErrMsg,omitempty"`
+	// Cursor 下一页的分页游标，已无更多数据时为空
+	Cursor               string   `protobuf:"bytes,5,opt,name=Cursor,proto3" json:"Cursor,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1143,7 +1522,7 @@ func (m *RespSelect) Reset()         { *m = RespSelect{} }
 func (m *RespSelect) String() string { return proto.CompactTextString(m) }
 func (*RespSelect) ProtoMessage()    {}
 func (*RespSelect) Descriptor() ([]byte, []int) {
-	return fileDescriptor_674682bf8ffb71fc, []int{19}
+	return fileDescriptor_674682bf8ffb71fc, []int{26}
 }
 
 func (m *RespSelect) XXX_Unmarshal(b []byte) error {
@@ -1192,6 +1571,326 @@ func (m *RespSelect) GetErrMsg() string {
 	return ""
 }
 
+func (m *RespSelect) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+// RespSelectStream 流式响应获取数据，每条数据单独发送
+type RespSelectStream struct {
+	// Code 响应结果码
+	Code Code `protobuf:"varint,1,opt,name=Code,proto3,enum=api.Code" json:"Code,omitempty"`
+	// Value 单条数据结果
+	Value []byte `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	// ErrMsg 错误信息
+	ErrMsg               string   `protobuf:"bytes,3,opt,name=ErrMsg,proto3" json:"ErrMsg,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RespSelectStream) Reset()         { *m = RespSelectStream{} }
+func (m *RespSelectStream) String() string { return proto.CompactTextString(m) }
+func (*RespSelectStream) ProtoMessage()    {}
+func (*RespSelectStream) Descriptor() ([]byte, []int) {
+	return fileDescriptor_674682bf8ffb71fc, []int{27}
+}
+
+func (m *RespSelectStream) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespSelectStream.Unmarshal(m, b)
+}
+func (m *RespSelectStream) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespSelectStream.Marshal(b, m, deterministic)
+}
+func (m *RespSelectStream) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespSelectStream.Merge(m, src)
+}
+func (m *RespSelectStream) XXX_Size() int {
+	return xxx_messageInfo_RespSelectStream.Size(m)
+}
+func (m *RespSelectStream) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespSelectStream.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespSelectStream proto.InternalMessageInfo
+
+func (m *RespSelectStream) GetCode() Code {
+	if m != nil {
+		return m.Code
+	}
+	return Code_Success
+}
+
+func (m *RespSelectStream) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *RespSelectStream) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
+	}
+	return ""
+}
+
+// ReqAggregate 分组聚合检索结果
+type ReqAggregate struct {
+	// DatabaseName 数据库名称
+	DatabaseName string `protobuf:"bytes,1,opt,name=DatabaseName,proto3" json:"DatabaseName,omitempty"`
+	// FormName 表名称
+	FormName string `protobuf:"bytes,2,opt,name=FormName,proto3" json:"FormName,omitempty"`
+	// selector 条件选择器，Skip、Sort及Limit作用于各分组的聚合结果
+	Selector *Selector `protobuf:"bytes,3,opt,name=Selector,proto3" json:"Selector,omitempty"`
+	// Aggregation 聚合方式
+	Aggregation          *Aggregation `protobuf:"bytes,4,opt,name=Aggregation,proto3" json:"Aggregation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ReqAggregate) Reset()         { *m = ReqAggregate{} }
+func (m *ReqAggregate) String() string { return proto.CompactTextString(m) }
+func (*ReqAggregate) ProtoMessage()    {}
+func (*ReqAggregate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_674682bf8ffb71fc, []int{28}
+}
+
+func (m *ReqAggregate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqAggregate.Unmarshal(m, b)
+}
+func (m *ReqAggregate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqAggregate.Marshal(b, m, deterministic)
+}
+func (m *ReqAggregate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqAggregate.Merge(m, src)
+}
+func (m *ReqAggregate) XXX_Size() int {
+	return xxx_messageInfo_ReqAggregate.Size(m)
+}
+func (m *ReqAggregate) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqAggregate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqAggregate proto.InternalMessageInfo
+
+func (m *ReqAggregate) GetDatabaseName() string {
+	if m != nil {
+		return m.DatabaseName
+	}
+	return ""
+}
+
+func (m *ReqAggregate) GetFormName() string {
+	if m != nil {
+		return m.FormName
+	}
+	return ""
+}
+
+func (m *ReqAggregate) GetSelector() *Selector {
+	if m != nil {
+		return m.Selector
+	}
+	return nil
+}
+
+func (m *ReqAggregate) GetAggregation() *Aggregation {
+	if m != nil {
+		return m.Aggregation
+	}
+	return nil
+}
+
+// RespAggregate 响应分组聚合检索结果
+type RespAggregate struct {
+	// Code 响应结果码
+	Code Code `protobuf:"varint,1,opt,name=Code,proto3,enum=api.Code" json:"Code,omitempty"`
+	// Count 分组总数
+	Count int32 `protobuf:"varint,2,opt,name=Count,proto3" json:"Count,omitempty"`
+	// Value 各分组的聚合结果
+	Value []byte `protobuf:"bytes,3,opt,name=Value,proto3" json:"Value,omitempty"`
+	// ErrMsg 错误信息
+	ErrMsg               string   `protobuf:"bytes,4,opt,name=ErrMsg,proto3" json:"ErrMsg,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RespAggregate) Reset()         { *m = RespAggregate{} }
+func (m *RespAggregate) String() string { return proto.CompactTextString(m) }
+func (*RespAggregate) ProtoMessage()    {}
+func (*RespAggregate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_674682bf8ffb71fc, []int{29}
+}
+
+func (m *RespAggregate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespAggregate.Unmarshal(m, b)
+}
+func (m *RespAggregate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespAggregate.Marshal(b, m, deterministic)
+}
+func (m *RespAggregate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespAggregate.Merge(m, src)
+}
+func (m *RespAggregate) XXX_Size() int {
+	return xxx_messageInfo_RespAggregate.Size(m)
+}
+func (m *RespAggregate) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespAggregate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespAggregate proto.InternalMessageInfo
+
+func (m *RespAggregate) GetCode() Code {
+	if m != nil {
+		return m.Code
+	}
+	return Code_Success
+}
+
+func (m *RespAggregate) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *RespAggregate) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *RespAggregate) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
+	}
+	return ""
+}
+
+// ReqExplain 获取检索计划
+type ReqExplain struct {
+	// DatabaseName 数据库名称
+	DatabaseName string `protobuf:"bytes,1,opt,name=DatabaseName,proto3" json:"DatabaseName,omitempty"`
+	// FormName 表名称
+	FormName string `protobuf:"bytes,2,opt,name=FormName,proto3" json:"FormName,omitempty"`
+	// selector 条件选择器
+	Selector             *Selector `protobuf:"bytes,3,opt,name=Selector,proto3" json:"Selector,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ReqExplain) Reset()         { *m = ReqExplain{} }
+func (m *ReqExplain) String() string { return proto.CompactTextString(m) }
+func (*ReqExplain) ProtoMessage()    {}
+func (*ReqExplain) Descriptor() ([]byte, []int) {
+	return fileDescriptor_674682bf8ffb71fc, []int{30}
+}
+
+func (m *ReqExplain) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqExplain.Unmarshal(m, b)
+}
+func (m *ReqExplain) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqExplain.Marshal(b, m, deterministic)
+}
+func (m *ReqExplain) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqExplain.Merge(m, src)
+}
+func (m *ReqExplain) XXX_Size() int {
+	return xxx_messageInfo_ReqExplain.Size(m)
+}
+func (m *ReqExplain) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqExplain.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqExplain proto.InternalMessageInfo
+
+func (m *ReqExplain) GetDatabaseName() string {
+	if m != nil {
+		return m.DatabaseName
+	}
+	return ""
+}
+
+func (m *ReqExplain) GetFormName() string {
+	if m != nil {
+		return m.FormName
+	}
+	return ""
+}
+
+func (m *ReqExplain) GetSelector() *Selector {
+	if m != nil {
+		return m.Selector
+	}
+	return nil
+}
+
+// RespExplain 响应获取检索计划
+type RespExplain struct {
+	// Code 响应结果码
+	Code Code `protobuf:"varint,1,opt,name=Code,proto3,enum=api.Code" json:"Code,omitempty"`
+	// Plan 检索计划
+	Plan *Plan `protobuf:"bytes,2,opt,name=Plan,proto3" json:"Plan,omitempty"`
+	// ErrMsg 错误信息
+	ErrMsg               string   `protobuf:"bytes,3,opt,name=ErrMsg,proto3" json:"ErrMsg,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RespExplain) Reset()         { *m = RespExplain{} }
+func (m *RespExplain) String() string { return proto.CompactTextString(m) }
+func (*RespExplain) ProtoMessage()    {}
+func (*RespExplain) Descriptor() ([]byte, []int) {
+	return fileDescriptor_674682bf8ffb71fc, []int{31}
+}
+
+func (m *RespExplain) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespExplain.Unmarshal(m, b)
+}
+func (m *RespExplain) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespExplain.Marshal(b, m, deterministic)
+}
+func (m *RespExplain) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespExplain.Merge(m, src)
+}
+func (m *RespExplain) XXX_Size() int {
+	return xxx_messageInfo_RespExplain.Size(m)
+}
+func (m *RespExplain) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespExplain.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespExplain proto.InternalMessageInfo
+
+func (m *RespExplain) GetCode() Code {
+	if m != nil {
+		return m.Code
+	}
+	return Code_Success
+}
+
+func (m *RespExplain) GetPlan() *Plan {
+	if m != nil {
+		return m.Plan
+	}
+	return nil
+}
+
+func (m *RespExplain) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
+	}
+	return ""
+}
+
 // ReqGet 删除数据
 type ReqRemove struct {
 	// DatabaseName 数据库名称
@@ -1209,7 +1908,7 @@ func (m *ReqRemove) Reset()         { *m = ReqRemove{} }
 func (m *ReqRemove) String() string { return proto.CompactTextString(m) }
 func (*ReqRemove) ProtoMessage()    {}
 func (*ReqRemove) Descriptor() ([]byte, []int) {
-	return fileDescriptor_674682bf8ffb71fc, []int{20}
+	return fileDescriptor_674682bf8ffb71fc, []int{32}
 }
 
 func (m *ReqRemove) XXX_Unmarshal(b []byte) error {
@@ -1268,7 +1967,7 @@ func (m *ReqDelete) Reset()         { *m = ReqDelete{} }
 func (m *ReqDelete) String() string { return proto.CompactTextString(m) }
 func (*ReqDelete) ProtoMessage()    {}
 func (*ReqDelete) Descriptor() ([]byte, []int) {
-	return fileDescriptor_674682bf8ffb71fc, []int{21}
+	return fileDescriptor_674682bf8ffb71fc, []int{33}
 }
 
 func (m *ReqDelete) XXX_Unmarshal(b []byte) error {
@@ -1327,7 +2026,7 @@ func (m *RespDelete) Reset()         { *m = RespDelete{} }
 func (m *RespDelete) String() string { return proto.CompactTextString(m) }
 func (*RespDelete) ProtoMessage()    {}
 func (*RespDelete) Descriptor() ([]byte, []int) {
-	return fileDescriptor_674682bf8ffb71fc, []int{22}
+	return fileDescriptor_674682bf8ffb71fc, []int{34}
 }
 
 func (m *RespDelete) XXX_Unmarshal(b []byte) error {
@@ -1384,7 +2083,7 @@ func (m *Resp) Reset()         { *m = Resp{} }
 func (m *Resp) String() string { return proto.CompactTextString(m) }
 func (*Resp) ProtoMessage()    {}
 func (*Resp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_674682bf8ffb71fc, []int{23}
+	return fileDescriptor_674682bf8ffb71fc, []int{35}
 }
 
 func (m *Resp) XXX_Unmarshal(b []byte) error {
@@ -1431,6 +2130,13 @@ func init() {
 	proto.RegisterType((*ReqCreateForm)(nil), "api.ReqCreateForm")
 	proto.RegisterType((*ReqCreateKey)(nil), "api.ReqCreateKey")
 	proto.RegisterType((*ReqCreateIndex)(nil), "api.ReqCreateIndex")
+	proto.RegisterType((*ReqRenameDatabase)(nil), "api.ReqRenameDatabase")
+	proto.RegisterType((*ReqCommentDatabase)(nil), "api.ReqCommentDatabase")
+	proto.RegisterType((*ReqRenameForm)(nil), "api.ReqRenameForm")
+	proto.RegisterType((*ReqCommentForm)(nil), "api.ReqCommentForm")
+	proto.RegisterType((*ReqDropDatabase)(nil), "api.ReqDropDatabase")
+	proto.RegisterType((*ReqDropForm)(nil), "api.ReqDropForm")
+	proto.RegisterType((*ReqDropIndex)(nil), "api.ReqDropIndex")
 	proto.RegisterType((*ReqPut)(nil), "api.ReqPut")
 	proto.RegisterType((*RespPut)(nil), "api.RespPut")
 	proto.RegisterType((*ReqSet)(nil), "api.ReqSet")
@@ -1441,6 +2147,11 @@ func init() {
 	proto.RegisterType((*RespInsert)(nil), "api.RespInsert")
 	proto.RegisterType((*ReqSelect)(nil), "api.ReqSelect")
 	proto.RegisterType((*RespSelect)(nil), "api.RespSelect")
+	proto.RegisterType((*RespSelectStream)(nil), "api.RespSelectStream")
+	proto.RegisterType((*ReqAggregate)(nil), "api.ReqAggregate")
+	proto.RegisterType((*RespAggregate)(nil), "api.RespAggregate")
+	proto.RegisterType((*ReqExplain)(nil), "api.ReqExplain")
+	proto.RegisterType((*RespExplain)(nil), "api.RespExplain")
 	proto.RegisterType((*ReqRemove)(nil), "api.ReqRemove")
 	proto.RegisterType((*ReqDelete)(nil), "api.ReqDelete")
 	proto.RegisterType((*RespDelete)(nil), "api.RespDelete")
@@ -1450,45 +2161,56 @@ func init() {
 func init() { proto.RegisterFile("connector/grpc/rs.proto", fileDescriptor_674682bf8ffb71fc) }
 
 var fileDescriptor_674682bf8ffb71fc = []byte{
-	// 628 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0x41, 0x4f, 0xdb, 0x4c,
-	0x10, 0xfd, 0x92, 0x18, 0x12, 0x4f, 0x00, 0xf1, 0xad, 0xaa, 0xd6, 0xa5, 0xaa, 0x88, 0xf6, 0x14,
-	0xa8, 0x64, 0x24, 0x7a, 0xee, 0xa1, 0x84, 0x42, 0x11, 0x6a, 0x85, 0x36, 0x15, 0x52, 0x91, 0x38,
-	0x6c, 0x9c, 0x81, 0x46, 0x4a, 0xbc, 0xce, 0xae, 0x83, 0x9a, 0xff, 0xd0, 0x1f, 0x5d, 0xcd, 0xae,
-	0xed, 0x24, 0x88, 0xc8, 0xa8, 0x40, 0x6e, 0x9e, 0x79, 0x9e, 0x79, 0xef, 0xcd, 0x6c, 0x9c, 0x85,
-	0x37, 0x91, 0x8a, 0x63, 0x8c, 0x52, 0xa5, 0x0f, 0x6e, 0x75, 0x12, 0x1d, 0x68, 0x13, 0x26, 0x5a,
-	0xa5, 0x8a, 0xd5, 0x64, 0x32, 0xd8, 0x79, 0x7b, 0x0f, 0xed, 0xcb, 0x54, 0x3a, 0x7c, 0xe7, 0xdd,
-	0x3d, 0x28, 0x52, 0xf1, 0xcd, 0xe0, 0xd6, 0x81, 0xdc, 0x87, 0xba, 0xc0, 0x71, 0x47, 0xc5, 0x37,
-	0xbc, 0x07, 0x0d, 0x81, 0x26, 0xa1, 0x67, 0xf6, 0x1e, 0xbc, 0x8e, 0xea, 0x63, 0x50, 0x69, 0x55,
-	0xda, 0x5b, 0x87, 0x7e, 0x28, 0x93, 0x41, 0x48, 0x09, 0x61, 0xd3, 0x6c, 0x97, 0xe0, 0xf8, 0x26,
-	0xa8, 0xb6, 0x2a, 0xed, 0xe6, 0x61, 0x33, 0x83, 0xa9, 0xad, 0xb0, 0x00, 0x7b, 0x0d, 0xeb, 0x5f,
-	0xb4, 0xfe, 0x66, 0x6e, 0x83, 0x5a, 0xab, 0xd2, 0xf6, 0x45, 0x16, 0xf1, 0x2d, 0xd8, 0x10, 0x38,
-	0x3e, 0x96, 0xa9, 0xec, 0x49, 0x83, 0x86, 0x1b, 0xd8, 0x24, 0xce, 0x22, 0x51, 0x46, 0xfc, 0x01,
-	0xfc, 0xe2, 0xdd, 0xa0, 0xda, 0xaa, 0xb5, 0x9b, 0x87, 0x9b, 0xf6, 0x9d, 0x3c, 0x2b, 0x66, 0xf8,
-	0x52, 0x11, 0x21, 0x19, 0x1d, 0x9f, 0x28, 0x3d, 0x32, 0x8c, 0xc3, 0x46, 0x5e, 0xf0, 0x5d, 0x8e,
-	0x1c, 0xaf, 0x2f, 0x16, 0x72, 0x3c, 0x02, 0x9f, 0x44, 0xba, 0x82, 0xd2, 0xc9, 0xac, 0xd9, 0xf7,
-	0x32, 0x71, 0x0e, 0xa7, 0x8c, 0x70, 0xf9, 0xa5, 0xa2, 0x3e, 0xc3, 0xff, 0xb4, 0x08, 0x8d, 0x32,
-	0xc5, 0x9c, 0x9d, 0x31, 0xf0, 0xe6, 0x54, 0xd9, 0x67, 0x16, 0x40, 0xbd, 0xa3, 0x46, 0x23, 0x8c,
-	0x53, 0x3b, 0x7e, 0x5f, 0xe4, 0x21, 0xff, 0x53, 0xa1, 0x69, 0x66, 0x3d, 0x88, 0xed, 0x31, 0xee,
-	0x0a, 0x8e, 0xea, 0xc3, 0x1c, 0xb5, 0x05, 0x0e, 0xb6, 0x07, 0x0d, 0xea, 0xfc, 0x63, 0x9a, 0x60,
-	0xe0, 0xd9, 0x11, 0x6c, 0x16, 0x16, 0x29, 0x29, 0x0a, 0x98, 0x6b, 0xbb, 0x6b, 0xa7, 0xe6, 0x1c,
-	0xa7, 0x8f, 0x12, 0xb3, 0xe3, 0xda, 0xcf, 0x09, 0x2a, 0x62, 0xaa, 0x3f, 0xc7, 0x69, 0x37, 0xd5,
-	0x93, 0x28, 0x9d, 0x68, 0xcc, 0x94, 0x2d, 0xe4, 0x78, 0x0a, 0x5b, 0x05, 0xe7, 0x59, 0xdc, 0xc7,
-	0xdf, 0x2b, 0x61, 0x4d, 0x60, 0x5d, 0xe0, 0xf8, 0x62, 0x92, 0x3e, 0x99, 0x6d, 0x1b, 0x6a, 0xe7,
-	0x38, 0xcd, 0x48, 0xe8, 0x91, 0xbd, 0x82, 0xb5, 0x4b, 0x39, 0x9c, 0xb8, 0x69, 0x6f, 0x08, 0x17,
-	0xf0, 0x2b, 0xfa, 0xd9, 0x9a, 0x84, 0x28, 0x4b, 0x0e, 0x64, 0x00, 0xf5, 0xaf, 0xd2, 0xfc, 0xa2,
-	0xae, 0x44, 0xe6, 0x89, 0x3c, 0x5c, 0x7a, 0x12, 0x9d, 0x9b, 0x2e, 0xae, 0xdc, 0x0d, 0x51, 0x3e,
-	0xbb, 0x9b, 0x2b, 0xeb, 0xe6, 0xf4, 0x25, 0xdc, 0xf0, 0x4b, 0xa7, 0xfb, 0xb4, 0x5c, 0x77, 0xe1,
-	0xbb, 0x3a, 0xe7, 0x7b, 0xa9, 0x66, 0x43, 0x1f, 0x9c, 0xf1, 0x59, 0x6c, 0x50, 0xaf, 0x6e, 0x09,
-	0xd7, 0x00, 0x64, 0x26, 0x63, 0x7d, 0xf6, 0x3d, 0xdc, 0x59, 0x4f, 0x5d, 0x1c, 0x62, 0xf4, 0x74,
-	0x4f, 0x7b, 0xd0, 0x70, 0x9d, 0x94, 0xb6, 0x34, 0xf9, 0xbf, 0x40, 0x9e, 0x14, 0x05, 0xcc, 0x95,
-	0xb3, 0x95, 0x11, 0x97, 0xaf, 0xa9, 0xa3, 0x26, 0xd9, 0x97, 0x75, 0x4d, 0xb8, 0x60, 0x36, 0xaf,
-	0xda, 0xc3, 0xcb, 0xf3, 0x16, 0x8c, 0x5e, 0x5b, 0xa3, 0x02, 0x47, 0xea, 0x0e, 0x5f, 0xe0, 0xcc,
-	0xb9, 0x39, 0x1e, 0xe3, 0x10, 0x53, 0x5c, 0xe5, 0x1c, 0x7f, 0xba, 0x39, 0x66, 0xc4, 0xff, 0x34,
-	0xc7, 0x65, 0x47, 0xe3, 0x13, 0x78, 0xd4, 0xba, 0xac, 0xe9, 0xac, 0xbc, 0x3a, 0x5f, 0xbe, 0x9f,
-	0x95, 0xb1, 0x26, 0xd4, 0xbb, 0x93, 0x28, 0x42, 0x63, 0xb6, 0xff, 0x63, 0x0d, 0xf0, 0x4e, 0xe4,
-	0x60, 0xb8, 0x5d, 0x39, 0xda, 0x87, 0xdd, 0x28, 0x0e, 0x65, 0x0f, 0xf5, 0x20, 0x0a, 0x87, 0x83,
-	0xe1, 0xb4, 0xdf, 0x0b, 0x8b, 0x1b, 0x51, 0x48, 0x37, 0xa2, 0xa3, 0xba, 0xe8, 0x5e, 0xd0, 0x6d,
-	0xa8, 0xb7, 0x6e, 0x2f, 0x45, 0x1f, 0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0x12, 0x9d, 0xd9, 0x0c,
-	0x6c, 0x09, 0x00, 0x00,
+	// 813 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0xdb, 0x6e, 0xc2, 0x46,
+	0x10, 0xad, 0xc1, 0x04, 0x18, 0x20, 0xa5, 0x56, 0xd5, 0xba, 0xa9, 0xa2, 0xa0, 0x95, 0x2a, 0x91,
+	0x54, 0x72, 0x24, 0xfa, 0xdc, 0x87, 0x84, 0x5c, 0x1a, 0x45, 0x8d, 0xa2, 0xa5, 0x8d, 0xd4, 0x48,
+	0x51, 0xb5, 0x98, 0x09, 0xb5, 0x64, 0x6c, 0xb3, 0x36, 0x49, 0xf8, 0x82, 0xbe, 0xf4, 0x57, 0xfa,
+	0x8f, 0xd5, 0x5e, 0x6c, 0x4c, 0x14, 0xea, 0x28, 0x04, 0xde, 0x3c, 0x33, 0xbb, 0x73, 0xce, 0x99,
+	0x19, 0xaf, 0xbd, 0xf0, 0xad, 0x1b, 0x06, 0x01, 0xba, 0x49, 0xc8, 0x8f, 0xc7, 0x3c, 0x72, 0x8f,
+	0x79, 0xec, 0x44, 0x3c, 0x4c, 0x42, 0xab, 0xcc, 0x22, 0x6f, 0xef, 0xbb, 0x57, 0xd1, 0x11, 0x4b,
+	0x98, 0x8a, 0xef, 0x7d, 0xff, 0x2a, 0xe4, 0x86, 0xc1, 0xa3, 0x37, 0x56, 0x41, 0x52, 0x87, 0x2a,
+	0xc5, 0x69, 0x3f, 0x0c, 0x1e, 0xc9, 0x10, 0x6a, 0x14, 0xe3, 0x48, 0x3c, 0x5b, 0xfb, 0x60, 0xf6,
+	0xc3, 0x11, 0xda, 0x46, 0xc7, 0xe8, 0xee, 0xf6, 0xea, 0x0e, 0x8b, 0x3c, 0x47, 0x38, 0xa8, 0x74,
+	0x5b, 0x07, 0x22, 0x1c, 0x3c, 0xda, 0xa5, 0x8e, 0xd1, 0x6d, 0xf4, 0x1a, 0x3a, 0x2c, 0xd2, 0x52,
+	0x19, 0xb0, 0xbe, 0x81, 0x9d, 0x73, 0xce, 0x7f, 0x8d, 0xc7, 0x76, 0xb9, 0x63, 0x74, 0xeb, 0x54,
+	0x5b, 0x64, 0x17, 0x9a, 0x14, 0xa7, 0x67, 0x2c, 0x61, 0x43, 0x16, 0x63, 0x4c, 0x62, 0x68, 0x09,
+	0xcc, 0xcc, 0x51, 0x04, 0xfc, 0x23, 0xd4, 0xb3, 0xb5, 0x76, 0xa9, 0x53, 0xee, 0x36, 0x7a, 0x2d,
+	0xb9, 0x26, 0xf5, 0xd2, 0x45, 0x7c, 0x25, 0x09, 0x47, 0x08, 0x9d, 0x5e, 0x84, 0x7c, 0x12, 0x5b,
+	0x04, 0x9a, 0xe9, 0x86, 0x1b, 0x36, 0x51, 0xb8, 0x75, 0xba, 0xe4, 0x23, 0x2e, 0xd4, 0x05, 0x49,
+	0xb5, 0xa1, 0xb0, 0x32, 0x15, 0xb9, 0x4e, 0x93, 0x53, 0x71, 0xe1, 0xa1, 0xca, 0xbf, 0x92, 0xd4,
+	0x09, 0x7c, 0x25, 0x1a, 0xc1, 0x91, 0x25, 0x98, 0xa2, 0x5b, 0x16, 0x98, 0x39, 0x56, 0xf2, 0xd9,
+	0xb2, 0xa1, 0xda, 0x0f, 0x27, 0x13, 0x0c, 0x12, 0x59, 0xfe, 0x3a, 0x4d, 0x4d, 0xf2, 0x8f, 0x01,
+	0xad, 0x2c, 0x87, 0x40, 0x7b, 0x8f, 0xba, 0x0c, 0xa3, 0xf4, 0x36, 0x46, 0x79, 0x09, 0xc3, 0x3a,
+	0x84, 0x9a, 0xc8, 0xfc, 0xdb, 0x3c, 0x42, 0xdb, 0x94, 0x25, 0x68, 0x65, 0x12, 0x85, 0x93, 0x66,
+	0x61, 0xc2, 0xa1, 0x99, 0xb1, 0xb9, 0xc6, 0xf9, 0xbb, 0xc8, 0xec, 0xa9, 0xf4, 0x39, 0x42, 0x99,
+	0x2d, 0xf6, 0x5f, 0xe3, 0x7c, 0x90, 0xf0, 0x99, 0x9b, 0xcc, 0x38, 0x6a, 0x66, 0x4b, 0x3e, 0x51,
+	0x82, 0xdd, 0x0c, 0xf4, 0x2a, 0x18, 0xe1, 0xcb, 0x36, 0x60, 0x45, 0x53, 0x7f, 0x0f, 0xbc, 0xe9,
+	0x4c, 0xd5, 0xa4, 0x46, 0xb5, 0xa5, 0x9b, 0x4a, 0x31, 0x60, 0x93, 0xc2, 0xa6, 0xde, 0xe0, 0x73,
+	0x0e, 0x3f, 0x35, 0xc9, 0x29, 0x58, 0xf2, 0x05, 0x95, 0xe5, 0xff, 0xe0, 0x60, 0x30, 0x68, 0x65,
+	0x34, 0xd6, 0x9d, 0x8b, 0x94, 0x66, 0x79, 0x99, 0xe6, 0x50, 0xd5, 0x5d, 0x01, 0x6e, 0x66, 0xf6,
+	0xc8, 0x0f, 0xf0, 0xa5, 0x38, 0x3c, 0x78, 0x18, 0xfd, 0x5f, 0x1d, 0xc8, 0x39, 0x34, 0xf4, 0xb2,
+	0x75, 0x78, 0xe8, 0xf1, 0x15, 0x69, 0xb6, 0x36, 0x47, 0x24, 0x82, 0x1d, 0x8a, 0xd3, 0xdb, 0x59,
+	0xb2, 0x36, 0x5a, 0x1b, 0xca, 0xd7, 0x38, 0xd7, 0x20, 0xe2, 0xd1, 0xfa, 0x1a, 0x2a, 0x77, 0xcc,
+	0xd7, 0x23, 0xda, 0xa4, 0xca, 0x20, 0xf7, 0xe2, 0xfc, 0x8f, 0x23, 0x01, 0x59, 0x70, 0xb2, 0xd9,
+	0x50, 0xfd, 0x85, 0xc5, 0x7f, 0x89, 0xac, 0x02, 0xcc, 0xa4, 0xa9, 0xb9, 0xf2, 0x48, 0x53, 0x6a,
+	0x06, 0xb8, 0x75, 0x35, 0x03, 0xdc, 0x80, 0x9a, 0x7b, 0xa9, 0xe6, 0x72, 0x13, 0x6a, 0xc8, 0x9d,
+	0xe2, 0x7d, 0x59, 0xcc, 0x3b, 0xd3, 0x5d, 0xca, 0xe9, 0x5e, 0xc9, 0x39, 0x16, 0x5f, 0xae, 0xe9,
+	0x55, 0x10, 0x23, 0xdf, 0x5e, 0x13, 0x1e, 0x00, 0x84, 0x18, 0x8d, 0xfa, 0xe9, 0x7d, 0x78, 0x92,
+	0x9a, 0x06, 0xe8, 0xa3, 0xbb, 0xbe, 0xa6, 0x43, 0xa8, 0xa9, 0x4c, 0x21, 0x97, 0x30, 0xe9, 0xef,
+	0x44, 0xea, 0xa4, 0x59, 0x98, 0xfc, 0x6d, 0x28, 0x5d, 0x1a, 0xb9, 0xb8, 0x4f, 0xfd, 0x70, 0xa6,
+	0x8f, 0xe2, 0x0a, 0x55, 0xc6, 0xa2, 0x60, 0xe5, 0xb7, 0xbb, 0x67, 0xe6, 0x95, 0x0a, 0x7f, 0x7f,
+	0xc6, 0xe3, 0x90, 0xdb, 0x15, 0xe5, 0x57, 0x16, 0xf9, 0x13, 0xda, 0x0b, 0x22, 0x83, 0x84, 0x23,
+	0x9b, 0x7c, 0xee, 0xd8, 0xfc, 0x6b, 0xc8, 0xb3, 0xef, 0x64, 0x3c, 0xe6, 0x38, 0x66, 0x09, 0x6e,
+	0xb1, 0xcc, 0x56, 0x0f, 0x1a, 0x29, 0xae, 0x17, 0x06, 0xb2, 0x22, 0x8d, 0x5e, 0x5b, 0xae, 0xce,
+	0xf9, 0x69, 0x7e, 0x11, 0xe1, 0xea, 0x2f, 0x72, 0xc1, 0x77, 0xf3, 0xcd, 0x21, 0xcf, 0x62, 0x1a,
+	0xa6, 0xe7, 0x2f, 0x91, 0xcf, 0xbc, 0x60, 0x9b, 0x73, 0xe8, 0x8a, 0xcf, 0x5b, 0x1c, 0xa5, 0xc8,
+	0x05, 0x52, 0xf7, 0xc1, 0xbc, 0xf5, 0x59, 0xa0, 0xff, 0xd4, 0x55, 0x58, 0x38, 0xa8, 0x74, 0xaf,
+	0x9c, 0x80, 0x07, 0xf9, 0x92, 0x51, 0x9c, 0x84, 0x4f, 0xb8, 0x81, 0xf3, 0x4e, 0xbd, 0xc3, 0x67,
+	0xe8, 0xe3, 0x56, 0x87, 0x8b, 0xfc, 0xa1, 0x5e, 0x61, 0x0d, 0xfc, 0xa1, 0x29, 0x59, 0x55, 0xb1,
+	0x9f, 0xc1, 0x14, 0xa9, 0x8b, 0x92, 0x2e, 0xb6, 0x97, 0xf2, 0xdb, 0x8f, 0xf4, 0x36, 0xab, 0x01,
+	0xd5, 0xc1, 0xcc, 0x75, 0x31, 0x8e, 0xdb, 0x5f, 0x58, 0x35, 0x30, 0x2f, 0x98, 0xe7, 0xb7, 0x8d,
+	0xd3, 0x23, 0x38, 0x70, 0x03, 0x87, 0x0d, 0x91, 0x7b, 0xae, 0xe3, 0x7b, 0xfe, 0x7c, 0x34, 0x74,
+	0xb2, 0x6b, 0x9d, 0x23, 0xae, 0x75, 0xa7, 0x55, 0x3a, 0xb8, 0x15, 0x57, 0xba, 0xe1, 0x8e, 0xbc,
+	0xd9, 0xfd, 0xf4, 0xdf, 0x00, 0xa3, 0x57, 0xd1, 0x00, 0x31, 0x0e, 0x00, 0x00,
 }
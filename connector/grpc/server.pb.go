@@ -4,10 +4,12 @@
 package api
 
 import (
+	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
-	context "golang.org/x/net/context"
 	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
 	math "math"
 )
 
@@ -20,44 +22,53 @@ var _ = math.Inf
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 func init() { proto.RegisterFile("connector/grpc/server.proto", fileDescriptor_3858c8520d9e216e) }
 
 var fileDescriptor_3858c8520d9e216e = []byte{
-	// 357 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x92, 0x41, 0x4b, 0xf3, 0x40,
-	0x10, 0x86, 0x03, 0xfd, 0x48, 0xe9, 0xa4, 0x5f, 0x8b, 0x23, 0x28, 0xc4, 0x83, 0x10, 0x10, 0x84,
-	0xe2, 0x16, 0x14, 0x2f, 0xde, 0x6c, 0x8b, 0xa1, 0x28, 0x18, 0x9a, 0x5f, 0xb0, 0x49, 0x47, 0x09,
-	0xa4, 0x49, 0xba, 0xd9, 0x16, 0xfb, 0xe3, 0x05, 0x49, 0xa6, 0xd9, 0x26, 0x3d, 0xbe, 0xcf, 0x3c,
-	0xef, 0x90, 0x2c, 0x03, 0x37, 0x71, 0x9e, 0x65, 0x14, 0xeb, 0x5c, 0x4d, 0xbf, 0x55, 0x11, 0x4f,
-	0x4b, 0x52, 0x7b, 0x52, 0xa2, 0x50, 0xb9, 0xce, 0xb1, 0x27, 0x8b, 0xc4, 0xbd, 0x3e, 0x33, 0x54,
-	0xc9, 0xd3, 0xc7, 0xdf, 0x7f, 0xd0, 0xff, 0x48, 0xd2, 0xc3, 0x6b, 0xb0, 0xc4, 0x7b, 0xe8, 0xfb,
-	0xa4, 0xe7, 0x79, 0xf6, 0x85, 0x43, 0x21, 0x8b, 0x44, 0xac, 0x68, 0x5b, 0x25, 0xf7, 0xff, 0x31,
-	0x95, 0x45, 0x15, 0x3d, 0x0b, 0x5f, 0x60, 0xfc, 0x19, 0x69, 0x99, 0x64, 0x0b, 0xa9, 0x65, 0x24,
-	0x4b, 0x2a, 0xf1, 0xa2, 0x69, 0x18, 0xe4, 0xa2, 0xa9, 0x19, 0xe6, 0x59, 0x28, 0xc0, 0xe1, 0xee,
-	0x5b, 0xae, 0x36, 0x25, 0x36, 0xbb, 0xb7, 0x75, 0x74, 0x47, 0xa6, 0x53, 0x67, 0xcf, 0xc2, 0x67,
-	0x18, 0xcd, 0x15, 0x49, 0x4d, 0xcd, 0x12, 0xbc, 0x32, 0x1f, 0xd7, 0xe1, 0xee, 0xc0, 0x74, 0x3d,
-	0x0b, 0x1f, 0x00, 0x78, 0x5c, 0xed, 0x41, 0xec, 0x56, 0x2a, 0xd6, 0xd5, 0x27, 0x30, 0xe0, 0xd1,
-	0x3b, 0x1d, 0x4e, 0xff, 0x62, 0x50, 0x57, 0x9e, 0x82, 0xc3, 0x93, 0x65, 0xb6, 0xa6, 0x1f, 0xbc,
-	0xec, 0xea, 0x35, 0xec, 0x16, 0x3c, 0xe8, 0x05, 0x3b, 0x8d, 0x4e, 0x23, 0x06, 0x3b, 0xed, 0x0e,
-	0x8d, 0x10, 0xec, 0x34, 0x3b, 0x21, 0xb5, 0x9c, 0x90, 0xda, 0x4e, 0x48, 0x47, 0xc7, 0x6f, 0x3b,
-	0x7e, 0xc7, 0xf1, 0x6b, 0x67, 0x02, 0xf6, 0x32, 0x2b, 0x49, 0x69, 0x6c, 0xde, 0x72, 0xcb, 0xd9,
-	0x1d, 0x1b, 0x93, 0x01, 0xcb, 0x21, 0xa5, 0x14, 0xb7, 0x64, 0xce, 0x2d, 0x99, 0x81, 0x67, 0xe1,
-	0x1d, 0xd8, 0x2b, 0xda, 0xe4, 0x7b, 0x3a, 0xc9, 0x9c, 0xcf, 0x9f, 0xd2, 0x5e, 0x50, 0x4a, 0xba,
-	0xa5, 0x71, 0x6e, 0xed, 0x64, 0xe0, 0x59, 0x33, 0x01, 0xb7, 0x71, 0x26, 0x64, 0x44, 0x2a, 0x89,
-	0x45, 0x9a, 0xa4, 0x87, 0x75, 0x24, 0xcc, 0xad, 0x8a, 0xea, 0x56, 0x67, 0x4e, 0x58, 0x9f, 0x73,
-	0x50, 0xdd, 0x6b, 0x64, 0xd7, 0x67, 0xfb, 0xf4, 0x17, 0x00, 0x00, 0xff, 0xff, 0x14, 0x51, 0xd5,
-	0xd0, 0xf3, 0x02, 0x00, 0x00,
+	// 501 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x94, 0x6f, 0x8b, 0xda, 0x4e,
+	0x10, 0xc7, 0xf3, 0xe3, 0x40, 0xcf, 0xd1, 0x9f, 0xde, 0xcd, 0xb5, 0x3d, 0x48, 0x1f, 0x14, 0x02,
+	0x85, 0x96, 0xa3, 0xb1, 0xff, 0x8e, 0xd2, 0x3e, 0xbb, 0xd3, 0x56, 0xa4, 0x85, 0x8a, 0x79, 0x05,
+	0x6b, 0x6e, 0x2a, 0x81, 0x64, 0x13, 0x37, 0xeb, 0x71, 0xbe, 0xf4, 0x3e, 0x2b, 0xc9, 0x98, 0xcd,
+	0xae, 0xfa, 0xcc, 0xf9, 0xce, 0xe7, 0x3b, 0xd9, 0xd9, 0x71, 0x07, 0x5e, 0xc6, 0xb9, 0x94, 0x14,
+	0xeb, 0x5c, 0x8d, 0xd7, 0xaa, 0x88, 0xc7, 0x25, 0xa9, 0x47, 0x52, 0x61, 0xa1, 0x72, 0x9d, 0xe3,
+	0x99, 0x28, 0x12, 0xff, 0xfa, 0x80, 0x50, 0x25, 0x67, 0x3f, 0xfe, 0x3d, 0x87, 0xee, 0xaf, 0x24,
+	0xdd, 0xdd, 0x2d, 0xe6, 0xf8, 0x06, 0xba, 0x33, 0xd2, 0x93, 0x5c, 0xfe, 0xc1, 0x41, 0x28, 0x8a,
+	0x24, 0x5c, 0xd2, 0xa6, 0x8a, 0xfc, 0xff, 0xf7, 0x51, 0x59, 0x54, 0x61, 0xe0, 0xe1, 0x37, 0x18,
+	0xfd, 0x5e, 0x69, 0x91, 0xc8, 0xa9, 0xd0, 0x62, 0x25, 0x4a, 0x2a, 0xf1, 0xb2, 0x71, 0x18, 0xc9,
+	0x47, 0x63, 0x33, 0x5a, 0xe0, 0x61, 0x08, 0x7d, 0xf6, 0xfe, 0xc8, 0x55, 0x56, 0x62, 0x53, 0x7b,
+	0x53, 0x87, 0xfe, 0xd0, 0x78, 0xea, 0x38, 0xf0, 0xf0, 0x16, 0x86, 0x13, 0x45, 0x42, 0x53, 0x53,
+	0x04, 0x5f, 0x98, 0xc3, 0x39, 0xba, 0xdf, 0x33, 0xde, 0xc0, 0xc3, 0x77, 0x00, 0x9c, 0xae, 0xea,
+	0x20, 0xba, 0x96, 0x4a, 0x73, 0xf1, 0x1b, 0xe8, 0x71, 0xea, 0x27, 0xed, 0xda, 0x5e, 0x8c, 0xe4,
+	0xc2, 0x63, 0xe8, 0x73, 0x66, 0x2e, 0x1f, 0xe8, 0x09, 0xaf, 0x5c, 0xbc, 0x16, 0x5d, 0xc3, 0x2d,
+	0x0c, 0x97, 0x24, 0x45, 0x76, 0xa2, 0x07, 0x57, 0x77, 0x6d, 0x5f, 0x60, 0x34, 0xc9, 0xb3, 0x8c,
+	0xa4, 0x36, 0xbe, 0xeb, 0x76, 0x30, 0x4e, 0xe2, 0xa8, 0x79, 0xae, 0xeb, 0x36, 0xdf, 0x6a, 0xc7,
+	0xfd, 0x70, 0xb9, 0x9a, 0xbf, 0x3a, 0xf8, 0xc6, 0xb1, 0xe1, 0x03, 0x0c, 0xa6, 0x2a, 0x37, 0x63,
+	0xc5, 0x67, 0x66, 0xf8, 0x96, 0xea, 0x5a, 0xde, 0xc2, 0x79, 0x95, 0xac, 0x3f, 0x70, 0x61, 0xe3,
+	0x27, 0x67, 0x51, 0x25, 0xf8, 0x72, 0x2f, 0x6d, 0xf6, 0xc4, 0xd5, 0x06, 0x70, 0xb6, 0xd8, 0x6a,
+	0xec, 0x37, 0xd8, 0x62, 0xab, 0xfd, 0x81, 0x01, 0x16, 0x5b, 0xcd, 0x4c, 0x44, 0x16, 0x13, 0x91,
+	0xcd, 0x44, 0xb4, 0x67, 0x66, 0x36, 0x33, 0x73, 0x98, 0x59, 0xcd, 0xdc, 0x40, 0x67, 0x2e, 0x4b,
+	0x52, 0x1a, 0x9b, 0xbf, 0xe9, 0x86, 0x63, 0x7f, 0x64, 0x48, 0x16, 0x18, 0x8e, 0x28, 0xa5, 0xd8,
+	0x82, 0x39, 0xb6, 0x60, 0x16, 0x02, 0x0f, 0xbf, 0xc2, 0x80, 0x7f, 0x47, 0x5a, 0x91, 0xc8, 0x8e,
+	0x2c, 0xcf, 0x0f, 0x2c, 0x8c, 0x05, 0xde, 0xfb, 0xff, 0xf0, 0x33, 0xf4, 0xee, 0xd6, 0x6b, 0x45,
+	0x6b, 0xa1, 0xa9, 0xbd, 0x2d, 0x23, 0x59, 0xaf, 0xd0, 0x68, 0xf5, 0x2b, 0xec, 0x7e, 0x7f, 0x2a,
+	0x52, 0x91, 0x48, 0x6c, 0x8e, 0xb3, 0xd9, 0x0b, 0xfe, 0x85, 0x71, 0xec, 0x95, 0xc0, 0xc3, 0xd7,
+	0xd0, 0x59, 0x52, 0x96, 0x3f, 0x52, 0x7b, 0x34, 0x8e, 0x0f, 0x47, 0xd7, 0x99, 0x52, 0x4a, 0xda,
+	0xc2, 0x38, 0xb6, 0x9a, 0x66, 0x21, 0xf0, 0xee, 0x43, 0x78, 0x15, 0xcb, 0x50, 0xac, 0x48, 0x25,
+	0x71, 0x98, 0x26, 0xe9, 0xee, 0x61, 0x15, 0x9a, 0x3d, 0x15, 0x56, 0x7b, 0xea, 0xbe, 0x1f, 0xd5,
+	0xab, 0x6c, 0x51, 0xed, 0xaa, 0x55, 0xa7, 0x5e, 0x59, 0x9f, 0xfe, 0x0d, 0x00, 0x76, 0x9a, 0x61,
+	0x22, 0xef, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ context.Context
-var _ grpc.ClientConn
+var _ grpc.ClientConnInterface
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+const _ = grpc.SupportPackageIsVersion6
 
 // LilyAPIClient is the client API for LilyAPI service.
 //
@@ -77,6 +88,20 @@ type LilyAPIClient interface {
 	CreateKey(ctx context.Context, in *ReqCreateKey, opts ...grpc.CallOption) (*Resp, error)
 	// CreateIndex 新建索引
 	CreateIndex(ctx context.Context, in *ReqCreateIndex, opts ...grpc.CallOption) (*Resp, error)
+	// RenameDatabase 修改数据库名
+	RenameDatabase(ctx context.Context, in *ReqRenameDatabase, opts ...grpc.CallOption) (*Resp, error)
+	// CommentDatabase 修改数据库描述
+	CommentDatabase(ctx context.Context, in *ReqCommentDatabase, opts ...grpc.CallOption) (*Resp, error)
+	// RenameForm 修改表名
+	RenameForm(ctx context.Context, in *ReqRenameForm, opts ...grpc.CallOption) (*Resp, error)
+	// CommentForm 修改表描述
+	CommentForm(ctx context.Context, in *ReqCommentForm, opts ...grpc.CallOption) (*Resp, error)
+	// DropDatabase 删除数据库
+	DropDatabase(ctx context.Context, in *ReqDropDatabase, opts ...grpc.CallOption) (*Resp, error)
+	// DropForm 删除表
+	DropForm(ctx context.Context, in *ReqDropForm, opts ...grpc.CallOption) (*Resp, error)
+	// DropIndex 删除索引
+	DropIndex(ctx context.Context, in *ReqDropIndex, opts ...grpc.CallOption) (*Resp, error)
 	// Put 新增数据
 	Put(ctx context.Context, in *ReqPut, opts ...grpc.CallOption) (*RespPut, error)
 	// Set 新增数据
@@ -87,6 +112,12 @@ type LilyAPIClient interface {
 	Insert(ctx context.Context, in *ReqInsert, opts ...grpc.CallOption) (*RespInsert, error)
 	// Select 获取数据
 	Select(ctx context.Context, in *ReqSelect, opts ...grpc.CallOption) (*RespSelect, error)
+	// SelectStream 流式获取数据，在遍历索引的过程中逐条返回
+	SelectStream(ctx context.Context, in *ReqSelect, opts ...grpc.CallOption) (LilyAPI_SelectStreamClient, error)
+	// Aggregate 分组聚合检索结果
+	Aggregate(ctx context.Context, in *ReqAggregate, opts ...grpc.CallOption) (*RespAggregate, error)
+	// Explain 获取检索计划
+	Explain(ctx context.Context, in *ReqExplain, opts ...grpc.CallOption) (*RespExplain, error)
 	// Remove 删除数据
 	Remove(ctx context.Context, in *ReqRemove, opts ...grpc.CallOption) (*Resp, error)
 	// Delete 删除数据
@@ -94,10 +125,10 @@ type LilyAPIClient interface {
 }
 
 type lilyAPIClient struct {
-	cc *grpc.ClientConn
+	cc grpc.ClientConnInterface
 }
 
-func NewLilyAPIClient(cc *grpc.ClientConn) LilyAPIClient {
+func NewLilyAPIClient(cc grpc.ClientConnInterface) LilyAPIClient {
 	return &lilyAPIClient{cc}
 }
 
@@ -164,6 +195,69 @@ func (c *lilyAPIClient) CreateIndex(ctx context.Context, in *ReqCreateIndex, opt
 	return out, nil
 }
 
+func (c *lilyAPIClient) RenameDatabase(ctx context.Context, in *ReqRenameDatabase, opts ...grpc.CallOption) (*Resp, error) {
+	out := new(Resp)
+	err := c.cc.Invoke(ctx, "/api.LilyAPI/RenameDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lilyAPIClient) CommentDatabase(ctx context.Context, in *ReqCommentDatabase, opts ...grpc.CallOption) (*Resp, error) {
+	out := new(Resp)
+	err := c.cc.Invoke(ctx, "/api.LilyAPI/CommentDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lilyAPIClient) RenameForm(ctx context.Context, in *ReqRenameForm, opts ...grpc.CallOption) (*Resp, error) {
+	out := new(Resp)
+	err := c.cc.Invoke(ctx, "/api.LilyAPI/RenameForm", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lilyAPIClient) CommentForm(ctx context.Context, in *ReqCommentForm, opts ...grpc.CallOption) (*Resp, error) {
+	out := new(Resp)
+	err := c.cc.Invoke(ctx, "/api.LilyAPI/CommentForm", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lilyAPIClient) DropDatabase(ctx context.Context, in *ReqDropDatabase, opts ...grpc.CallOption) (*Resp, error) {
+	out := new(Resp)
+	err := c.cc.Invoke(ctx, "/api.LilyAPI/DropDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lilyAPIClient) DropForm(ctx context.Context, in *ReqDropForm, opts ...grpc.CallOption) (*Resp, error) {
+	out := new(Resp)
+	err := c.cc.Invoke(ctx, "/api.LilyAPI/DropForm", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lilyAPIClient) DropIndex(ctx context.Context, in *ReqDropIndex, opts ...grpc.CallOption) (*Resp, error) {
+	out := new(Resp)
+	err := c.cc.Invoke(ctx, "/api.LilyAPI/DropIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lilyAPIClient) Put(ctx context.Context, in *ReqPut, opts ...grpc.CallOption) (*RespPut, error) {
 	out := new(RespPut)
 	err := c.cc.Invoke(ctx, "/api.LilyAPI/Put", in, out, opts...)
@@ -209,6 +303,56 @@ func (c *lilyAPIClient) Select(ctx context.Context, in *ReqSelect, opts ...grpc.
 	return out, nil
 }
 
+func (c *lilyAPIClient) SelectStream(ctx context.Context, in *ReqSelect, opts ...grpc.CallOption) (LilyAPI_SelectStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_LilyAPI_serviceDesc.Streams[0], "/api.LilyAPI/SelectStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lilyAPISelectStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type LilyAPI_SelectStreamClient interface {
+	Recv() (*RespSelectStream, error)
+	grpc.ClientStream
+}
+
+type lilyAPISelectStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *lilyAPISelectStreamClient) Recv() (*RespSelectStream, error) {
+	m := new(RespSelectStream)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lilyAPIClient) Aggregate(ctx context.Context, in *ReqAggregate, opts ...grpc.CallOption) (*RespAggregate, error) {
+	out := new(RespAggregate)
+	err := c.cc.Invoke(ctx, "/api.LilyAPI/Aggregate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lilyAPIClient) Explain(ctx context.Context, in *ReqExplain, opts ...grpc.CallOption) (*RespExplain, error) {
+	out := new(RespExplain)
+	err := c.cc.Invoke(ctx, "/api.LilyAPI/Explain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lilyAPIClient) Remove(ctx context.Context, in *ReqRemove, opts ...grpc.CallOption) (*Resp, error) {
 	out := new(Resp)
 	err := c.cc.Invoke(ctx, "/api.LilyAPI/Remove", in, out, opts...)
@@ -243,6 +387,20 @@ type LilyAPIServer interface {
 	CreateKey(context.Context, *ReqCreateKey) (*Resp, error)
 	// CreateIndex 新建索引
 	CreateIndex(context.Context, *ReqCreateIndex) (*Resp, error)
+	// RenameDatabase 修改数据库名
+	RenameDatabase(context.Context, *ReqRenameDatabase) (*Resp, error)
+	// CommentDatabase 修改数据库描述
+	CommentDatabase(context.Context, *ReqCommentDatabase) (*Resp, error)
+	// RenameForm 修改表名
+	RenameForm(context.Context, *ReqRenameForm) (*Resp, error)
+	// CommentForm 修改表描述
+	CommentForm(context.Context, *ReqCommentForm) (*Resp, error)
+	// DropDatabase 删除数据库
+	DropDatabase(context.Context, *ReqDropDatabase) (*Resp, error)
+	// DropForm 删除表
+	DropForm(context.Context, *ReqDropForm) (*Resp, error)
+	// DropIndex 删除索引
+	DropIndex(context.Context, *ReqDropIndex) (*Resp, error)
 	// Put 新增数据
 	Put(context.Context, *ReqPut) (*RespPut, error)
 	// Set 新增数据
@@ -253,12 +411,95 @@ type LilyAPIServer interface {
 	Insert(context.Context, *ReqInsert) (*RespInsert, error)
 	// Select 获取数据
 	Select(context.Context, *ReqSelect) (*RespSelect, error)
+	// SelectStream 流式获取数据，在遍历索引的过程中逐条返回
+	SelectStream(*ReqSelect, LilyAPI_SelectStreamServer) error
+	// Aggregate 分组聚合检索结果
+	Aggregate(context.Context, *ReqAggregate) (*RespAggregate, error)
+	// Explain 获取检索计划
+	Explain(context.Context, *ReqExplain) (*RespExplain, error)
 	// Remove 删除数据
 	Remove(context.Context, *ReqRemove) (*Resp, error)
 	// Delete 删除数据
 	Delete(context.Context, *ReqDelete) (*RespDelete, error)
 }
 
+// UnimplementedLilyAPIServer can be embedded to have forward compatible implementations.
+type UnimplementedLilyAPIServer struct {
+}
+
+func (*UnimplementedLilyAPIServer) GetConf(ctx context.Context, req *ReqConf) (*RespConf, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConf not implemented")
+}
+func (*UnimplementedLilyAPIServer) ObtainDatabases(ctx context.Context, req *ReqDatabases) (*RespDatabases, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ObtainDatabases not implemented")
+}
+func (*UnimplementedLilyAPIServer) ObtainForms(ctx context.Context, req *ReqForms) (*RespForms, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ObtainForms not implemented")
+}
+func (*UnimplementedLilyAPIServer) CreateDatabase(ctx context.Context, req *ReqCreateDatabase) (*Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDatabase not implemented")
+}
+func (*UnimplementedLilyAPIServer) CreateForm(ctx context.Context, req *ReqCreateForm) (*Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateForm not implemented")
+}
+func (*UnimplementedLilyAPIServer) CreateKey(ctx context.Context, req *ReqCreateKey) (*Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateKey not implemented")
+}
+func (*UnimplementedLilyAPIServer) CreateIndex(ctx context.Context, req *ReqCreateIndex) (*Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateIndex not implemented")
+}
+func (*UnimplementedLilyAPIServer) RenameDatabase(ctx context.Context, req *ReqRenameDatabase) (*Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameDatabase not implemented")
+}
+func (*UnimplementedLilyAPIServer) CommentDatabase(ctx context.Context, req *ReqCommentDatabase) (*Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommentDatabase not implemented")
+}
+func (*UnimplementedLilyAPIServer) RenameForm(ctx context.Context, req *ReqRenameForm) (*Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameForm not implemented")
+}
+func (*UnimplementedLilyAPIServer) CommentForm(ctx context.Context, req *ReqCommentForm) (*Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommentForm not implemented")
+}
+func (*UnimplementedLilyAPIServer) DropDatabase(ctx context.Context, req *ReqDropDatabase) (*Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DropDatabase not implemented")
+}
+func (*UnimplementedLilyAPIServer) DropForm(ctx context.Context, req *ReqDropForm) (*Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DropForm not implemented")
+}
+func (*UnimplementedLilyAPIServer) DropIndex(ctx context.Context, req *ReqDropIndex) (*Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DropIndex not implemented")
+}
+func (*UnimplementedLilyAPIServer) Put(ctx context.Context, req *ReqPut) (*RespPut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Put not implemented")
+}
+func (*UnimplementedLilyAPIServer) Set(ctx context.Context, req *ReqSet) (*RespSet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Set not implemented")
+}
+func (*UnimplementedLilyAPIServer) Get(ctx context.Context, req *ReqGet) (*RespGet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (*UnimplementedLilyAPIServer) Insert(ctx context.Context, req *ReqInsert) (*RespInsert, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Insert not implemented")
+}
+func (*UnimplementedLilyAPIServer) Select(ctx context.Context, req *ReqSelect) (*RespSelect, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Select not implemented")
+}
+func (*UnimplementedLilyAPIServer) SelectStream(req *ReqSelect, srv LilyAPI_SelectStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method SelectStream not implemented")
+}
+func (*UnimplementedLilyAPIServer) Aggregate(ctx context.Context, req *ReqAggregate) (*RespAggregate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Aggregate not implemented")
+}
+func (*UnimplementedLilyAPIServer) Explain(ctx context.Context, req *ReqExplain) (*RespExplain, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Explain not implemented")
+}
+func (*UnimplementedLilyAPIServer) Remove(ctx context.Context, req *ReqRemove) (*Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Remove not implemented")
+}
+func (*UnimplementedLilyAPIServer) Delete(ctx context.Context, req *ReqDelete) (*RespDelete, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+
 func RegisterLilyAPIServer(s *grpc.Server, srv LilyAPIServer) {
 	s.RegisterService(&_LilyAPI_serviceDesc, srv)
 }
@@ -389,6 +630,132 @@ func _LilyAPI_CreateIndex_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LilyAPI_RenameDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqRenameDatabase)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LilyAPIServer).RenameDatabase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.LilyAPI/RenameDatabase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LilyAPIServer).RenameDatabase(ctx, req.(*ReqRenameDatabase))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LilyAPI_CommentDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqCommentDatabase)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LilyAPIServer).CommentDatabase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.LilyAPI/CommentDatabase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LilyAPIServer).CommentDatabase(ctx, req.(*ReqCommentDatabase))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LilyAPI_RenameForm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqRenameForm)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LilyAPIServer).RenameForm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.LilyAPI/RenameForm",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LilyAPIServer).RenameForm(ctx, req.(*ReqRenameForm))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LilyAPI_CommentForm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqCommentForm)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LilyAPIServer).CommentForm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.LilyAPI/CommentForm",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LilyAPIServer).CommentForm(ctx, req.(*ReqCommentForm))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LilyAPI_DropDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqDropDatabase)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LilyAPIServer).DropDatabase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.LilyAPI/DropDatabase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LilyAPIServer).DropDatabase(ctx, req.(*ReqDropDatabase))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LilyAPI_DropForm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqDropForm)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LilyAPIServer).DropForm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.LilyAPI/DropForm",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LilyAPIServer).DropForm(ctx, req.(*ReqDropForm))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LilyAPI_DropIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqDropIndex)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LilyAPIServer).DropIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.LilyAPI/DropIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LilyAPIServer).DropIndex(ctx, req.(*ReqDropIndex))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _LilyAPI_Put_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReqPut)
 	if err := dec(in); err != nil {
@@ -479,6 +846,63 @@ func _LilyAPI_Select_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LilyAPI_SelectStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReqSelect)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LilyAPIServer).SelectStream(m, &lilyAPISelectStreamServer{stream})
+}
+
+type LilyAPI_SelectStreamServer interface {
+	Send(*RespSelectStream) error
+	grpc.ServerStream
+}
+
+type lilyAPISelectStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *lilyAPISelectStreamServer) Send(m *RespSelectStream) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _LilyAPI_Aggregate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqAggregate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LilyAPIServer).Aggregate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.LilyAPI/Aggregate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LilyAPIServer).Aggregate(ctx, req.(*ReqAggregate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LilyAPI_Explain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqExplain)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LilyAPIServer).Explain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.LilyAPI/Explain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LilyAPIServer).Explain(ctx, req.(*ReqExplain))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _LilyAPI_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReqRemove)
 	if err := dec(in); err != nil {
@@ -547,6 +971,34 @@ var _LilyAPI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateIndex",
 			Handler:    _LilyAPI_CreateIndex_Handler,
 		},
+		{
+			MethodName: "RenameDatabase",
+			Handler:    _LilyAPI_RenameDatabase_Handler,
+		},
+		{
+			MethodName: "CommentDatabase",
+			Handler:    _LilyAPI_CommentDatabase_Handler,
+		},
+		{
+			MethodName: "RenameForm",
+			Handler:    _LilyAPI_RenameForm_Handler,
+		},
+		{
+			MethodName: "CommentForm",
+			Handler:    _LilyAPI_CommentForm_Handler,
+		},
+		{
+			MethodName: "DropDatabase",
+			Handler:    _LilyAPI_DropDatabase_Handler,
+		},
+		{
+			MethodName: "DropForm",
+			Handler:    _LilyAPI_DropForm_Handler,
+		},
+		{
+			MethodName: "DropIndex",
+			Handler:    _LilyAPI_DropIndex_Handler,
+		},
 		{
 			MethodName: "Put",
 			Handler:    _LilyAPI_Put_Handler,
@@ -567,6 +1019,14 @@ var _LilyAPI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Select",
 			Handler:    _LilyAPI_Select_Handler,
 		},
+		{
+			MethodName: "Aggregate",
+			Handler:    _LilyAPI_Aggregate_Handler,
+		},
+		{
+			MethodName: "Explain",
+			Handler:    _LilyAPI_Explain_Handler,
+		},
 		{
 			MethodName: "Remove",
 			Handler:    _LilyAPI_Remove_Handler,
@@ -576,6 +1036,12 @@ var _LilyAPI_serviceDesc = grpc.ServiceDesc{
 			Handler:    _LilyAPI_Delete_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SelectStream",
+			Handler:       _LilyAPI_SelectStream_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "connector/grpc/server.proto",
 }
@@ -0,0 +1,171 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package engine
+
+import (
+	"github.com/aberic/gnomon"
+	"github.com/aberic/lilydb/config"
+	"github.com/aberic/lilydb/connector"
+	api "github.com/aberic/lilydb/connector/grpc"
+	"github.com/aberic/lilydb/engine/siam"
+	"github.com/aberic/lilydb/engine/siam/storage"
+	"github.com/golang/protobuf/proto"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// bootstrap 读取引导文件，恢复库、表及索引结构
+//
+// 引导文件不存在时表示首次启动，无需恢复
+func (e *Engine) bootstrap() error {
+	lily, err := readBootstrap()
+	if nil != err {
+		return err
+	}
+	for _, db := range lily.Databases {
+		// 先重做预写日志中未完成的写入，确保随后恢复的索引与数据一致
+		if err = storage.Obtain().Replay(db.ID); nil != err {
+			return err
+		}
+		dbNew := &database{id: db.ID, name: db.Name, comment: db.Comment, forms: map[string]connector.Form{}, sync: e.sync}
+		for _, fm := range db.Forms {
+			if err = dbNew.recoverForm(fm); nil != err {
+				return err
+			}
+		}
+		e.databases[db.Name] = dbNew
+	}
+	for _, db := range e.databases { // 所有库表均恢复后再继续回填，避免回填完成时同步的引导文件缺少尚未恢复的库表
+		for _, fm := range db.forms {
+			if fm, ok := fm.(*siam.Form); ok {
+				if err = fm.Resume(e.indexBuilt); nil != err {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// readBootstrap 读取引导文件中记录的库、表及索引结构，引导文件不存在时返回空结构
+func readBootstrap() (*api.Lily, error) {
+	var (
+		data []byte
+		lily = &api.Lily{}
+		err  error
+	)
+	filePath := config.Obtain().LilyBootstrapFilePath
+	if !gnomon.FilePathExists(filePath) {
+		return lily, nil
+	}
+	if data, err = ioutil.ReadFile(filePath); nil != err {
+		return nil, err
+	}
+	if err = proto.Unmarshal(data, lily); nil != err {
+		return nil, err
+	}
+	return lily, nil
+}
+
+// sync 将当前库、表及索引结构写入引导文件
+func (e *Engine) sync() error {
+	return e.modify(func() error { return nil })
+}
+
+// modify 持有库集合锁执行变更，变更成功后在锁内快照库、表及索引结构，释放锁后再写入引导文件
+//
+// change 库集合变更方法，返回错误时不同步引导文件
+func (e *Engine) modify(change func() error) error {
+	e.mu.Lock()
+	if err := change(); nil != err {
+		e.mu.Unlock()
+		return err
+	}
+	lily, seq := e.catalog()
+	e.mu.Unlock()
+	return e.store(lily, seq)
+}
+
+// catalog 快照当前库、表及索引结构，调用方需持有库集合锁
+//
+// 返回快照及其序号，序号用于避免较早的快照覆盖已写入的较新快照
+func (e *Engine) catalog() (*api.Lily, uint64) {
+	lily := &api.Lily{Databases: map[string]*api.Database{}}
+	for _, db := range e.databases {
+		db.mu.Lock()
+		lily.Databases[db.name] = &api.Database{ID: db.id, Name: db.name, Comment: db.comment, Forms: e.formatForms(db)}
+		db.mu.Unlock()
+	}
+	e.catalogSeq++
+	return lily, e.catalogSeq
+}
+
+// store 将库、表及索引结构快照写入引导文件
+//
+// 先写入临时文件，落盘后再替换原引导文件，避免写入过程中宕机导致引导文件损坏
+//
+// lily 库、表及索引结构快照
+//
+// seq 快照序号，不大于已写入快照序号时忽略本次写入
+func (e *Engine) store(lily *api.Lily, seq uint64) error {
+	var (
+		file *os.File
+		data []byte
+		err  error
+	)
+	defer e.syncMu.Unlock()
+	e.syncMu.Lock()
+	if seq <= e.storedSeq {
+		return nil
+	}
+	if data, err = proto.Marshal(lily); nil != err {
+		return err
+	}
+	filePath := config.Obtain().LilyBootstrapFilePath
+	if err = os.MkdirAll(gnomon.FileParentPath(filePath), os.ModePerm); nil != err {
+		return err
+	}
+	tmpFilePath := strings.Join([]string{filePath, "tmp"}, ".")
+	if file, err = os.OpenFile(tmpFilePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644); nil != err {
+		return err
+	}
+	if _, err = file.Write(data); nil != err {
+		_ = file.Close()
+		return err
+	}
+	if err = file.Sync(); nil != err {
+		_ = file.Close()
+		return err
+	}
+	if err = file.Close(); nil != err {
+		return err
+	}
+	if err = os.Rename(tmpFilePath, filePath); nil != err {
+		return err
+	}
+	e.storedSeq = seq
+	return nil
+}
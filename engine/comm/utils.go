@@ -41,12 +41,32 @@ var (
 	ErrKeyNotFound = errors.New("key not found")
 	// ErrLinkNotFound 自定义error信息
 	ErrLinkNotFound = errors.New("link not found")
+	// ErrIndexExist 自定义error信息
+	ErrIndexExist = errors.New("index already exist")
+	// ErrIndexNotFound 自定义error信息
+	ErrIndexNotFound = errors.New("index not found")
+	// ErrIndexProtected 自定义error信息
+	ErrIndexProtected = errors.New("built-in primary index can not be dropped")
+	// ErrIndexDuplicate 自定义error信息
+	ErrIndexDuplicate = errors.New("unique index key duplicate in existing data")
+	// ErrDataCorrupt 自定义error信息
+	ErrDataCorrupt = errors.New("data corrupt")
+	// ErrAggregateNotSupport 自定义error信息
+	ErrAggregateNotSupport = errors.New("aggregate operation not support")
+	// ErrCursorInvalid 自定义error信息
+	ErrCursorInvalid = errors.New("cursor invalid or index changed")
+	// ErrSortNotSupport 自定义error信息
+	ErrSortNotSupport = errors.New("sort param can not be empty and nulls must be first or last")
+	// ErrJoinNotSupport 自定义error信息
+	ErrJoinNotSupport = errors.New("join type must be inner or left and on can not be empty")
+	// ErrPlanNotFound 自定义error信息
+	ErrPlanNotFound = errors.New("select returned no plan")
+	// ErrServerRunning 自定义error信息
+	ErrServerRunning = errors.New("server is running, stop it first")
 	//// ErrIndexFileNotFound 自定义error信息
 	//ErrIndexFileNotFound = errors.New("index file not found")
 	//// ErrKeyExist 自定义error信息
 	//ErrKeyExist = errors.New("key already exist")
-	//// ErrIndexExist 自定义error信息
-	//ErrIndexExist = errors.New("index already exist")
 	//// ErrKeyIsNil 自定义error信息
 	//ErrKeyIsNil = errors.New("put keyStructure can not be nil")
 )
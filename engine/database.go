@@ -26,6 +26,7 @@ package engine
 
 import (
 	"github.com/aberic/gnomon"
+	"github.com/aberic/gnomon/log"
 	"github.com/aberic/lilydb/connector"
 	api "github.com/aberic/lilydb/connector/grpc"
 	"github.com/aberic/lilydb/engine/comm"
@@ -43,6 +44,7 @@ type database struct {
 	name    string                    // 数据库名称，根据需求可以随时变化
 	comment string                    // 描述
 	forms   map[string]connector.Form // 表集合
+	sync    func() error              // 同步引导文件
 	mu      sync.Mutex
 }
 
@@ -87,6 +89,110 @@ func (db *database) newForm(formName, comment string, formType api.FormType) err
 	case api.FormType_MSiam:
 		db.forms[formName] = msiam.NewForm(db.id, formID, formName, comment)
 	}
+	db.forms[formName].SetSync(db.sync)
+	return nil
+}
+
+// dropForm 删除表
+//
+// formName 表名称
+func (db *database) dropForm(formName string) error {
+	defer db.mu.Unlock()
+	db.mu.Lock()
+	fm, exist := db.forms[formName]
+	if !exist {
+		return comm.ErrFormNotFoundOrSupport
+	}
+	if err := fm.Drop(); nil != err {
+		return err
+	}
+	delete(db.forms, formName)
+	return nil
+}
+
+// createIndex 在线新建索引
+//
+// formName 表名称
+//
+// keyStructure 按照规范结构组成的索引字段名称
+//
+// primary 是否主键
+//
+// unique 是否唯一索引
+//
+// built 回填结束回调
+func (db *database) createIndex(formName, keyStructure string, primary, unique bool, built func(err error)) error {
+	if fm, exist := db.forms[formName]; exist {
+		return fm.CreateIndex(keyStructure, primary, unique, built)
+	}
+	return comm.ErrFormNotFoundOrSupport
+}
+
+// dropIndex 删除索引
+//
+// formName 表名称
+//
+// keyStructure 按照规范结构组成的索引字段名称
+func (db *database) dropIndex(formName, keyStructure string) error {
+	if fm, exist := db.forms[formName]; exist {
+		return fm.DropIndex(keyStructure)
+	}
+	return comm.ErrFormNotFoundOrSupport
+}
+
+// renameForm 修改表名，表唯一ID及数据文件位置不变
+//
+// formName 表名称
+//
+// newName 新表名称
+func (db *database) renameForm(formName, newName string) error {
+	defer db.mu.Unlock()
+	db.mu.Lock()
+	fm, exist := db.forms[formName]
+	if !exist {
+		return comm.ErrFormNotFoundOrSupport
+	}
+	if _, exist = db.forms[newName]; exist {
+		return comm.ErrFormExist
+	}
+	fm.Rename(newName)
+	db.forms[newName] = fm
+	delete(db.forms, formName)
+	return nil
+}
+
+// commentForm 修改表描述
+//
+// formName 表名称
+//
+// comment 表描述
+func (db *database) commentForm(formName, comment string) error {
+	if fm, exist := db.forms[formName]; exist {
+		fm.SetComment(comment)
+		return nil
+	}
+	return comm.ErrFormNotFoundOrSupport
+}
+
+// recoverForm 根据引导文件中记录的表结构恢复表
+//
+// apiForm 引导文件中记录的表结构
+func (db *database) recoverForm(apiForm *api.Form) error {
+	defer db.mu.Unlock()
+	db.mu.Lock()
+	switch apiForm.FormType {
+	default:
+		return comm.ErrFormNotFoundOrSupport
+	case api.FormType_Siam:
+		fm := siam.RecoverForm(db.id, apiForm)
+		for indexID, err := range fm.Recover() { // 单个索引恢复失败不影响其它索引及表的恢复
+			log.Error("recoverForm", log.Field("form", apiForm.Name), log.Field("index", indexID), log.Err(err))
+		}
+		db.forms[apiForm.Name] = fm
+	case api.FormType_MSiam:
+		db.forms[apiForm.Name] = msiam.RecoverForm(db.id, apiForm)
+	}
+	db.forms[apiForm.Name].SetSync(db.sync)
 	return nil
 }
 
@@ -155,7 +261,7 @@ func (db *database) del(formName, key string) (interface{}, error) {
 		switch fm.FormType() {
 		default:
 			return 0, comm.ErrFormNotFoundOrSupport
-		case api.FormType_MSiam:
+		case api.FormType_MSiam, api.FormType_Siam:
 			return fm.Del(key)
 		}
 	}
@@ -171,7 +277,7 @@ func (db *database) insert(formName string, value interface{}) (uint64, error) {
 
 func (db *database) update(formName string, value interface{}) (uint64, error) {
 	if fm, exist := db.forms[formName]; exist && fm.FormType() == api.FormType_Siam {
-		return fm.Insert(value)
+		return fm.Update(value)
 	}
 	return 0, comm.ErrFormNotFoundOrSupport
 }
@@ -183,6 +289,20 @@ func (db *database) query(formName string, selectorBytes []byte) (int32, []inter
 	return 0, nil, comm.ErrFormNotFoundOrSupport
 }
 
+func (db *database) page(formName string, selectorBytes []byte) (int32, []interface{}, string, error) {
+	if fm, exist := db.forms[formName]; exist {
+		return fm.Page(selectorBytes)
+	}
+	return 0, nil, "", comm.ErrFormNotFoundOrSupport
+}
+
+func (db *database) stream(formName string, selectorBytes []byte, handler func(value interface{}) bool) (int32, error) {
+	if fm, exist := db.forms[formName]; exist {
+		return fm.Stream(selectorBytes, handler)
+	}
+	return 0, comm.ErrFormNotFoundOrSupport
+}
+
 func (db *database) delete(formName string, selectorBytes []byte) (int32, error) {
 	if fm, exist := db.forms[formName]; exist {
 		return fm.Delete(selectorBytes)
@@ -190,6 +310,20 @@ func (db *database) delete(formName string, selectorBytes []byte) (int32, error)
 	return 0, comm.ErrFormNotFoundOrSupport
 }
 
+func (db *database) compact(formName string) error {
+	if fm, exist := db.forms[formName]; exist {
+		return fm.Compact()
+	}
+	return comm.ErrFormNotFoundOrSupport
+}
+
+func (db *database) verifyForm(formName string) ([]int64, error) {
+	if fm, exist := db.forms[formName]; exist {
+		return fm.Verify()
+	}
+	return nil, comm.ErrFormNotFoundOrSupport
+}
+
 // name2ID 确保表唯一ID不重复
 func (db *database) name2ID(name string) string {
 	id := gnomon.HashMD516(name)
@@ -25,11 +25,15 @@
 package engine
 
 import (
+	"bytes"
+	"encoding/json"
 	"github.com/aberic/gnomon"
+	"github.com/aberic/gnomon/log"
 	"github.com/aberic/lilydb/config"
 	"github.com/aberic/lilydb/connector"
 	api "github.com/aberic/lilydb/connector/grpc"
 	"github.com/aberic/lilydb/engine/comm"
+	"github.com/aberic/lilydb/engine/siam/storage"
 	"os"
 	"path/filepath"
 	"strings"
@@ -47,6 +51,9 @@ func Obtain() *Engine {
 		engine = &Engine{
 			databases: map[string]*database{},
 		}
+		if err := engine.bootstrap(); nil != err {
+			log.Panic("ObtainEngine", log.Err(err))
+		}
 	})
 	return engine
 }
@@ -59,8 +66,11 @@ func Obtain() *Engine {
 //
 // 存储格式 {dataDir}/Data/{dataName}/{formName}/{formName}.dat/idx...
 type Engine struct {
-	databases map[string]*database
-	mu        sync.Mutex
+	databases  map[string]*database
+	mu         sync.Mutex
+	syncMu     sync.Mutex // 引导文件写入锁
+	catalogSeq uint64     // 最近一次快照的库、表及索引结构序号，由库集合锁保护
+	storedSeq  uint64     // 最近一次写入引导文件的快照序号，由引导文件写入锁保护
 }
 
 // Databases 获取数据库集合
@@ -108,21 +118,21 @@ func (e *Engine) Forms(databaseName string) []*api.Form {
 //
 // comment 数据库描述
 func (e *Engine) NewDatabase(databaseName, comment string) error {
-	defer e.mu.Unlock()
-	e.mu.Lock()
-	// 确定库名不重复
-	for k := range e.databases {
-		if k == databaseName {
-			return comm.ErrDatabaseExist
+	return e.modify(func() error {
+		// 确定库名不重复
+		for k := range e.databases {
+			if k == databaseName {
+				return comm.ErrDatabaseExist
+			}
 		}
-	}
-	// 确保数据库唯一ID不重复
-	databaseID := e.name2ID(databaseName)
-	if err := e.mkDataDir(databaseID); nil != err {
-		return err
-	}
-	e.databases[databaseName] = &database{id: databaseID, name: databaseName, comment: comment, forms: map[string]connector.Form{}}
-	return nil
+		// 确保数据库唯一ID不重复
+		databaseID := e.name2ID(databaseName)
+		if err := e.mkDataDir(databaseID); nil != err {
+			return err
+		}
+		e.databases[databaseName] = &database{id: databaseID, name: databaseName, comment: comment, forms: map[string]connector.Form{}, sync: e.sync}
+		return nil
+	})
 }
 
 // NewForm 新建表，会创建默认自增主键
@@ -136,7 +146,171 @@ func (e *Engine) NewDatabase(databaseName, comment string) error {
 // formType 表类型
 func (e *Engine) NewForm(databaseName, formName, comment string, formType api.FormType) error {
 	if db, exist := e.databases[databaseName]; exist {
-		return db.newForm(formName, comment, formType)
+		if err := db.newForm(formName, comment, formType); nil != err {
+			return err
+		}
+		return e.sync()
+	}
+	return comm.ErrDataNotFound
+}
+
+// RenameDatabase 修改数据库名，数据库唯一ID及存储目录不变
+//
+// databaseName 数据库名称
+//
+// newName 新数据库名称
+func (e *Engine) RenameDatabase(databaseName, newName string) error {
+	return e.modify(func() error {
+		db, exist := e.databases[databaseName]
+		if !exist {
+			return comm.ErrDataNotFound
+		}
+		if _, exist = e.databases[newName]; exist {
+			return comm.ErrDatabaseExist
+		}
+		db.name = newName
+		e.databases[newName] = db
+		delete(e.databases, databaseName)
+		return nil
+	})
+}
+
+// CommentDatabase 修改数据库描述
+//
+// databaseName 数据库名称
+//
+// comment 数据库描述
+func (e *Engine) CommentDatabase(databaseName, comment string) error {
+	return e.modify(func() error {
+		db, exist := e.databases[databaseName]
+		if !exist {
+			return comm.ErrDataNotFound
+		}
+		db.comment = comment
+		return nil
+	})
+}
+
+// RenameForm 修改表名，表唯一ID及数据文件位置不变
+//
+// databaseName 数据库名
+//
+// formName 表名称
+//
+// newName 新表名称
+func (e *Engine) RenameForm(databaseName, formName, newName string) error {
+	if db, exist := e.databases[databaseName]; exist {
+		if err := db.renameForm(formName, newName); nil != err {
+			return err
+		}
+		return e.sync()
+	}
+	return comm.ErrDataNotFound
+}
+
+// CommentForm 修改表描述
+//
+// databaseName 数据库名
+//
+// formName 表名称
+//
+// comment 表描述
+func (e *Engine) CommentForm(databaseName, formName, comment string) error {
+	if db, exist := e.databases[databaseName]; exist {
+		if err := db.commentForm(formName, comment); nil != err {
+			return err
+		}
+		return e.sync()
+	}
+	return comm.ErrDataNotFound
+}
+
+// DropDatabase 删除数据库，同时删除库下所有表及其数据文件
+//
+// databaseName 数据库名称
+func (e *Engine) DropDatabase(databaseName string) error {
+	return e.modify(func() error {
+		db, exist := e.databases[databaseName]
+		if !exist {
+			return comm.ErrDataNotFound
+		}
+		db.mu.Lock()
+		for _, fm := range db.forms { // 先释放各表的索引及文件句柄，再删除库目录
+			if err := fm.Drop(); nil != err {
+				db.mu.Unlock()
+				return err
+			}
+		}
+		db.mu.Unlock()
+		if err := storage.Obtain().DropDatabase(db.id); nil != err {
+			return err
+		}
+		delete(e.databases, databaseName)
+		return nil
+	})
+}
+
+// DropForm 删除表，同时删除表数据及索引文件
+//
+// databaseName 数据库名
+//
+// formName 表名称
+func (e *Engine) DropForm(databaseName, formName string) error {
+	if db, exist := e.databases[databaseName]; exist {
+		if err := db.dropForm(formName); nil != err {
+			return err
+		}
+		return e.sync()
+	}
+	return comm.ErrDataNotFound
+}
+
+// CreateIndex 在线新建索引，并在后台以表中已存在的数据回填
+//
+// 回填期间新索引即接收写入但不参与检索，进度可通过表索引集合查看，回填完成后同步引导文件
+//
+// databaseName 数据库名
+//
+// formName 表名称
+//
+// keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'，组合索引的各字段名称间通过','分隔，如'tenant,createdAt'
+//
+// primary 是否主键
+//
+// unique 是否唯一索引，主键总是唯一索引，非唯一索引的同一key可对应多行数据
+func (e *Engine) CreateIndex(databaseName, formName, keyStructure string, primary, unique bool) error {
+	if db, exist := e.databases[databaseName]; exist {
+		if err := db.createIndex(formName, keyStructure, primary, unique, e.indexBuilt); nil != err {
+			return err
+		}
+		return e.sync()
+	}
+	return comm.ErrDataNotFound
+}
+
+// indexBuilt 索引回填结束回调，回填完成或失败后均同步引导文件，回填失败的索引已被删除
+func (e *Engine) indexBuilt(err error) {
+	if nil != err {
+		log.Error("indexBuilt", log.Err(err))
+	}
+	if err = e.sync(); nil != err {
+		log.Error("indexBuilt", log.Err(err))
+	}
+}
+
+// DropIndex 删除索引，默认主键不可删除
+//
+// databaseName 数据库名
+//
+// formName 表名称
+//
+// keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'
+func (e *Engine) DropIndex(databaseName, formName, keyStructure string) error {
+	if db, exist := e.databases[databaseName]; exist {
+		if err := db.dropIndex(formName, keyStructure); nil != err {
+			return err
+		}
+		return e.sync()
 	}
 	return comm.ErrDataNotFound
 }
@@ -199,7 +373,7 @@ func (e *Engine) Get(databaseName, formName, key string) (interface{}, error) {
 //
 // formName 表名
 //
-// key 指定的key
+// key 指定的key，Siam表为自增ID
 //
 // 返回 删除的数据对象
 func (e *Engine) Del(databaseName, formName, key string) (interface{}, error) {
@@ -247,9 +421,9 @@ func (e *Engine) Update(databaseName, formName string, value interface{}) (uint6
 //
 // formName 表名
 //
-// selectorBytes 选择器字节数组，自定义转换策略
+// selectorBytes 选择器字节数组，自定义转换策略，包含Aggregate时返回各分组的聚合结果，Explain为true时仅返回检索计划*api.Plan
 //
-// return count 检索结果总条数
+// return count 检索结果总条数，聚合时为分组总数
 //
 // return values 检索结果集合
 //
@@ -261,6 +435,110 @@ func (e *Engine) Select(databaseName, formName string, selectorBytes []byte) (co
 	return 0, nil, comm.ErrDataNotFound
 }
 
+// Explain 获取检索计划
+//
+// databaseID 数据库名
+//
+// formName 表名
+//
+// selectorBytes 选择器字节数组，自定义转换策略，无需包含Explain
+//
+// return plan 所选索引、遍历方向、下推及过滤的条件与估算命中行数
+//
+// return err 检索错误信息，如果有
+func (e *Engine) Explain(databaseName, formName string, selectorBytes []byte) (*api.Plan, error) {
+	selector := make(map[string]interface{})
+	if len(selectorBytes) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(selectorBytes))
+		decoder.UseNumber() // 原样保留条件值中的数字
+		if err := decoder.Decode(&selector); nil != err {
+			return nil, err
+		}
+	}
+	selector["Explain"] = true
+	data, err := json.Marshal(selector)
+	if nil != err {
+		return nil, err
+	}
+	_, values, err := e.Select(databaseName, formName, data)
+	if nil != err {
+		return nil, err
+	}
+	if len(values) != 1 {
+		return nil, comm.ErrPlanNotFound
+	}
+	plan, ok := values[0].(*api.Plan)
+	if !ok {
+		return nil, comm.ErrPlanNotFound
+	}
+	return plan, nil
+}
+
+// Page 根据条件分页检索
+//
+// databaseID 数据库名
+//
+// formName 表名
+//
+// selectorBytes 选择器字节数组，自定义转换策略，可包含上一页返回的分页游标Cursor
+//
+// return count 检索结果总条数
+//
+// return values 检索结果集合
+//
+// return cursor 下一页的分页游标，已无更多数据时为空
+//
+// return err 检索错误信息，如果有
+func (e *Engine) Page(databaseName, formName string, selectorBytes []byte) (count int32, values []interface{}, cursor string, err error) {
+	if db, exist := e.databases[databaseName]; exist {
+		return db.page(formName, selectorBytes)
+	}
+	return 0, nil, "", comm.ErrDataNotFound
+}
+
+// Stream 根据条件流式检索，检索结果在遍历索引的过程中逐条交由handler处理，不在内存中保留全部结果
+//
+// databaseID 数据库名
+//
+// formName 表名
+//
+// selectorBytes 选择器字节数组，自定义转换策略，Limit为0时不限制数量
+//
+// handler 检索结果处理方法，返回false时停止检索
+//
+// return count 停止前已检索的结果条数
+//
+// return err 检索错误信息，如果有
+func (e *Engine) Stream(databaseName, formName string, selectorBytes []byte, handler func(value interface{}) bool) (count int32, err error) {
+	if db, exist := e.databases[databaseName]; exist {
+		return db.stream(formName, selectorBytes, handler)
+	}
+	return 0, comm.ErrDataNotFound
+}
+
+// Join 连接同一数据库中的两张表检索，如
+//
+// {"Form":"order","Selector":{"Conditions":[{"Param":"amount","Cond":"gt","Value":100}]},
+// "Join":{"Form":"customer","Type":"left","On":[{"Left":"customerID","Right":"id"}],"As":"customer"}}
+//
+// 外侧表order按Selector检索后，每条数据与内侧表customer中id等于其customerID的数据连接，内侧表数据作为结果中的customer字段
+//
+// databaseID 数据库名
+//
+// joinBytes 连接选择器字节数组，Join.Type为inner或left，Join.Selector可限定内侧表条件
+//
+// return count 连接结果总条数
+//
+// return values 连接结果集合
+//
+// return err 检索错误信息，如果有
+func (e *Engine) Join(databaseName string, joinBytes []byte) (count int32, values []interface{}, err error) {
+	if db, exist := e.databases[databaseName]; exist {
+		return db.join(joinBytes)
+	}
+	return 0, nil, comm.ErrDataNotFound
+}
+
 // Delete 根据条件删除
 //
 // databaseID 数据库名
@@ -279,6 +557,32 @@ func (e *Engine) Delete(databaseName, formName string, selectorBytes []byte) (co
 	return 0, comm.ErrDataNotFound
 }
 
+// Compact 压缩表数据文件，回收更新及删除后不再被引用的空间
+//
+// databaseName 数据库名
+//
+// formName 表名
+func (e *Engine) Compact(databaseName, formName string) error {
+	if db, exist := e.databases[databaseName]; exist {
+		return db.compact(formName)
+	}
+	return comm.ErrDataNotFound
+}
+
+// VerifyForm 校验表数据，检查每条数据的记录头、长度及校验值
+//
+// databaseName 数据库名
+//
+// formName 表名
+//
+// 返回 损坏数据在文件中的起始位置集合
+func (e *Engine) VerifyForm(databaseName, formName string) ([]int64, error) {
+	if db, exist := e.databases[databaseName]; exist {
+		return db.verifyForm(formName)
+	}
+	return nil, comm.ErrDataNotFound
+}
+
 // name2ID 确保数据库唯一ID不重复
 func (e *Engine) name2ID(name string) string {
 	id := gnomon.HashMD516(name)
@@ -0,0 +1,139 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package engine
+
+import (
+	"fmt"
+	"github.com/aberic/lilydb/config"
+	api "github.com/aberic/lilydb/connector/grpc"
+	"github.com/aberic/lilydb/engine/comm"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestMain 将数据目录指向临时目录，测试结束后删除
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "lilydb-engine")
+	if nil != err {
+		panic(err)
+	}
+	conf := config.Obtain()
+	conf.RootDir = dir
+	conf.DataDir = filepath.Join(dir, "data")
+	conf.LilyLockFilePath = filepath.Join(dir, "lily.lock")
+	conf.LilyBootstrapFilePath = filepath.Join(conf.DataDir, "lily.sync")
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+type Value struct {
+	Name string
+	Age  int
+}
+
+func TestEngine_Update(t *testing.T) {
+	e := Obtain()
+	if err := e.NewDatabase("databaseUpdate", "comment"); nil != err {
+		t.Fatal(err)
+	}
+	if err := e.NewForm("databaseUpdate", "formUpdate", "comment", api.FormType_Siam); nil != err {
+		t.Fatal(err)
+	}
+	built := make(chan error, 1)
+	if err := e.databases["databaseUpdate"].createIndex("formUpdate", "Name", false, true, func(err error) { built <- err }); nil != err {
+		t.Fatal(err)
+	}
+	if err := <-built; nil != err {
+		t.Fatal(err)
+	}
+	if _, err := e.Insert("databaseUpdate", "formUpdate", &Value{Name: "a", Age: 0}); nil != err {
+		t.Fatal(err)
+	}
+	for i := 1; i <= 3; i++ {
+		if _, err := e.Update("databaseUpdate", "formUpdate", &Value{Name: "a", Age: i}); nil != err {
+			t.Fatal(err)
+		}
+	}
+	if err := e.Compact("databaseUpdate", "formUpdate"); nil != err {
+		t.Fatal(err)
+	}
+	count, values, err := e.Select("databaseUpdate", "formUpdate", []byte(`{"Conditions":[{"Param":"Age","Cond":"ge","Value":0}]}`))
+	if nil != err || count != 1 || fmt.Sprint(values[0].(map[string]interface{})["Age"]) != "3" {
+		t.Error("engine update failed", count, values, err)
+	}
+}
+
+func TestEngine_Explain(t *testing.T) {
+	e := Obtain()
+	if err := e.NewDatabase("databaseExplain", "comment"); nil != err {
+		t.Fatal(err)
+	}
+	if err := e.NewForm("databaseExplain", "formExplain", "comment", api.FormType_Siam); nil != err {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := e.Insert("databaseExplain", "formExplain", &Value{Name: "a", Age: i}); nil != err {
+			t.Fatal(err)
+		}
+	}
+	plan, err := e.Explain("databaseExplain", "formExplain", []byte(`{"Conditions":[{"Param":"Age","Cond":"ge","Value":1}]}`))
+	if nil != err || plan.TotalRows != 3 || len(plan.Filtered) != 1 {
+		t.Error("engine explain failed", plan, err)
+	}
+	if _, err = e.Explain("databaseExplain", "formNotExist", nil); err != comm.ErrFormNotFoundOrSupport {
+		t.Error("explain missing form should fail", err)
+	}
+}
+
+type Serial struct {
+	Name   string
+	Serial uint64
+}
+
+func TestEngine_SelectLargeNumber(t *testing.T) {
+	e := Obtain()
+	if err := e.NewDatabase("databaseLargeNumber", "comment"); nil != err {
+		t.Fatal(err)
+	}
+	if err := e.NewForm("databaseLargeNumber", "formLargeNumber", "comment", api.FormType_Siam); nil != err {
+		t.Fatal(err)
+	}
+	for _, serial := range []uint64{1 << 53, 1<<53 + 1, math.MaxUint64 - 1, math.MaxUint64} {
+		if _, err := e.Insert("databaseLargeNumber", "formLargeNumber", &Serial{Name: fmt.Sprint(serial), Serial: serial}); nil != err {
+			t.Fatal(err)
+		}
+	}
+	for _, serial := range []uint64{1<<53 + 1, math.MaxUint64 - 1} {
+		selector := fmt.Sprintf(`{"Conditions":[{"Param":"Serial","Cond":"eq","Value":%d}]}`, serial)
+		count, values, err := e.Select("databaseLargeNumber", "formLargeNumber", []byte(selector))
+		if nil != err || count != 1 || values[0].(map[string]interface{})["Name"] != fmt.Sprint(serial) {
+			t.Error("select large number failed", serial, count, values, err)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package engine
+
+import (
+	"github.com/aberic/lilydb/config"
+	api "github.com/aberic/lilydb/connector/grpc"
+	"github.com/aberic/lilydb/engine/comm"
+	"github.com/aberic/lilydb/engine/siam"
+	"github.com/aberic/lilydb/engine/siam/storage"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// FsckResult 数据目录完整性检查结果
+type FsckResult struct {
+	Forms   []*siam.Check // 引导文件中记录的各siam表检查结果
+	Unknown []string      // 数据目录中未记录在引导文件中的库或表目录
+}
+
+// Healthy 数据目录中所有表是否均完整一致
+func (f *FsckResult) Healthy() bool {
+	for _, check := range f.Forms {
+		if !check.Healthy() {
+			return false
+		}
+	}
+	return true
+}
+
+// Fsck 离线检查数据目录，仅可在服务未启动时调用
+//
+// 根据引导文件遍历各库下siam表的form.dat及索引文件，并找出未记录在引导文件中的库或表目录
+//
+// repair 是否修复，修复前会先重做预写日志中未完成的写入，随后由主键索引重建各索引文件，进程锁文件记录的服务进程存活时拒绝修复
+func Fsck(repair bool) (*FsckResult, error) {
+	var (
+		result = &FsckResult{}
+		known  = map[string]bool{}
+	)
+	if _, alive := Locked(); repair && alive { // 修复会重写索引文件，服务运行中时拒绝修复
+		return nil, comm.ErrServerRunning
+	}
+	lily, err := readBootstrap()
+	if nil != err {
+		return nil, err
+	}
+	for _, db := range lily.Databases {
+		known[db.ID] = true
+		if repair {
+			if err = storage.Obtain().Replay(db.ID); nil != err {
+				return nil, err
+			}
+		}
+		for _, fm := range db.Forms {
+			known[filepath.Join(db.ID, fm.ID)] = true
+			if fm.FormType != api.FormType_Siam {
+				continue
+			}
+			check, err := siam.RecoverForm(db.ID, fm).Check(repair)
+			if nil != err {
+				return nil, err
+			}
+			result.Forms = append(result.Forms, check)
+		}
+	}
+	if result.Unknown, err = unknownDirs(known); nil != err {
+		return nil, err
+	}
+	return result, nil
+}
+
+// unknownDirs 找出数据目录中未记录在引导文件中的库或表目录
+//
+// known 引导文件中记录的库ID及“库ID/表ID”集合
+func unknownDirs(known map[string]bool) ([]string, error) {
+	var unknown []string
+	dataDir := config.Obtain().DataDir
+	databases, err := ioutil.ReadDir(dataDir)
+	if nil != err {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	for _, db := range databases {
+		if !db.IsDir() {
+			continue
+		}
+		if !known[db.Name()] {
+			unknown = append(unknown, filepath.Join(dataDir, db.Name()))
+			continue
+		}
+		forms, err := ioutil.ReadDir(filepath.Join(dataDir, db.Name()))
+		if nil != err {
+			return nil, err
+		}
+		for _, fm := range forms {
+			if fm.IsDir() && !known[filepath.Join(db.Name(), fm.Name())] {
+				unknown = append(unknown, filepath.Join(dataDir, db.Name(), fm.Name()))
+			}
+		}
+	}
+	return unknown, nil
+}
@@ -0,0 +1,277 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package engine
+
+import (
+	"encoding/json"
+	"github.com/aberic/lilydb/connector"
+	"github.com/aberic/lilydb/engine/comm"
+	"github.com/aberic/lilydb/engine/siam/utils"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	joinInner = "inner" // joinInner 内连接，仅返回两侧均匹配的数据
+	joinLeft  = "left"  // joinLeft 左连接，外侧表数据均返回，未匹配的内侧表数据为空
+	joinBatch = 256     // joinBatch 借助内侧表索引检索时每次检索的外侧表连接值数量
+)
+
+// joiner 表连接选择器，外侧表按Selector检索后逐行与内侧表连接
+//
+// 连接结果为外侧表数据浅拷贝后的map，内侧表数据作为其As字段，外侧表数据非map或结构体时以外侧表名为字段
+type joiner struct {
+	Form     string          `json:"Form"`     // Form 外侧表名
+	Selector json.RawMessage `json:"Selector"` // Selector 外侧表检索选择器，跳过及限制数量作用于外侧表数据
+	Join     *join           `json:"Join"`     // Join 内侧表连接方式
+}
+
+// join 内侧表连接方式
+type join struct {
+	Form     string          `json:"Form"`     // Form 内侧表名
+	Type     string          `json:"Type"`     // Type 连接类型 inner/left，默认为inner
+	On       []*joinOn       `json:"On"`       // On 连接条件，各条件均相等时匹配
+	As       string          `json:"As"`       // As 内侧表数据在连接结果中的字段名称，默认为内侧表名
+	Selector json.RawMessage `json:"Selector"` // Selector 内侧表检索选择器，仅条件及排序生效，排序决定同一外侧数据匹配的多条内侧数据的顺序
+}
+
+// joinOn 连接条件，外侧表字段值与内侧表字段值相等
+type joinOn struct {
+	Left  string `json:"Left"`  // Left 外侧表字段，由对象结构层级字段通过'.'组成
+	Right string `json:"Right"` // Right 内侧表字段，由对象结构层级字段通过'.'组成
+}
+
+// verify 校验连接选择器并填充默认值
+func (j *joiner) verify() error {
+	if nil == j.Join || len(j.Join.On) == 0 {
+		return comm.ErrJoinNotSupport
+	}
+	switch j.Join.Type {
+	default:
+		return comm.ErrJoinNotSupport
+	case "":
+		j.Join.Type = joinInner
+	case joinInner, joinLeft:
+	}
+	for _, on := range j.Join.On {
+		if on.Left == "" || on.Right == "" {
+			return comm.ErrJoinNotSupport
+		}
+	}
+	if j.Join.As == "" {
+		j.Join.As = j.Join.Form
+	}
+	return nil
+}
+
+// join 连接同一数据库中的两张表
+//
+// 内侧表存在首字段为首个连接条件内侧字段的可用索引时，以外侧表连接值分批借助索引检索内侧表；否则检索内侧表全部数据，两种方式均以哈希表匹配
+//
+// joinBytes 连接选择器字节数组
+func (db *database) join(joinBytes []byte) (int32, []interface{}, error) {
+	j := &joiner{}
+	if err := json.Unmarshal(joinBytes, j); nil != err {
+		return 0, nil, err
+	}
+	if err := j.verify(); nil != err {
+		return 0, nil, err
+	}
+	outerForm, exist := db.forms[j.Form]
+	if !exist {
+		return 0, nil, comm.ErrFormNotFoundOrSupport
+	}
+	innerForm, exist := db.forms[j.Join.Form]
+	if !exist {
+		return 0, nil, comm.ErrFormNotFoundOrSupport
+	}
+	outerSelector, outerFields, err := joinSelector(j.Selector, false)
+	if nil != err {
+		return 0, nil, err
+	}
+	_, outers, err := outerForm.Select(outerSelector)
+	if nil != err {
+		return 0, nil, err
+	}
+	var lefts, rights [][]string
+	for _, on := range j.Join.On {
+		lefts = append(lefts, strings.Split(on.Left, "."))
+		rights = append(rights, strings.Split(on.Right, "."))
+	}
+	inners, innerFields, err := db.joinInners(j, innerForm, outers, lefts)
+	if nil != err {
+		return 0, nil, err
+	}
+	table := make(map[string][]interface{})
+	for _, inner := range inners {
+		if key, ok := joinKey(inner, rights); ok {
+			table[key] = append(table[key], inner)
+		}
+	}
+	values := make([]interface{}, 0)
+	for _, outer := range outers {
+		var matches []interface{}
+		if key, ok := joinKey(outer, lefts); ok {
+			matches = table[key]
+		}
+		if len(matches) == 0 {
+			if j.Join.Type == joinLeft {
+				values = append(values, joinMerge(j, outer, outerFields, nil))
+			}
+			continue
+		}
+		for _, inner := range matches {
+			if len(innerFields) > 0 {
+				inner = utils.Project(inner, innerFields)
+			}
+			values = append(values, joinMerge(j, outer, outerFields, inner))
+		}
+	}
+	return int32(len(values)), values, nil
+}
+
+// joinInners 检索内侧表中可能匹配的数据
+func (db *database) joinInners(j *joiner, innerForm connector.Form, outers []interface{}, lefts [][]string) ([]interface{}, []string, error) {
+	innerSelector, innerFields, err := joinSelector(j.Join.Selector, true)
+	if nil != err {
+		return nil, nil, err
+	}
+	if !joinIndexed(innerForm, j.Join.On[0].Right) {
+		_, inners, err := innerForm.Select(innerSelector)
+		return inners, innerFields, err
+	}
+	var (
+		inners []interface{}
+		keys   = make(map[string]bool)
+		batch  []interface{}
+	)
+	for position, outer := range outers {
+		if key, ok := joinKey(outer, lefts[:1]); ok && !keys[key] {
+			keys[key] = true
+			value, _ := utils.ValueByParams(outer, lefts[0])
+			batch = append(batch, value)
+		}
+		if len(batch) == 0 || (len(batch) < joinBatch && position < len(outers)-1) {
+			continue
+		}
+		selectorBytes, err := joinCondition(innerSelector, j.Join.On[0].Right, batch)
+		if nil != err {
+			return nil, nil, err
+		}
+		_, values, err := innerForm.Select(selectorBytes)
+		if nil != err {
+			return nil, nil, err
+		}
+		inners, batch = append(inners, values...), nil
+	}
+	return inners, innerFields, nil
+}
+
+// joinIndexed 表中是否存在首字段为param且已可用的索引
+func joinIndexed(fm connector.Form, param string) bool {
+	for _, index := range fm.Indexes() {
+		if !index.Building && utils.KeyStructures(index.KeyStructure)[0] == param {
+			return true
+		}
+	}
+	return false
+}
+
+// joinSelector 整理连接两侧的检索选择器，取出字段投影以便连接完成后再投影，内侧表检索不受跳过、限制数量及分页游标约束
+func joinSelector(selectorBytes json.RawMessage, inner bool) ([]byte, []string, error) {
+	selector := make(map[string]interface{})
+	if len(selectorBytes) > 0 {
+		if err := json.Unmarshal(selectorBytes, &selector); nil != err {
+			return nil, nil, err
+		}
+	}
+	var fields []string
+	if list, ok := selector["Fields"].([]interface{}); ok {
+		for _, field := range list {
+			if f, ok := field.(string); ok {
+				fields = append(fields, f)
+			}
+		}
+	}
+	delete(selector, "Fields")
+	delete(selector, "Aggregate")
+	delete(selector, "Explain")
+	if inner {
+		delete(selector, "Skip")
+		delete(selector, "Cursor")
+		selector["Limit"] = uint32(math.MaxUint32)
+	}
+	data, err := json.Marshal(selector)
+	return data, fields, err
+}
+
+// joinCondition 在内侧表检索选择器中追加连接字段属于外侧表连接值的条件
+func joinCondition(selectorBytes []byte, param string, values []interface{}) ([]byte, error) {
+	selector := make(map[string]interface{})
+	if err := json.Unmarshal(selectorBytes, &selector); nil != err {
+		return nil, err
+	}
+	conditions, _ := selector["Conditions"].([]interface{})
+	selector["Conditions"] = append(conditions, map[string]interface{}{"Param": param, "Cond": "in", "Value": values})
+	return json.Marshal(selector)
+}
+
+// joinKey 由各连接字段值组成的哈希键，数值不论类型按数值相等，任一字段不存在、为空或不支持时不参与匹配
+func joinKey(value interface{}, params [][]string) (string, bool) {
+	var builder strings.Builder
+	for _, param := range params {
+		item, exist := utils.ValueByParams(value, param)
+		if !exist || nil == item {
+			return "", false
+		}
+		key, _, support := utils.Type2index(item)
+		if !support {
+			return "", false
+		}
+		switch item.(type) {
+		default:
+			key = "d" + key
+		case string:
+			key = "s" + key
+		case bool:
+			key = "b" + key
+		}
+		builder.WriteString(strconv.Itoa(len(key)))
+		builder.WriteString(":")
+		builder.WriteString(key)
+	}
+	return builder.String(), true
+}
+
+// joinMerge 将内侧表数据作为As字段合并至外侧表数据的浅拷贝中，连接完成后再按外侧表字段投影
+func joinMerge(j *joiner, outer interface{}, outerFields []string, inner interface{}) interface{} {
+	row, ok := utils.Project(outer, outerFields).(map[string]interface{})
+	if !ok {
+		row = map[string]interface{}{j.Form: outer}
+	}
+	row[j.Join.As] = inner
+	return row
+}
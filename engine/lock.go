@@ -0,0 +1,73 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package engine
+
+import (
+	"github.com/aberic/gnomon"
+	"github.com/aberic/lilydb/config"
+	"github.com/aberic/lilydb/engine/comm"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// Lock 将当前进程号写入进程锁文件，标识服务已启动
+//
+// 进程锁文件记录的进程仍存活时返回 comm.ErrServerRunning
+func Lock() error {
+	if _, alive := Locked(); alive {
+		return comm.ErrServerRunning
+	}
+	filePath := config.Obtain().LilyLockFilePath
+	if err := os.MkdirAll(gnomon.FileParentPath(filePath), os.ModePerm); nil != err {
+		return err
+	}
+	return ioutil.WriteFile(filePath, []byte(strconv.Itoa(os.Getpid())), 0644)
+}
+
+// Locked 读取进程锁文件中记录的进程号，并判断该进程是否仍存活
+//
+// 进程锁文件不存在或内容无效时返回进程号0
+func Locked() (pid int, alive bool) {
+	data, err := ioutil.ReadFile(config.Obtain().LilyLockFilePath)
+	if nil != err {
+		return 0, false
+	}
+	if pid, err = strconv.Atoi(strings.TrimSpace(string(data))); nil != err || pid <= 0 {
+		return 0, false
+	}
+	if pid == os.Getpid() {
+		return pid, false
+	}
+	process, err := os.FindProcess(pid)
+	if nil != err {
+		return pid, false
+	}
+	// 信号0仅检查进程是否存在，无权限发送信号时进程同样存在
+	err = process.Signal(syscall.Signal(0))
+	return pid, nil == err || err == syscall.EPERM
+}
@@ -31,13 +31,15 @@ import (
 	"github.com/aberic/lilydb/engine/comm"
 	"github.com/aberic/lilydb/engine/msiam/index"
 	"github.com/aberic/lilydb/engine/siam/utils"
-	"reflect"
 	"strings"
 	"sync"
 )
 
 const indexDefaultID = "lily_do_not_repeat_default_id"
 
+// backfillBatch 单次持有表锁回填的数据行数，批次之间释放表锁以便写入继续进行
+const backfillBatch = 1000
+
 // NewForm 新建表，会创建默认自增主键
 //
 // 所属数据库ID
@@ -58,7 +60,29 @@ func NewForm(databaseID, formID, formName, comment string) *Form {
 		formType:   api.FormType_MSiam,
 		databaseID: databaseID,
 	}
-	fm.NewIndex(indexDefaultID, true) // 创建默认主键
+	fm.NewIndex(indexDefaultID, true, true) // 创建默认主键
+	return fm
+}
+
+// RecoverForm 根据引导文件中记录的表结构恢复表，索引ID沿用记录中的值，不再新建默认主键
+//
+// 所属数据库ID
+//
+// apiForm 引导文件中记录的表结构
+func RecoverForm(databaseID string, apiForm *api.Form) *Form {
+	var autoID uint64 = 0
+	fm := &Form{
+		autoID:     &autoID,
+		name:       apiForm.Name,
+		id:         apiForm.ID,
+		comment:    apiForm.Comment,
+		indexes:    map[string]*index.Index{},
+		formType:   api.FormType_MSiam,
+		databaseID: databaseID,
+	}
+	for _, idx := range apiForm.Indexes {
+		fm.indexes[idx.ID] = index.NewIndex(databaseID, apiForm.ID, idx.ID, idx.KeyStructure, idx.Primary, idx.Unique)
+	}
 	return fm
 }
 
@@ -71,6 +95,7 @@ type Form struct {
 	formType   api.FormType            // 表类型 siam
 	indexes    map[string]*index.Index // 索引ID集合
 	databaseID string                  // 所属数据库ID
+	sync       func() error            // 表结构变化后同步引导文件
 
 	mu sync.RWMutex
 }
@@ -95,6 +120,27 @@ func (f *Form) Comment() string {
 	return f.comment
 }
 
+// Rename 修改表名，表唯一ID不变
+func (f *Form) Rename(name string) {
+	defer f.mu.Unlock()
+	f.mu.Lock()
+	f.name = name
+}
+
+// SetComment 修改表描述
+func (f *Form) SetComment(comment string) {
+	defer f.mu.Unlock()
+	f.mu.Lock()
+	f.comment = comment
+}
+
+// SetSync 设置表结构变化后同步引导文件的方法
+func (f *Form) SetSync(sync func() error) {
+	defer f.mu.Unlock()
+	f.mu.Lock()
+	f.sync = sync
+}
+
 // FormType 获取表类型
 func (f *Form) FormType() api.FormType {
 	return f.formType
@@ -102,23 +148,157 @@ func (f *Form) FormType() api.FormType {
 
 // Indexes 获取索引api集合
 func (f *Form) Indexes() map[string]*api.Index {
+	defer f.mu.RUnlock()
+	f.mu.RLock()
 	var idx = make(map[string]*api.Index)
 	for _, i := range f.indexes {
-		idx[i.ID()] = &api.Index{ID: i.ID(), Primary: i.Primary(), KeyStructure: i.KeyStructure()}
+		done, total := i.Progress()
+		idx[i.ID()] = &api.Index{ID: i.ID(), Primary: i.Primary(), KeyStructure: i.KeyStructure(), Unique: i.Unique(), Building: i.Building(), Done: done, Total: total}
 	}
 	return idx
 }
 
 // NewIndex 新建索引
 //
-// keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'
+// keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'，组合索引的各字段名称间通过','分隔，如'tenant,createdAt'
 //
 // primary 是否主键
-func (f *Form) NewIndex(keyStructure string, primary bool) {
+//
+// unique 是否唯一索引，非唯一索引的同一key可对应多行数据
+//
+// 已设置引导文件同步方法时，新建后同步引导文件
+func (f *Form) NewIndex(keyStructure string, primary, unique bool) error {
+	f.mu.Lock()
+	indexID := f.name2ID4Index(strings.Join([]string{f.name, keyStructure}, "_"))
+	f.indexes[indexID] = index.NewIndex(f.databaseID, f.id, indexID, keyStructure, primary, unique)
+	sync := f.sync
+	f.mu.Unlock()
+	if nil == sync {
+		return nil
+	}
+	return sync()
+}
+
+// CreateIndex 在线新建索引，并在后台以默认主键中已存在的数据回填
+//
+// 新索引立即接收新的写入，回填完成前不参与检索
+//
+// keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'，组合索引的各字段名称间通过','分隔，如'tenant,createdAt'
+//
+// primary 是否主键
+//
+// unique 是否唯一索引
+//
+// built 回填结束回调，err为空表示回填完成，已存在的数据不包含索引字段时回填失败，索引将被删除
+func (f *Form) CreateIndex(keyStructure string, primary, unique bool, built func(err error)) error {
 	defer f.mu.Unlock()
 	f.mu.Lock()
+	for _, idx := range f.indexes {
+		if idx.KeyStructure() == keyStructure {
+			return comm.ErrIndexExist
+		}
+	}
 	indexID := f.name2ID4Index(strings.Join([]string{f.name, keyStructure}, "_"))
-	f.indexes[indexID] = index.NewIndex(f.databaseID, f.id, indexID, keyStructure, primary)
+	idx := index.NewIndex(f.databaseID, f.id, indexID, keyStructure, primary, unique)
+	idx.Backfill(0)
+	var links []*index.Link // 与新索引加入表在同一持锁期间获取，此后写入的数据由写入过程建立索引
+	for _, def := range f.indexes {
+		if def.KeyStructure() == indexDefaultID {
+			def.Range(func(link *index.Link) bool {
+				links = append(links, link)
+				return true
+			})
+		}
+	}
+	f.indexes[indexID] = idx
+	go f.backfill(idx, links, built)
+	return nil
+}
+
+// backfill 以创建索引时默认主键中已存在的数据分批回填索引，批次之间释放表锁
+//
+// links 创建索引时默认主键中已存在的数据
+func (f *Form) backfill(idx *index.Index, links []*index.Link, built func(err error)) {
+	idx.Backfill(uint64(len(links)))
+	for start := 0; start < len(links); start += backfillBatch {
+		end := start + backfillBatch
+		if end > len(links) {
+			end = len(links)
+		}
+		if err := f.backfillRows(idx, links[start:end]); nil != err {
+			f.abandon(idx)
+			if nil != built {
+				built(err)
+			}
+			return
+		}
+	}
+	idx.Complete()
+	if nil != built {
+		built(nil)
+	}
+}
+
+// abandon 回填失败时将索引移出表
+func (f *Form) abandon(idx *index.Index) {
+	defer f.mu.Unlock()
+	f.mu.Lock()
+	if f.indexes[idx.ID()] == idx {
+		delete(f.indexes, idx.ID())
+	}
+}
+
+// backfillRows 回填一批数据行，已删除的数据行将被跳过，与写入时一致，数据行不包含索引字段时返回错误
+func (f *Form) backfillRows(idx *index.Index, links []*index.Link) error {
+	defer f.mu.Unlock()
+	f.mu.Lock()
+	if f.indexes[idx.ID()] != idx { // 回填期间索引已被删除
+		return comm.ErrIndexNotFound
+	}
+	var def *index.Index
+	for _, i := range f.indexes {
+		if i.KeyStructure() == indexDefaultID {
+			def = i
+		}
+	}
+	for _, link := range links {
+		idx.Advance(1)
+		if nil == def || def.Get(link.MD516Key(), utils.String2hashKey(link.Key())) != link { // 数据行已被删除
+			continue
+		}
+		key, hashKey, err := f.getCustomIndex(idx, link.Value())
+		if nil != err {
+			return err
+		}
+		idx.Put(key, gnomon.HashMD516(key), hashKey, link.Value(), 0)
+	}
+	return nil
+}
+
+// DropIndex 删除索引，默认主键不可删除
+//
+// keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'
+func (f *Form) DropIndex(keyStructure string) error {
+	if keyStructure == indexDefaultID {
+		return comm.ErrIndexProtected
+	}
+	defer f.mu.Unlock()
+	f.mu.Lock()
+	for indexID, idx := range f.indexes {
+		if idx.KeyStructure() == keyStructure {
+			delete(f.indexes, indexID)
+			return nil
+		}
+	}
+	return comm.ErrIndexNotFound
+}
+
+// Drop 删除表，释放表内所有数据
+func (f *Form) Drop() error {
+	defer f.mu.Unlock()
+	f.mu.Lock()
+	f.indexes = map[string]*index.Index{}
+	return nil
 }
 
 // name2ID4Index 确保表下索引唯一ID不重复
@@ -178,7 +358,7 @@ func (f *Form) Set(key string, value interface{}) (uint64, error) {
 //
 // 返回 获取的数据对象
 func (f *Form) Get(key string) (interface{}, error) {
-	hashKey := comm.Hash(key)
+	hashKey := utils.String2hashKey(key)
 	md516Key := gnomon.HashMD516(key)
 	if link := f.indexes[indexDefaultID].Get(md516Key, hashKey); nil != link {
 		return link.Value(), nil
@@ -192,7 +372,7 @@ func (f *Form) Get(key string) (interface{}, error) {
 //
 // 返回 删除的数据对象
 func (f *Form) Del(key string) (interface{}, error) {
-	hashKey := comm.Hash(key)
+	hashKey := utils.String2hashKey(key)
 	md516Key := gnomon.HashMD516(key)
 	return f.indexes[indexDefaultID].Del(md516Key, hashKey)
 }
@@ -209,16 +389,64 @@ func (f *Form) Del(key string) (interface{}, error) {
 //
 // return err 检索错误信息，如果有
 func (f *Form) Select(selectorBytes []byte) (int32, []interface{}, error) {
-	var indexes []*index.Index
-	for _, idx := range f.indexes {
-		indexes = append(indexes, idx)
-	}
-	selector, err := index.NewSelector(selectorBytes, indexes, f.databaseID, f.id, false)
+	count, values, _, err := f.Page(selectorBytes)
+	return count, values, err
+}
+
+// Page 根据条件分页检索
+//
+// databaseID 数据库唯一ID
+//
+// selectorBytes 选择器字节数组，自定义转换策略，可包含上一页返回的分页游标Cursor
+//
+// return count 检索结果总条数
+//
+// return values 检索结果集合
+//
+// return cursor 下一页的分页游标，已无更多数据时为空
+//
+// return err 检索错误信息，如果有
+func (f *Form) Page(selectorBytes []byte) (int32, []interface{}, string, error) {
+	selector, err := index.NewSelector(selectorBytes, f.searchable(), f.databaseID, f.id, false)
 	if nil != err {
-		return 0, nil, err
+		return 0, nil, "", err
 	}
 	count, values := selector.Run()
-	return count, values, nil
+	if err = selector.Err(); nil != err {
+		return 0, nil, "", err
+	}
+	return count, values, selector.NextCursor(), nil
+}
+
+// Stream 根据条件流式检索，检索结果在遍历索引的过程中逐条交由handler处理
+//
+// selectorBytes 选择器字节数组，自定义转换策略，Limit为0时不限制数量
+//
+// handler 检索结果处理方法，返回false时停止检索
+//
+// return count 停止前已检索的结果条数
+//
+// return err 检索错误信息，如果有
+func (f *Form) Stream(selectorBytes []byte, handler func(value interface{}) bool) (int32, error) {
+	selector, err := index.NewSelector(selectorBytes, f.searchable(), f.databaseID, f.id, false)
+	if nil != err {
+		return 0, err
+	}
+	count := selector.Range(handler)
+	return count, selector.Err()
+}
+
+// searchable 在读锁下获取可参与检索的索引集合，回填完成前的索引不参与检索
+func (f *Form) searchable() []*index.Index {
+	defer f.mu.RUnlock()
+	f.mu.RLock()
+	var indexes []*index.Index
+	for _, idx := range f.indexes {
+		if !idx.Building() {
+			indexes = append(indexes, idx)
+		}
+	}
+	return indexes
 }
 
 // Delete 根据条件删除
@@ -231,16 +459,22 @@ func (f *Form) Select(selectorBytes []byte) (int32, []interface{}, error) {
 //
 // return err 删除错误信息，如果有
 func (f *Form) Delete(selectorBytes []byte) (int32, error) {
-	var indexes []*index.Index
-	for _, idx := range f.indexes {
-		indexes = append(indexes, idx)
-	}
-	selector, err := index.NewSelector(selectorBytes, indexes, f.databaseID, f.id, true)
+	selector, err := index.NewSelector(selectorBytes, f.searchable(), f.databaseID, f.id, true)
 	if nil != err {
 		return 0, err
 	}
 	count, _ := selector.Run()
-	return count, nil
+	return count, selector.Err()
+}
+
+// Compact 压缩表数据文件，内存表无需压缩
+func (f *Form) Compact() error {
+	return comm.ErrFormNotFoundOrSupport
+}
+
+// Verify 校验表数据，内存表无需校验
+func (f *Form) Verify() ([]int64, error) {
+	return nil, comm.ErrFormNotFoundOrSupport
 }
 
 // rangeIndexes 遍历表索引ID集合，检索并计算所有索引返回对象集合
@@ -257,7 +491,7 @@ func (f *Form) store(key string, value interface{}, update bool) error {
 			var hashKey uint64
 			//gnomon.Log().Debug("rangeIndexes", gnomon.Log().Field("index.id", index.getID()), gnomon.Log().Field("index.keyStructure", index.getKeyStructure()))
 			if index.KeyStructure() == indexDefaultID {
-				hashKey = comm.Hash(key)
+				hashKey = utils.String2hashKey(key)
 			} else {
 				key, hashKey, err = f.getCustomIndex(index, value)
 				if nil != err {
@@ -279,57 +513,37 @@ func (f *Form) store(key string, value interface{}, update bool) error {
 }
 
 // getCustomIndex 获取自定义索引预插入返回对象
+//
+// 组合索引依次获取各字段的索引信息，并通过 utils.CompositeKey 组合为保序的key，任一字段无效则该行不写入组合索引
 func (f *Form) getCustomIndex(idx *index.Index, value interface{}) (key string, hashKey uint64, err error) {
-	reflectValue := reflect.ValueOf(value) // 反射对象，通过reflectObj获取存储在里面的值，还可以去改变值
-	params := strings.Split(idx.KeyStructure(), ".")
-	switch kind := reflectValue.Kind(); kind {
-	default:
-		err = fmt.Errorf("index %s with type is invalid", idx.KeyStructure())
-		return
-	case reflect.Map:
-		var (
-			item      interface{}
-			paramsLen = len(params)
-			position  int
-			itemMap   = value.(map[string]interface{})
-		)
-		for _, param := range params {
-			position++
-			item = itemMap[param]
-			if position == paramsLen { // 表示没有后续参数
-				break
-			}
-			switch item := item.(type) {
-			default:
-				err = fmt.Errorf("index %s with map is invalid", idx.KeyStructure())
-				return
-			case map[string]interface{}:
-				itemMap = item
-				continue
-			}
-		}
-		if keyNew, hashKeyNew, valid := utils.Type2index(item); valid {
-			return keyNew, hashKeyNew, nil
-		}
-		err = fmt.Errorf("index %s with map is invalid", idx.KeyStructure())
-		return
-	case reflect.Ptr:
-		checkValue := reflectValue
-		for _, param := range params {
-			checkNewValue := checkValue.Elem().FieldByName(param)
-			if checkNewValue.IsValid() { // 子字段有效
-				checkValue = checkNewValue
-				continue
-			}
-			err = fmt.Errorf("index %s with ptr is invalid", idx.KeyStructure())
+	if !idx.Composite() {
+		return f.getCustomKey(idx.KeyStructure(), value)
+	}
+	keyStructures := idx.KeyStructures()
+	keys, hashKeys := make([]string, len(keyStructures)), make([]uint64, len(keyStructures))
+	for position, keyStructure := range keyStructures {
+		if keys[position], hashKeys[position], err = f.getCustomKey(keyStructure, value); nil != err {
 			return
 		}
-		if keyNew, hashKeyNew, valid := utils.ValueType2index(&checkValue); valid {
-			return keyNew, hashKeyNew, nil
-		}
-		err = fmt.Errorf("index %s with ptr is invalid", idx.KeyStructure())
+	}
+	key, hashKey = utils.CompositeKey(keys, hashKeys)
+	return
+}
+
+// getCustomKey 获取单个索引字段的索引信息
+//
+// keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'
+func (f *Form) getCustomKey(keyStructure string, value interface{}) (key string, hashKey uint64, err error) {
+	checkValue, ok := utils.ReflectByParams(value, strings.Split(keyStructure, "."))
+	if !ok {
+		err = fmt.Errorf("index %s with type is invalid", keyStructure)
 		return
 	}
+	if keyNew, hashKeyNew, valid := utils.ValueType2index(&checkValue); valid {
+		return keyNew, hashKeyNew, nil
+	}
+	err = fmt.Errorf("index %s with type is invalid", keyStructure)
+	return
 }
 
 // Insert 新增数据
@@ -0,0 +1,89 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package msiam
+
+import (
+	"github.com/aberic/lilydb/config"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// TestMain 将数据目录指向临时目录，测试结束后删除
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "lilydb-msiam")
+	if nil != err {
+		panic(err)
+	}
+	conf := config.Obtain()
+	conf.RootDir = dir
+	conf.DataDir = filepath.Join(dir, "data")
+	conf.LilyLockFilePath = filepath.Join(dir, "lily.lock")
+	conf.LilyBootstrapFilePath = filepath.Join(conf.DataDir, "lily.sync")
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+type Value struct {
+	Name string
+	Age  int
+}
+
+// TestForm_PageDuringCreateIndex 在线建立索引期间并发检索，需配合 -race 运行
+//
+// 每次检索均会输出调试日志，而日志库自身的异步写入存在竞争，因此只检索一次
+func TestForm_PageDuringCreateIndex(t *testing.T) {
+	fm := NewForm("databaseID", "formCreateIndexID", "formCreateIndex", "comment")
+	defer func() { _ = fm.Drop() }()
+	for i := 0; i < 3*backfillBatch; i++ {
+		if _, err := fm.Put(strconv.Itoa(i), &Value{Name: "name", Age: i}); nil != err {
+			t.Fatal(err)
+		}
+	}
+	paged := make(chan error, 1)
+	go func() {
+		_, _, _, err := fm.Page([]byte(`{"Conditions":[{"Param":"Age","Cond":"lt","Value":10}],"Limit":10}`))
+		fm.Indexes()
+		paged <- err
+	}()
+	built := make(chan error, 1)
+	if err := fm.CreateIndex("Age", false, false, func(err error) { built <- err }); nil != err {
+		t.Fatal(err)
+	}
+	if err := <-paged; nil != err {
+		t.Fatal(err)
+	}
+	if err := <-built; nil != err {
+		t.Fatal(err)
+	}
+	for _, idx := range fm.Indexes() {
+		if idx.KeyStructure == "Age" && (idx.Building || idx.Done != 3*backfillBatch) {
+			t.Fatal(idx)
+		}
+	}
+}
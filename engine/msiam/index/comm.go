@@ -25,8 +25,7 @@
 package index
 
 import (
-	"github.com/aberic/gnomon"
-	"strconv"
+	"github.com/aberic/lilydb/engine/siam/utils"
 )
 
 const (
@@ -55,126 +54,47 @@ func levelDistance(level uint8) uint64 {
 	return 0
 }
 
-// conditionValueInt64 判断当前条件是否满足
-func conditionValueInt64(cond string, paramType paramType, paramValue interface{}, value interface{}) bool {
-	var (
-		valueInt64      int64
-		paramInt64Value int64
-	)
-	switch value := value.(type) {
-	default:
-		return false
-	case int:
-		valueInt64 = int64(value)
-	case int8:
-		valueInt64 = int64(value)
-	case int16:
-		valueInt64 = int64(value)
-	case int32:
-		valueInt64 = int64(value)
-	case int64:
-		valueInt64 = value
-	}
-	if paramType != paramInt64 {
-		var success bool
-		paramInt64Value, success = param2Int64(paramType, paramValue)
-		if !success {
-			return false
-		}
-	} else {
-		paramInt64Value = paramValue.(int64)
-	}
-	switch cond {
-	default:
-		return false
-	case "gt":
-		return valueInt64 > paramInt64Value
-	case "lt":
-		return valueInt64 < paramInt64Value
-	case "eq":
-		return valueInt64 == paramInt64Value
-	case "dif":
-		return valueInt64 != paramInt64Value
-	}
-}
-
-// conditionValueUint64 判断当前条件是否满足
-func conditionValueUint64(cond string, paramType paramType, paramValue interface{}, value interface{}) bool {
-	var (
-		valueUint64      uint64
-		paramUint64Value uint64
-	)
-	switch value := value.(type) {
-	default:
-		return false
-	case uint:
-		valueUint64 = uint64(value)
-	case uint8:
-		valueUint64 = uint64(value)
-	case uint16:
-		valueUint64 = uint64(value)
-	case uint32:
-		valueUint64 = uint64(value)
-	case uint64:
-		valueUint64 = value
-	}
-	if paramType != paramUint64 {
-		var success bool
-		paramUint64Value, success = param2UInt64(paramType, paramValue)
-		if !success {
-			return false
-		}
-	} else {
-		paramUint64Value = paramValue.(uint64)
-	}
-	switch cond {
-	default:
-		return false
-	case "gt":
-		return valueUint64 > paramUint64Value
-	case "lt":
-		return valueUint64 < paramUint64Value
-	case "eq":
-		return value == paramUint64Value
-	case "dif":
-		return value != paramUint64Value
+// degreeIndex 根据hashKey获取其在指定层级节点中的下标，level1仅有一个节点，下标为0
+func degreeIndex(hashKey uint64, level uint8) uint16 {
+	switch level {
+	case 1:
+		return 0
+	case 2:
+		return uint16(hashKey / level1Distance)
 	}
+	return uint16(hashKey % levelDistance(level-2) / levelDistance(level-1))
 }
 
-// conditionValueFloat64 判断当前条件是否满足
-func conditionValueFloat64(cond string, paramType paramType, paramValue interface{}, value interface{}) bool {
-	var (
-		valueFloat64      float64
-		paramFloat64Value float64
-	)
-	switch value := value.(type) {
+// conditionValueNumber 判断当前条件是否满足，int、uint及float之间按数值大小无损比较
+func conditionValueNumber(cond string, paramType paramType, paramValue interface{}, value utils.Number) bool {
+	var number utils.Number
+	switch paramType {
 	default:
 		return false
-	case float32:
-		valueFloat64 = float64(value)
-	case float64:
-		valueFloat64 = value
-	}
-	if paramType != paramFloat64 {
+	case paramNumber:
+		number = paramValue.(utils.Number)
+	case paramString:
 		var success bool
-		paramFloat64Value, success = param2Float64(paramType, paramValue)
-		if !success {
+		if number, success = utils.ParseNumber(paramValue.(string)); !success {
 			return false
 		}
-	} else {
-		paramFloat64Value = paramValue.(float64)
 	}
+	compare := value.Compare(number)
 	switch cond {
 	default:
 		return false
 	case "gt":
-		return valueFloat64 > paramFloat64Value
+		return compare > 0
 	case "lt":
-		return valueFloat64 < paramFloat64Value
+		return compare < 0
+	case "ge":
+		return compare >= 0
+	case "le":
+		return compare <= 0
 	case "eq":
-		return valueFloat64 == paramFloat64Value
+		return compare == 0
 	case "dif":
-		return valueFloat64 != paramFloat64Value
+		return compare != 0
 	}
 }
 
@@ -197,6 +117,10 @@ func conditionValueString(cond string, paramType paramType, paramValue interface
 		return value > paramStringValue
 	case "lt":
 		return value < paramStringValue
+	case "ge":
+		return value >= paramStringValue
+	case "le":
+		return value <= paramStringValue
 	case "eq":
 		return value == paramStringValue
 	case "dif":
@@ -216,84 +140,12 @@ func conditionValueBool(cond string, param, value bool) bool {
 	}
 }
 
-func param2Int64(paramType paramType, paramValue interface{}) (paramInt int64, trans bool) {
-	switch paramType {
-	default:
-		return 0, false
-	case paramInt64:
-		return paramValue.(int64), true
-	case paramUint64:
-		uint64Str := strconv.FormatUint(paramValue.(uint64), 10) // uint64转string
-		intNum, err := strconv.Atoi(uint64Str)                   // string转int
-		if nil != err {
-			return 0, false
-		}
-		return int64(intNum), true
-	case paramFloat64:
-		return gnomon.ScaleFloat64toInt64(paramValue.(float64), 0), true
-	case paramString:
-		intNum, err := strconv.Atoi(paramValue.(string)) // string转int
-		if nil != err {
-			return 0, false
-		}
-		return int64(intNum), true
-	}
-}
-
-func param2UInt64(paramType paramType, paramValue interface{}) (paramInt uint64, trans bool) {
-	switch paramType {
-	default:
-		return 0, false
-	case paramInt64:
-		int64Str := strconv.FormatInt(paramValue.(int64), 10)
-		intNum, err := strconv.Atoi(int64Str)
-		if nil != err {
-			return 0, false
-		}
-		return uint64(intNum), true
-	case paramUint64:
-		return paramValue.(uint64), true
-	case paramFloat64:
-		return param2UInt64(paramInt64, gnomon.ScaleFloat64toInt64(paramValue.(float64), 0))
-	case paramString:
-		intNum, err := strconv.Atoi(paramValue.(string)) // string转int
-		if nil != err {
-			return 0, false
-		}
-		return uint64(intNum), true
-	}
-}
-
-func param2Float64(paramType paramType, paramValue interface{}) (paramInt float64, trans bool) {
-	switch paramType {
-	default:
-		return 0, false
-	case paramInt64:
-		return gnomon.ScaleInt64toFloat64(paramValue.(int64), 0), true
-	case paramUint64:
-		uint64Str := strconv.FormatUint(paramValue.(uint64), 10) // uint64转string
-		return param2Float64(paramString, uint64Str)
-	case paramFloat64:
-		return paramValue.(float64), true
-	case paramString:
-		intNum, err := strconv.Atoi(paramValue.(string)) // string转int
-		if nil != err {
-			return 0, false
-		}
-		return param2Float64(paramInt64, int64(intNum))
-	}
-}
-
 func param2String(paramType paramType, paramValue interface{}) (paramInt string, trans bool) {
 	switch paramType {
 	default:
 		return "", false
-	case paramInt64:
-		return strconv.FormatInt(paramValue.(int64), 10), true
-	case paramUint64:
-		return strconv.FormatUint(paramValue.(uint64), 10), true
-	case paramFloat64:
-		return strconv.FormatFloat(paramValue.(float64), 'E', -1, 64), true
+	case paramNumber:
+		return paramValue.(utils.Number).String(), true
 	case paramString:
 		return paramValue.(string), true
 	}
@@ -24,6 +24,11 @@
 
 package index
 
+import (
+	"github.com/aberic/lilydb/engine/siam/utils"
+	"sync/atomic"
+)
+
 // NewIndex 新建索引
 //
 // databaseID 数据库唯一ID
@@ -32,17 +37,21 @@ package index
 //
 // id 索引唯一ID
 //
-// keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'
+// keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'，组合索引的各字段名称间通过','分隔，如'tenant,createdAt'
 //
 // primary 是否主键
-func NewIndex(databaseID, formID, id, keyStructure string, primary bool) *Index {
+//
+// unique 是否唯一索引，主键总是唯一索引，非唯一索引的同一key可对应多行数据
+func NewIndex(databaseID, formID, id, keyStructure string, primary, unique bool) *Index {
 	return &Index{
-		id:           id,
-		primary:      primary,
-		keyStructure: keyStructure,
-		node:         &node{level: 1, degreeIndex: 0, preNode: nil, nodes: []*node{}},
-		databaseID:   databaseID,
-		formID:       formID,
+		id:            id,
+		primary:       primary,
+		unique:        primary || unique,
+		keyStructure:  keyStructure,
+		keyStructures: utils.KeyStructures(keyStructure),
+		node:          &node{level: 1, degreeIndex: 0, preNode: nil, nodes: []*node{}},
+		databaseID:    databaseID,
+		formID:        formID,
 	}
 }
 
@@ -50,12 +59,17 @@ func NewIndex(databaseID, formID, id, keyStructure string, primary bool) *Index
 //
 // 5位key及16位md5后key及5位起始seek和4位持续seek
 type Index struct {
-	id           string // id 索引唯一ID
-	primary      bool   // 是否主键
-	keyStructure string // keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'
-	node         *node  // 节点
-	databaseID   string // 所属数据库ID
-	formID       string // 所属表ID
+	id            string   // id 索引唯一ID
+	primary       bool     // 是否主键
+	unique        bool     // 是否唯一索引
+	keyStructure  string   // keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'
+	keyStructures []string // keyStructures 组合索引按声明顺序拆分的各字段名称，单字段索引仅包含keyStructure
+	node          *node    // 节点
+	databaseID    string   // 所属数据库ID
+	formID        string   // 所属表ID
+	building      int32    // 是否正在回填已存在的数据，回填完成前不参与检索
+	done          uint64   // 已回填数据行数
+	total         uint64   // 需回填数据行数
 }
 
 // ID 索引唯一ID
@@ -68,11 +82,62 @@ func (i *Index) Primary() bool {
 	return i.primary
 }
 
+// Unique 是否唯一索引
+func (i *Index) Unique() bool {
+	return i.unique
+}
+
 // KeyStructure 索引字段名称，由对象结构层级字段通过'.'组成，如
 func (i *Index) KeyStructure() string {
 	return i.keyStructure
 }
 
+// KeyStructures 按声明顺序拆分的各字段名称，组合索引按首字段有序
+func (i *Index) KeyStructures() []string {
+	return i.keyStructures
+}
+
+// Composite 是否由多个字段组成的组合索引
+func (i *Index) Composite() bool {
+	return len(i.keyStructures) > 1
+}
+
+// Rows 索引中的数据行数，作为检索计划估算代价的统计信息
+func (i *Index) Rows() int64 {
+	return atomic.LoadInt64(&i.node.count)
+}
+
+// Backfill 标记索引正在回填已存在的数据
+//
+// total 需回填数据行数
+func (i *Index) Backfill(total uint64) {
+	atomic.StoreUint64(&i.total, total)
+	atomic.StoreUint64(&i.done, 0)
+	atomic.StoreInt32(&i.building, 1)
+}
+
+// Advance 记录已回填数据行数
+func (i *Index) Advance(delta uint64) {
+	atomic.AddUint64(&i.done, delta)
+}
+
+// Complete 回填完成，索引开始参与检索
+func (i *Index) Complete() {
+	atomic.StoreInt32(&i.building, 0)
+}
+
+// Building 是否正在回填已存在的数据
+func (i *Index) Building() bool {
+	return atomic.LoadInt32(&i.building) == 1
+}
+
+// Progress 回填进度
+//
+// 返回 done 已回填数据行数，total 需回填数据行数
+func (i *Index) Progress() (done, total uint64) {
+	return atomic.LoadUint64(&i.done), atomic.LoadUint64(&i.total)
+}
+
 // Put 插入数据
 //
 // key 必须string类型
@@ -84,8 +149,10 @@ func (i *Index) KeyStructure() string {
 // value 存储对象
 //
 // version 当前索引数据版本号
+//
+// 非唯一索引总是新建link，exist恒为false
 func (i *Index) Put(key, md516Key string, hashKey uint64, value interface{}, version int) (link *Link, exist, versionGT bool) {
-	return i.node.put(key, md516Key, hashKey, value, version)
+	return i.node.put(key, md516Key, hashKey, value, version, i.unique)
 }
 
 // Get 获取数据，返回存储对象
@@ -105,3 +172,8 @@ func (i *Index) Get(md516Key string, hashKey uint64) *Link {
 func (i *Index) Del(md516Key string, hashKey uint64) (interface{}, error) {
 	return i.node.del(md516Key, hashKey, hashKey)
 }
+
+// Range 按索引顺序遍历所有link，handler返回false时终止遍历
+func (i *Index) Range(handler func(link *Link) bool) {
+	i.node.rangeLinks(handler)
+}
@@ -26,12 +26,13 @@ package index
 
 import (
 	"github.com/aberic/gnomon"
+	"github.com/aberic/lilydb/engine/siam/utils"
 	"strconv"
 	"testing"
 )
 
 func newIndex(databaseID, formID string) *Index {
-	return NewIndex(databaseID, formID, "indexID", "indexID", true)
+	return NewIndex(databaseID, formID, "indexID", "indexID", true, true)
 }
 
 func linkFit() *Link {
@@ -63,7 +64,7 @@ func TestLink_Version(t *testing.T) {
 }
 
 func TestNewIndex(t *testing.T) {
-	t.Log(NewIndex("database", "form", "indexID", "id", true))
+	t.Log(NewIndex("database", "form", "indexID", "id", true, true))
 }
 
 func TestIndex_ID(t *testing.T) {
@@ -172,3 +173,233 @@ func TestSelector_Run(t *testing.T) {
 		t.Log(v)
 	}
 }
+
+func TestSelector_RunStruct(t *testing.T) {
+	var (
+		idx     = newIndex("database", "form")
+		indexes = []*Index{idx}
+	)
+	type In struct {
+		Tags  []string
+		Attrs map[interface{}]interface{}
+	}
+	type Value struct {
+		Name string
+		Age  int
+		In   *In
+	}
+	var i uint64
+	for i = 0; i < 10; i++ {
+		v := Value{Name: "name", Age: int(i), In: &In{Tags: []string{"tag", strconv.Itoa(int(i % 2))}, Attrs: map[interface{}]interface{}{"level": int(i)}}}
+		_, _, _ = idx.Put("key", gnomon.HashMD516(strconv.Itoa(int(i))), i, v, 0)
+	}
+	selector, err := NewSelector([]byte(`{"Conditions":[{"Param":"In.Tags.1","Cond":"eq","Value":"1"},{"Param":"In.Attrs.level","Cond":"gt","Value":4}],"Sort":{"Param":"Age","Asc":true}}`), indexes, "database", "form", false)
+	if nil != err {
+		t.Error(err)
+	}
+	count, values := selector.Run()
+	if count != 3 {
+		t.Error("struct select failed", count, values)
+	}
+	for position, age := range []int{5, 7, 9} {
+		if position < len(values) && values[position].(Value).Age != age {
+			t.Error("struct sort failed", values)
+		}
+	}
+}
+
+func TestSelector_RunOperators(t *testing.T) {
+	var (
+		idx     = NewIndex("database", "form", "indexID", "Age", false, true)
+		indexes = []*Index{idx}
+	)
+	type Value struct {
+		Name string
+		Age  int
+	}
+	for i := 0; i < 10; i++ {
+		key, hashKey, _ := utils.Type2index(i)
+		_, _, _ = idx.Put(key, gnomon.HashMD516(key), hashKey, &Value{Name: "name" + strconv.Itoa(i), Age: i}, 0)
+	}
+	for selectorJSON, want := range map[string]int32{
+		`{"Conditions":[{"Param":"Age","Cond":"between","Value":[3,6]}]}`:                                        4,
+		`{"Conditions":[{"Param":"Age","Cond":"in","Value":[1,5,12]}]}`:                                          2,
+		`{"Conditions":[{"Param":"Age","Cond":"ge","Value":8}]}`:                                                 2,
+		`{"Conditions":[{"Param":"Name","Cond":"regex","Value":"^name[0-2]$"}]}`:                                 3,
+		`{"Conditions":[{"Param":"Age","Cond":"le","Value":1},{"Param":"Name","Cond":"prefix","Value":"name"}]}`: 2,
+	} {
+		selector, err := NewSelector([]byte(selectorJSON), indexes, "database", "form", false)
+		if nil != err {
+			t.Error(err)
+			continue
+		}
+		if count, values := selector.Run(); count != want {
+			t.Error("operator select failed", selectorJSON, count, values)
+		}
+	}
+}
+
+func TestSelector_RunAggregate(t *testing.T) {
+	var (
+		idx     = NewIndex("database", "form", "indexID", "Age", false, true)
+		indexes = []*Index{idx}
+	)
+	type Value struct {
+		Name string
+		Age  int
+	}
+	for i := 0; i < 10; i++ {
+		key, hashKey, _ := utils.Type2index(i)
+		_, _, _ = idx.Put(key, gnomon.HashMD516(key), hashKey, &Value{Name: "name" + strconv.Itoa(i%3), Age: i}, 0)
+	}
+	selector, err := NewSelector([]byte(`{"Sort":{"Param":"total","Asc":false},"Aggregate":{"GroupBy":["Name"],"Accumulators":[{"Name":"total","Op":"sum","Param":"Age"}]}}`), indexes, "database", "form", false)
+	if nil != err {
+		t.Fatal(err)
+	}
+	count, values := selector.Run()
+	if count != 3 || len(values) != 3 {
+		t.Fatal("aggregate select failed", count, values)
+	}
+	if value := values[0].(map[string]interface{}); value["Name"] != "name0" || value["total"] != int64(18) { // 0+3+6+9
+		t.Error("aggregate sort failed", values)
+	}
+}
+
+func TestSelector_RunSorts(t *testing.T) {
+	var (
+		idx     = NewIndex("database", "form", "indexID", "Age", false, true)
+		indexes = []*Index{idx}
+	)
+	type Value struct {
+		Name *string
+		Age  int
+	}
+	for i := 0; i < 10; i++ {
+		key, hashKey, _ := utils.Type2index(i)
+		name := "name" + strconv.Itoa(i%3)
+		value := &Value{Name: &name, Age: i}
+		if i == 5 {
+			value.Name = nil
+		}
+		_, _, _ = idx.Put(key, gnomon.HashMD516(key), hashKey, value, 0)
+	}
+	selector, err := NewSelector([]byte(`{"Sorts":[{"Param":"Name","Asc":false,"Nulls":"first"},{"Param":"Age","Asc":true}],"Skip":1,"Limit":4}`), indexes, "database", "form", false)
+	if nil != err {
+		t.Fatal(err)
+	}
+	count, values := selector.Run()
+	if count != 10 || len(values) != 4 || selector.NextCursor() != "" {
+		t.Fatal("sorts select failed", count, values)
+	}
+	for position, age := range []int{2, 8, 1, 4} { // 跳过Name为空的5后，name2及name1组内按Age升序
+		if values[position].(*Value).Age != age {
+			t.Error("sorts order failed", position, values[position])
+		}
+	}
+	if _, err = NewSelector([]byte(`{"Sorts":[{"Param":"Name","Nulls":"middle"}]}`), indexes, "database", "form", false); nil == err {
+		t.Error("sort nulls should not be supported")
+	}
+}
+
+func TestSelector_Range(t *testing.T) {
+	var (
+		idx     = NewIndex("database", "form", "indexID", "Age", false, true)
+		indexes = []*Index{idx}
+		ages    []int
+	)
+	type Value struct {
+		Age int
+	}
+	for i := 0; i < 10; i++ {
+		key, hashKey, _ := utils.Type2index(i)
+		_, _, _ = idx.Put(key, gnomon.HashMD516(key), hashKey, &Value{Age: i}, 0)
+	}
+	selector, err := NewSelector([]byte(`{"Conditions":[{"Param":"Age","Cond":"gt","Value":2}],"Sort":{"Param":"Age","Asc":false}}`), indexes, "database", "form", false)
+	if nil != err {
+		t.Fatal(err)
+	}
+	selector.Range(func(value interface{}) bool {
+		ages = append(ages, value.(*Value).Age)
+		return len(ages) < 4
+	})
+	if len(ages) != 4 || ages[0] != 9 || ages[3] != 6 {
+		t.Error("range failed", ages)
+	}
+}
+
+func TestSelector_RunCursor(t *testing.T) {
+	var (
+		idx     = NewIndex("database", "form", "indexID", "Age", false, true)
+		indexes = []*Index{idx}
+		ages    []int
+		cursor  string
+	)
+	type Value struct {
+		Age int
+	}
+	for i := 0; i < 10; i++ {
+		key, hashKey, _ := utils.Type2index(i)
+		_, _, _ = idx.Put(key, gnomon.HashMD516(key), hashKey, &Value{Age: i}, 0)
+	}
+	for {
+		selector, err := NewSelector([]byte(`{"Limit":3,"Sort":{"Param":"Age","Asc":false},"Cursor":"`+cursor+`"}`), indexes, "database", "form", false)
+		if nil != err {
+			t.Fatal(err)
+		}
+		_, values := selector.Run()
+		for _, value := range values {
+			ages = append(ages, value.(*Value).Age)
+		}
+		if cursor = selector.NextCursor(); cursor == "" {
+			break
+		}
+	}
+	if len(ages) != 10 || ages[0] != 9 || ages[9] != 0 {
+		t.Error("cursor select failed", ages)
+	}
+}
+
+func TestSelector_RunCursorNonUnique(t *testing.T) {
+	var (
+		idx     = NewIndex("database", "form", "indexID", "Age", false, false)
+		indexes = []*Index{idx}
+		seen    = map[int]bool{}
+		cursor  string
+	)
+	type Value struct {
+		Age int
+		N   int
+	}
+	for i := 0; i < 10; i++ {
+		key, hashKey, _ := utils.Type2index(1)
+		_, _, _ = idx.Put(key, gnomon.HashMD516(key), hashKey, &Value{Age: 1, N: i}, 0)
+	}
+	for pages := 0; pages < 10; pages++ {
+		selector, err := NewSelector([]byte(`{"Limit":3,"Sort":{"Param":"Age","Asc":true},"Cursor":"`+cursor+`"}`), indexes, "database", "form", false)
+		if nil != err {
+			t.Fatal(err)
+		}
+		_, values := selector.Run()
+		for _, value := range values {
+			if seen[value.(*Value).N] {
+				t.Error("page repeated", value.(*Value).N)
+			}
+			seen[value.(*Value).N] = true
+		}
+		if pages == 0 { // 翻页之间删除上一页已返回的首条数据
+			del, err := NewSelector([]byte(`{"Conditions":[{"Param":"N","Cond":"eq","Value":`+strconv.Itoa(values[0].(*Value).N)+`}]}`), indexes, "database", "form", true)
+			if nil != err {
+				t.Fatal(err)
+			}
+			if count, _ := del.Run(); count != 1 {
+				t.Fatal("delete failed", count)
+			}
+		}
+		if cursor = selector.NextCursor(); cursor == "" {
+			break
+		}
+	}
+	if len(seen) != 10 {
+		t.Error("page missed", len(seen), seen)
+	}
+}
@@ -24,14 +24,25 @@
 
 package index
 
+import "sync/atomic"
+
+// linkID 进程内已分配的最大link标识
+var linkID uint64
+
 // Link 叶子节点下的链表对象接口
 type Link struct {
+	id       uint64      // 创建时分配的唯一标识，更新时不变，用于分页游标定位数据行
 	key      string      // 存入key
 	md516Key string      // md516后的key
 	value    interface{} // 值
 	version  int         // 当前索引数据版本号
 }
 
+// newLink 新建分配了唯一标识的link
+func newLink(key, md516Key string, value interface{}, version int) *Link {
+	return &Link{id: atomic.AddUint64(&linkID, 1), key: key, md516Key: md516Key, value: value, version: version}
+}
+
 // Fit 填充数据
 //
 // key 存入key
@@ -67,3 +78,8 @@ func (l *Link) Value() interface{} {
 func (l *Link) Version() int {
 	return l.version
 }
+
+// row 数据行标识，即link唯一标识
+func (l *Link) row() uint64 {
+	return l.id
+}
@@ -27,9 +27,12 @@ package index
 import (
 	"errors"
 	"github.com/aberic/lilydb/engine/comm"
+	"github.com/aberic/lilydb/engine/plan"
+	"math"
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 // node 手提袋
@@ -45,6 +48,7 @@ type node struct {
 	preNode     *node  // node 所属 trolley
 	nodes       []*node
 	links       []*Link
+	count       int64 // 当前节点下的link数量，作为检索计划估算命中行数的统计信息
 	mu          sync.RWMutex
 }
 
@@ -60,8 +64,10 @@ type node struct {
 //
 // version 当前索引数据版本号
 //
+// unique 是否唯一索引，非唯一索引总是新建link
+//
 // return exist 返回是否存在
-func (n *node) put(key, md516Key string, flexibleKey uint64, value interface{}, version int) (link *Link, exist, versionGT bool) {
+func (n *node) put(key, md516Key string, flexibleKey uint64, value interface{}, version int, unique bool) (link *Link, exist, versionGT bool) {
 	var (
 		nextDegree      uint16 // 下一节点所在当前节点下度的坐标
 		nextFlexibleKey uint64 // 下一级最左最小树所对应真实key
@@ -79,9 +85,9 @@ func (n *node) put(key, md516Key string, flexibleKey uint64, value interface{},
 			nd = n.createOrTakeNode(nextDegree) // 创建或获取下一个子节点
 		}
 	} else {
-		return n.link(key, md516Key, value, version)
+		return n.link(key, md516Key, value, version, unique)
 	}
-	return nd.put(key, md516Key, nextFlexibleKey, value, version)
+	return nd.put(key, md516Key, nextFlexibleKey, value, version, unique)
 }
 
 // get 获取数据，返回存储数据的可检索对象
@@ -140,6 +146,28 @@ func (n *node) del(md516Key string, hashKey, flexibleKey uint64) (interface{}, e
 	return nil, comm.ErrLinkNotFound
 }
 
+// rangeLinks 按索引顺序遍历所有link，handler返回false时终止遍历
+func (n *node) rangeLinks(handler func(link *Link) bool) bool {
+	if n.level < 5 {
+		for _, nd := range n.nodes {
+			if !nd.rangeLinks(handler) {
+				return false
+			}
+		}
+		return true
+	}
+	n.mu.RLock()
+	links := make([]*Link, len(n.links))
+	copy(links, n.links)
+	n.mu.RUnlock()
+	for _, link := range links {
+		if !handler(link) {
+			return false
+		}
+	}
+	return true
+}
+
 func (n *node) existNode(index uint16) (realIndex int, err error) {
 	return n.binaryMatchData(index)
 }
@@ -193,7 +221,17 @@ func (n *node) createOrTakeLeaf(index uint16) *node {
 // value 值
 //
 // version 当前索引数据版本号
-func (n *node) link(key, md516Key string, value interface{}, version int) (lk *Link, exist, versionGT bool) {
+//
+// unique 是否唯一索引，非唯一索引总是新建link
+func (n *node) link(key, md516Key string, value interface{}, version int, unique bool) (lk *Link, exist, versionGT bool) {
+	if !unique {
+		defer n.mu.Unlock()
+		n.mu.Lock()
+		lk = newLink(key, md516Key, value, version)
+		n.links = append(n.links, lk)
+		n.addCount(1)
+		return lk, false, true
+	}
 	if pos, exist := n.existLink(md516Key); exist {
 		lk = n.links[pos]
 		if version > lk.version {
@@ -203,8 +241,9 @@ func (n *node) link(key, md516Key string, value interface{}, version int) (lk *L
 	}
 	defer n.mu.Unlock()
 	n.mu.Lock()
-	lk = &Link{key: key, md516Key: md516Key, value: value, version: version}
+	lk = newLink(key, md516Key, value, version)
 	n.links = append(n.links, lk)
+	n.addCount(1)
 	return lk, false, true
 }
 
@@ -226,6 +265,7 @@ func (n *node) removeLink(md516Key string) (interface{}, error) {
 	for index, link := range n.links {
 		if strings.EqualFold(link.MD516Key(), md516Key) {
 			n.links = append(n.links[:index], n.links[index+1:]...)
+			n.addCount(-1)
 			return link, nil
 		}
 	}
@@ -289,3 +329,48 @@ func (n *node) binaryMatchData(matchIndex uint16) (realIndex int, err error) {
 	}
 	return 0, errors.New("index is nil")
 }
+
+// Count 当前节点下的link数量
+func (n *node) Count() int64 {
+	return atomic.LoadInt64(&n.count)
+}
+
+// Children 子节点集合，叶子节点返回nil
+func (n *node) Children() []plan.Tree {
+	nodes := n.nodes
+	if nil == nodes {
+		return nil
+	}
+	children := make([]plan.Tree, len(nodes))
+	for i, nd := range nodes {
+		children[i] = nd
+	}
+	return children
+}
+
+// addCount 变更当前节点及各上级节点下的link数量
+func (n *node) addCount(delta int64) {
+	for nd := n; nil != nd; nd = nd.preNode {
+		atomic.AddInt64(&nd.count, delta)
+	}
+}
+
+// path 自第二层级节点至当前节点各节点的degreeIndex，用于生成分页游标
+func (n *node) path() []uint16 {
+	path := make([]uint16, 4)
+	for nd := n; nil != nd.preNode; nd = nd.preNode {
+		path[nd.level-2] = nd.degreeIndex
+	}
+	return path
+}
+
+// scope 当前节点所能容纳的hashKey范围，叶子节点仅容纳一个hashKey
+func (n *node) scope() (lo, hi uint64) {
+	if n.level == 1 {
+		return 0, math.MaxUint64
+	}
+	for nd := n; nd.level > 1; nd = nd.preNode {
+		lo += uint64(nd.degreeIndex) * levelDistance(nd.level-1)
+	}
+	return lo, lo + levelDistance(n.level-1) - 1
+}
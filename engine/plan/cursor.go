@@ -0,0 +1,130 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package plan
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/aberic/lilydb/engine/comm"
+)
+
+// Cursor 分页游标，记录上一页最后一条数据在所用索引中的位置
+//
+// 游标按索引树中的位置续查，不受两次检索之间新增或删除数据的影响，不会重复或跳过未变化的数据
+type Cursor struct {
+	IndexID  string   `json:"I"` // IndexID 所用索引ID
+	Asc      bool     `json:"A"` // Asc 是否顺序检索
+	Unique   bool     `json:"U"` // Unique 所用索引是否唯一索引，唯一索引叶子节点内md516Key即可确定数据行
+	Path     []uint16 `json:"P"` // Path 自第二层级节点至叶子节点各节点的degreeIndex
+	Position int      `json:"N"` // Position 最后一条数据在叶子节点中的下标
+	MD516Key string   `json:"K"` // MD516Key 最后一条数据的md516Key，叶子节点内数据变化时据此重新定位
+	Row      uint64   `json:"R"` // Row 最后一条数据的行标识，非唯一索引叶子节点内各数据md516Key相同，据此重新定位
+}
+
+// Decode 解析分页游标
+func Decode(cursorStr string) (*Cursor, error) {
+	var (
+		c    = &Cursor{}
+		data []byte
+		err  error
+	)
+	if data, err = base64.RawURLEncoding.DecodeString(cursorStr); nil != err {
+		return nil, comm.ErrCursorInvalid
+	}
+	if err = json.Unmarshal(data, c); nil != err || len(c.Path) != 4 {
+		return nil, comm.ErrCursorInvalid
+	}
+	return c, nil
+}
+
+// Encode 编码为不透明的分页游标字符串
+//
+// path 自第二层级节点至最后一条数据所在叶子节点各节点的degreeIndex
+func (c *Cursor) Encode(path []uint16) string {
+	c.Path = path
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Before 节点是否位于游标之前，即已在之前的分页中遍历过；游标所在路径上的节点需继续向下定位
+//
+// level 节点所在树层级
+//
+// degreeIndex 节点所在集合中的索引下标
+func (c *Cursor) Before(level uint8, degreeIndex uint16) bool {
+	target := c.Path[level-2]
+	if c.Asc {
+		return degreeIndex < target
+	}
+	return degreeIndex > target
+}
+
+// OnPath 节点是否位于游标所在路径上
+//
+// level 节点所在树层级
+//
+// degreeIndex 节点所在集合中的索引下标
+func (c *Cursor) OnPath(level uint8, degreeIndex uint16) bool {
+	return degreeIndex == c.Path[level-2]
+}
+
+// Resume 游标所在叶子节点中续查的起始下标，顺序检索时向后遍历，倒序检索时向前遍历
+//
+// 优先按原下标定位最后一条数据，否则在叶子节点中查找；该数据已被删除时，其后的数据前移至原下标
+//
+// count 叶子节点中的link数量
+//
+// link 获取叶子节点中指定下标link的md516Key及行标识
+func (c *Cursor) Resume(count int, link func(position int) (md516Key string, row uint64)) int {
+	position := -1
+	if c.Position < count && c.match(link(c.Position)) {
+		position = c.Position
+	} else {
+		for i := 0; i < count; i++ {
+			if c.match(link(i)) {
+				position = i
+				break
+			}
+		}
+	}
+	if position < 0 { // 最后一条数据已被删除
+		if position = c.Position; position > count {
+			position = count
+		}
+		if c.Asc {
+			return position
+		}
+		return position - 1
+	}
+	if c.Asc {
+		return position + 1
+	}
+	return position - 1
+}
+
+// match link是否为最后一条数据，唯一索引比较md516Key，非唯一索引同时比较行标识
+func (c *Cursor) match(md516Key string, row uint64) bool {
+	return md516Key == c.MD516Key && (c.Unique || row == c.Row)
+}
@@ -0,0 +1,80 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package plan
+
+import (
+	"fmt"
+	api "github.com/aberic/lilydb/connector/grpc"
+	"strings"
+)
+
+// Explain 检索计划，说明所选索引、遍历方向、下推至索引树及逐行过滤的条件与估算命中行数
+//
+// candidates 排序后的候选索引，首个为所选索引
+//
+// asc 实际遍历方向，分页续查时沿用上一页的方向
+//
+// inMemorySort 是否需要在内存中排序
+//
+// pushed 下推至索引树的条件
+//
+// filtered 逐行过滤的条件
+func Explain(candidates []*Candidate, asc, inMemorySort bool, pushed, filtered []string) *api.Plan {
+	chosen := candidates[0]
+	p := &api.Plan{
+		IndexID:       chosen.Index.ID(),
+		KeyStructure:  chosen.Index.KeyStructure(),
+		Asc:           asc,
+		EstimatedRows: chosen.Rows,
+		TotalRows:     chosen.Index.Rows(),
+		InMemorySort:  inMemorySort,
+		Pushed:        pushed,
+		Filtered:      filtered,
+	}
+	for _, c := range candidates {
+		p.Candidates = append(p.Candidates, &api.PlanCandidate{IndexID: c.Index.ID(), KeyStructure: c.Index.KeyStructure(), EstimatedRows: c.Rows})
+	}
+	return p
+}
+
+// FormatCondition 条件的可读形式，如'Age ge 3'
+func FormatCondition(param, cond string, value interface{}) string {
+	return fmt.Sprintf("%s %s %v", param, cond, value)
+}
+
+// FormatGroup 条件组的可读形式，如'(status eq open or status eq pending)'
+//
+// logic 组内逻辑，为空表示逻辑与
+//
+// items 组内各条件及子组的可读形式
+func FormatGroup(logic string, items []string) string {
+	switch logic {
+	case "":
+		logic = "and"
+	case "not":
+		return "not (" + strings.Join(items, " and ") + ")"
+	}
+	return "(" + strings.Join(items, " "+logic+" ") + ")"
+}
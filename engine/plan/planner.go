@@ -0,0 +1,174 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package plan
+
+import (
+	"github.com/aberic/lilydb/engine/comm"
+	"sort"
+)
+
+// Budget 估算命中行数时最多遍历的节点数量，超出后不再向下遍历，以节点统计的link数量代替
+const Budget = 1024
+
+// Index 参与检索计划的索引，由各表引擎的索引实现
+type Index interface {
+	ID() string              // ID 索引唯一ID
+	Primary() bool           // Primary 是否主键
+	KeyStructure() string    // KeyStructure 按照规范结构组成的索引字段名称
+	KeyStructures() []string // KeyStructures 索引各字段名称，单字段索引仅包含一个字段
+	Rows() int64             // Rows 索引中的数据行数
+}
+
+// Tree 估算命中行数时逐层遍历的索引树节点，由各表引擎的索引树实现
+type Tree interface {
+	Count() int64     // Count 节点下的link数量
+	Children() []Tree // Children 子节点集合，叶子节点返回nil
+}
+
+// Candidate 检索计划的候选索引
+type Candidate struct {
+	Index  Index
+	Asc    bool  // 是否顺序遍历索引
+	Pushed bool  // 是否将首字段上的条件下推至索引树，否则遍历整个索引
+	Rows   int64 // 估算命中行数
+	Sorted bool  // 索引首字段是否为排序字段
+	Cover  int   // 条件所覆盖的索引字段前缀长度
+}
+
+// better 是否优于other
+//
+// 优先估算命中行数更少的索引，其次依次优先首字段为排序字段、条件覆盖字段前缀更长、主键及索引字段名称在前的索引，确保相同条件总是选择相同索引
+func (c *Candidate) better(other *Candidate) bool {
+	switch {
+	case c.Rows != other.Rows:
+		return c.Rows < other.Rows
+	case c.Sorted != other.Sorted:
+		return c.Sorted
+	case c.Cover != other.Cover:
+		return c.Cover > other.Cover
+	case c.Index.Primary() != other.Index.Primary():
+		return c.Index.Primary()
+	}
+	return c.Index.KeyStructure() < other.Index.KeyStructure()
+}
+
+// Rank 为各可用索引估算检索代价并排序，首个候选即为所选索引
+//
+// 首字段存在必须满足的比较类条件的索引仅包含满足条件的数据行，可下推条件；其余索引中仅主键及排序字段索引可用于遍历，
+// 均不可用时采用第一个索引；没有可用索引时，如检索期间表被删除，返回 comm.ErrIndexNotFound
+//
+// indexes 可用索引集合
+//
+// ands 检索结果必须满足且可借助索引检索的条件字段集合
+//
+// sortParam 首个排序字段，为空表示不排序
+//
+// sortAsc 首个排序字段是否顺序
+//
+// estimate 估算索引中满足首字段上下推条件的行数
+func Rank(indexes []Index, ands []string, sortParam string, sortAsc bool, estimate func(idx Index) int64) ([]*Candidate, error) {
+	if len(indexes) == 0 {
+		return nil, comm.ErrIndexNotFound
+	}
+	var candidates []*Candidate
+	for _, idx := range indexes {
+		c := &Candidate{Index: idx, Asc: true, Sorted: sortParam != "" && sortParam == idx.KeyStructures()[0], Cover: coverage(idx, ands)}
+		leading := c.Cover > 0
+		if !leading && !c.Sorted && !idx.Primary() {
+			continue
+		}
+		if c.Sorted {
+			c.Asc = sortAsc // 按排序方向遍历索引，分页游标据此续查
+		}
+		if c.Pushed = leading; leading {
+			c.Rows = estimate(idx)
+		} else {
+			c.Rows = idx.Rows()
+		}
+		candidates = append(candidates, c)
+	}
+	if len(candidates) == 0 {
+		candidates = append(candidates, &Candidate{Index: indexes[0], Asc: true, Rows: indexes[0].Rows()})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].better(candidates[j]) })
+	return candidates, nil
+}
+
+// coverage 条件所覆盖的索引字段前缀长度，大于0表示索引首字段存在必须满足的比较类条件
+func coverage(idx Index, ands []string) int {
+	var cover int
+	for _, keyStructure := range idx.KeyStructures() {
+		covered := false
+		for _, param := range ands {
+			if param == keyStructure {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			break
+		}
+		cover++
+	}
+	return cover
+}
+
+// Estimate 估算索引树中满足下推条件的行数，按检索时相同的方式逐层匹配节点，累加满足条件的叶子节点中的link数量
+//
+// 遍历节点数量超出 Budget 时以节点统计的link数量代替
+//
+// match 节点是否满足下推条件，depth为节点相对根节点的层级，leaf为true时匹配叶子节点中的link
+func Estimate(root Tree, match func(nd Tree, depth int, leaf bool) bool) int64 {
+	var (
+		rows   int64
+		budget = Budget
+	)
+	for _, nd := range root.Children() {
+		if match(nd, 1, false) {
+			rows += estimate(nd, 1, match, &budget)
+		}
+	}
+	return rows
+}
+
+// estimate 估算节点下满足条件的行数
+func estimate(nd Tree, depth int, match func(nd Tree, depth int, leaf bool) bool, budget *int) int64 {
+	if *budget--; *budget < 0 {
+		return nd.Count()
+	}
+	if children := nd.Children(); nil != children {
+		var rows int64
+		for _, child := range children {
+			if match(child, depth+1, false) {
+				rows += estimate(child, depth+1, match, budget)
+			}
+		}
+		return rows
+	}
+	if match(nd, depth, true) {
+		return nd.Count()
+	}
+	return 0
+}
@@ -0,0 +1,202 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package siam
+
+import (
+	"github.com/aberic/gnomon"
+	"github.com/aberic/lilydb/engine/comm"
+	"github.com/aberic/lilydb/engine/siam/index"
+	"github.com/aberic/lilydb/engine/siam/storage"
+	"github.com/aberic/lilydb/engine/siam/utils"
+	"strings"
+)
+
+// backfillBatch 单次持有表锁回填的数据行数，批次之间释放表锁以便写入继续进行
+const backfillBatch = 1000
+
+// CreateIndex 在线新建索引，并在后台以自增主键索引中已存在的数据回填
+//
+// 新索引立即接收新的写入，回填完成前不参与检索
+//
+// keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'，组合索引的各字段名称间通过','分隔，如'tenant,createdAt'
+//
+// primary 是否主键
+//
+// unique 是否唯一索引
+//
+// built 回填结束回调，err为空表示回填完成，已存在的数据不包含索引字段或违反唯一约束时回填失败，索引将被删除
+func (f *Form) CreateIndex(keyStructure string, primary, unique bool, built func(err error)) error {
+	defer f.mu.Unlock()
+	f.mu.Lock()
+	for _, idx := range f.indexes {
+		if idx.KeyStructure() == keyStructure {
+			return comm.ErrIndexExist
+		}
+	}
+	indexID := f.name2ID4Index(strings.Join([]string{f.name, keyStructure}, "_"))
+	idx, err := f.building(indexID, keyStructure, primary, unique)
+	if nil != err {
+		return err
+	}
+	go f.backfill(idx, built)
+	return nil
+}
+
+// Resume 重新回填上次未完成回填的索引，启动恢复表后调用
+//
+// built 每个索引回填结束时的回调，err为空表示回填完成
+func (f *Form) Resume(built func(err error)) error {
+	var indexes []*index.Index
+	f.mu.Lock()
+	for indexID, idx := range f.indexes {
+		if !idx.Building() {
+			continue
+		}
+		idx, err := f.building(indexID, idx.KeyStructure(), idx.Primary(), idx.Unique())
+		if nil != err {
+			f.mu.Unlock()
+			return err
+		}
+		indexes = append(indexes, idx)
+	}
+	f.mu.Unlock()
+	for _, idx := range indexes {
+		go f.backfill(idx, built)
+	}
+	return nil
+}
+
+// building 清理索引遗留文件后新建待回填的空索引并加入表，调用方需持有表锁
+func (f *Form) building(indexID, keyStructure string, primary, unique bool) (*index.Index, error) {
+	if err := storage.Obtain().DropIndex(f.databaseID, f.id, indexID); nil != err {
+		return nil, err
+	}
+	idx := index.NewIndex(f.databaseID, f.id, indexID, keyStructure, primary, unique)
+	idx.Backfill(0)
+	f.swapMu.Lock()
+	f.indexes[indexID] = idx
+	f.swapMu.Unlock()
+	return idx, nil
+}
+
+// backfill 以创建索引时自增主键索引中已存在的数据分批回填索引，此后写入的数据由写入过程建立索引
+func (f *Form) backfill(idx *index.Index, built func(err error)) {
+	var links []*index.Link
+	f.mu.RLock()
+	for _, auto := range f.indexes {
+		if auto.KeyStructure() == indexAutoID {
+			auto.Range(func(link *index.Link) bool {
+				if link.SeekStartIndex() >= 0 {
+					links = append(links, link)
+				}
+				return true
+			})
+		}
+	}
+	f.mu.RUnlock()
+	idx.Backfill(uint64(len(links)))
+	for start := 0; start < len(links); start += backfillBatch {
+		end := start + backfillBatch
+		if end > len(links) {
+			end = len(links)
+		}
+		if err := f.backfillRows(idx, links[start:end]); nil != err {
+			f.abandon(idx)
+			if nil != built {
+				built(err)
+			}
+			return
+		}
+	}
+	idx.Complete()
+	if nil != built {
+		built(nil)
+	}
+}
+
+// abandon 回填失败时将索引移出表并删除索引文件，残留文件将在再次新建该索引时清理
+func (f *Form) abandon(idx *index.Index) {
+	defer f.mu.Unlock()
+	f.mu.Lock()
+	if f.indexes[idx.ID()] != idx { // 回填期间索引已被删除
+		return
+	}
+	f.swapMu.Lock()
+	delete(f.indexes, idx.ID())
+	f.swapMu.Unlock()
+	_ = storage.Obtain().DropIndex(f.databaseID, f.id, idx.ID())
+}
+
+// backfillRows 回填一批数据行，已删除或回填期间已由写入过程建立索引的数据行将被跳过
+//
+// 与写入时一致，数据行不包含索引字段或违反唯一约束时返回错误
+func (f *Form) backfillRows(idx *index.Index, links []*index.Link) error {
+	defer f.mu.Unlock()
+	f.mu.Lock()
+	if f.indexes[idx.ID()] != idx { // 回填期间索引已被删除
+		return comm.ErrIndexNotFound
+	}
+	for _, link := range links {
+		idx.Advance(1)
+		if autoID, exist := f.rows[link.SeekStart()]; !exist || autoID != link.HashKey() { // 数据行已被删除
+			continue
+		}
+		value, err := storage.Obtain().Take(utils.PathFormFile(f.databaseID, f.id), link.SeekStart(), link.SeekLast())
+		if nil != err {
+			return err
+		}
+		key, hashKey, err := f.getCustomIndex(idx, value)
+		if nil != err {
+			return err
+		}
+		md516Key := gnomon.HashMD516(key)
+		indexed := false
+		for _, exist := range idx.Links(md516Key, hashKey) {
+			if exist.SeekStart() == link.SeekStart() {
+				indexed = true
+			} else if _, alive := f.rows[exist.SeekStart()]; alive && idx.Unique() {
+				return comm.ErrIndexDuplicate
+			}
+		}
+		if indexed {
+			continue
+		}
+		newLink, _, _ := idx.Put(md516Key, hashKey, 0)
+		write := &storage.Write{
+			IndexID:           idx.ID(),
+			FormIndexFilePath: utils.PathFormIndexFile(f.databaseID, f.id, idx.ID()),
+			MD516Key:          md516Key,
+			HashKey:           hashKey,
+			SeekStartIndex:    newLink.SeekStartIndex(),
+			Handler: func(SeekStartIndex int64, SeekStart int64, SeekLast int) {
+				newLink.Fit(SeekStartIndex, SeekStart, SeekLast, 0)
+			},
+		}
+		if err = storage.Obtain().StoreIndex(f.databaseID, f.id, link.SeekStart(), link.SeekLast(), []*storage.Write{write}); nil != err {
+			return err
+		}
+	}
+	return nil
+}
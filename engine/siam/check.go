@@ -0,0 +1,249 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package siam
+
+import (
+	"github.com/aberic/gnomon"
+	"github.com/aberic/lilydb/engine/siam/index"
+	"github.com/aberic/lilydb/engine/siam/storage"
+	"github.com/aberic/lilydb/engine/siam/utils"
+	"os"
+	"sort"
+)
+
+// Check 表数据文件完整性检查结果
+type Check struct {
+	DatabaseID string                 // 所属数据库ID
+	FormID     string                 // 表唯一ID
+	FormName   string                 // 表名
+	Size       int64                  // 表数据文件长度
+	Rows       int                    // 主键索引中存活的数据行数
+	Corrupts   []int64                // 主键索引引用但越界、校验或解析失败的数据起始位置
+	Orphans    []int64                // 数据文件中未被主键索引引用的数据起始位置
+	Unscanned  int64                  // 数据文件中无法按记录头遍历部分的起始位置，等于Size时表示全部遍历
+	Indexes    map[string]*IndexCheck // 索引ID=索引文件检查结果
+}
+
+// IndexCheck 索引文件完整性检查结果
+type IndexCheck struct {
+	KeyStructure string  // 索引字段结构
+	Records      int     // 存活索引记录数
+	Tail         int     // 文件末尾不足单条索引长度的字节数
+	Dangling     []int64 // 所指数据未被主键索引引用的索引记录位置
+	Missing      int     // 主键索引中存活但未被该索引引用的数据行数
+	Rebuilt      bool    // 是否已由主键索引重建
+}
+
+// Healthy 表数据及索引文件是否均完整一致，孤立数据可由压缩回收，不影响一致性
+func (c *Check) Healthy() bool {
+	if len(c.Corrupts) > 0 {
+		return false
+	}
+	for _, ic := range c.Indexes {
+		if ic.Tail > 0 || len(ic.Dangling) > 0 || ic.Missing > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Check 离线检查表数据文件及各索引文件，仅可在服务未启动时调用
+//
+// 校验索引记录长度、主键索引所引用数据段能否解析，并找出未被引用的孤立数据及指向失效数据的索引记录
+//
+// repair 是否由主键索引重建各索引文件，主键索引文件仅移除墓碑及重复记录
+func (f *Form) Check(repair bool) (*Check, error) {
+	check, values, err := f.check()
+	if nil != err || !repair {
+		return check, err
+	}
+	if err = f.rebuild(check, values); nil != err {
+		return check, err
+	}
+	rebuilt := check
+	if check, _, err = f.check(); nil != err {
+		return check, err
+	}
+	for indexID, ic := range rebuilt.Indexes {
+		if now, exist := check.Indexes[indexID]; exist && ic.Rebuilt {
+			now.Rebuilt = true
+		}
+	}
+	return check, nil
+}
+
+// check 检查表文件，返回检查结果及主键索引中存活数据解析后的内容，key为数据起始位置
+func (f *Form) check() (*Check, map[int64]interface{}, error) {
+	var (
+		formFilePath = utils.PathFormFile(f.databaseID, f.id)
+		check        = &Check{DatabaseID: f.databaseID, FormID: f.id, FormName: f.name, Indexes: map[string]*IndexCheck{}}
+		lives        = map[string][]*storage.IndexRecord{} // 索引ID=存活索引记录
+		values       = map[int64]interface{}{}
+		rows         = map[int64]bool{} // 主键索引引用的数据起始位置
+		primary      string
+	)
+	if info, err := os.Stat(formFilePath); nil == err {
+		check.Size = info.Size()
+	} else if !os.IsNotExist(err) {
+		return nil, nil, err
+	}
+	for _, idx := range f.indexes {
+		ic := &IndexCheck{KeyStructure: idx.KeyStructure()}
+		check.Indexes[idx.ID()] = ic
+		if idx.KeyStructure() == indexAutoID {
+			primary = idx.ID()
+		}
+		records, tail, err := storage.ReadIndex(utils.PathFormIndexFile(f.databaseID, f.id, idx.ID()))
+		if nil != err {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, nil, err
+		}
+		ic.Tail = tail
+		lives[idx.ID()] = liveRecords(records, idx.Unique())
+		ic.Records = len(lives[idx.ID()])
+	}
+	for _, record := range lives[primary] {
+		rows[record.SeekStart] = true
+		if record.SeekStart+int64(record.SeekLast) > check.Size {
+			check.Corrupts = append(check.Corrupts, record.SeekStart)
+			continue
+		}
+		value, err := storage.Obtain().Take(formFilePath, record.SeekStart, record.SeekLast)
+		if nil != err {
+			check.Corrupts = append(check.Corrupts, record.SeekStart)
+			continue
+		}
+		values[record.SeekStart] = value
+	}
+	check.Rows = len(lives[primary])
+	for indexID, records := range lives {
+		if indexID == primary {
+			continue
+		}
+		referenced := map[int64]bool{}
+		for _, record := range records {
+			if rows[record.SeekStart] {
+				referenced[record.SeekStart] = true
+				continue
+			}
+			check.Indexes[indexID].Dangling = append(check.Indexes[indexID].Dangling, record.Position)
+		}
+		check.Indexes[indexID].Missing = len(rows) - len(referenced)
+	}
+	for indexID, ic := range check.Indexes {
+		if _, exist := lives[indexID]; !exist && indexID != primary {
+			ic.Missing = len(rows)
+		}
+	}
+	if gnomon.FilePathExists(formFilePath) {
+		segments, unscanned, err := storage.ScanRecords(formFilePath)
+		if nil != err {
+			return nil, nil, err
+		}
+		check.Unscanned = unscanned
+		for _, segment := range segments {
+			if !rows[segment.SeekStart] {
+				check.Orphans = append(check.Orphans, segment.SeekStart)
+			}
+		}
+	}
+	sort.Slice(check.Corrupts, func(i, j int) bool { return check.Corrupts[i] < check.Corrupts[j] })
+	return check, values, nil
+}
+
+// rebuild 由主键索引重建各索引文件，无法解析的数据行不写入非主键索引
+//
+// values 主键索引中存活数据解析后的内容，key为数据起始位置
+func (f *Form) rebuild(check *Check, values map[int64]interface{}) error {
+	var primary *index.Index
+	for _, idx := range f.indexes {
+		if idx.KeyStructure() == indexAutoID {
+			primary = idx
+		}
+	}
+	if nil == primary {
+		return nil
+	}
+	records, _, err := storage.ReadIndex(utils.PathFormIndexFile(f.databaseID, f.id, primary.ID()))
+	if nil != err && !os.IsNotExist(err) {
+		return err
+	}
+	lives := liveRecords(records, true)
+	if err = storage.RewriteIndex(utils.PathFormIndexFile(f.databaseID, f.id, primary.ID()), lives); nil != err {
+		return err
+	}
+	check.Indexes[primary.ID()].Rebuilt = true
+	sort.Slice(lives, func(i, j int) bool { return lives[i].HashKey < lives[j].HashKey }) // 相同key时保留自增ID较大的数据行
+	for _, idx := range f.indexes {
+		if idx == primary {
+			continue
+		}
+		var (
+			rebuilds []*storage.IndexRecord
+			keys     = map[string]int{} // md516Key=rebuilds下标
+		)
+		for _, live := range lives {
+			value, exist := values[live.SeekStart]
+			if !exist {
+				continue
+			}
+			key, hashKey, err := f.getCustomIndex(idx, value)
+			if nil != err {
+				continue
+			}
+			record := &storage.IndexRecord{MD516Key: gnomon.HashMD516(key), HashKey: hashKey, SeekStart: live.SeekStart, SeekLast: live.SeekLast}
+			if position, exist := keys[record.MD516Key]; exist && idx.Unique() {
+				rebuilds[position] = record
+				continue
+			}
+			keys[record.MD516Key] = len(rebuilds)
+			rebuilds = append(rebuilds, record)
+		}
+		if err = storage.RewriteIndex(utils.PathFormIndexFile(f.databaseID, f.id, idx.ID()), rebuilds); nil != err {
+			return err
+		}
+		check.Indexes[idx.ID()].Rebuilt = true
+	}
+	return nil
+}
+
+// liveRecords 移除墓碑记录，唯一索引还需按md516Key去重，保留索引文件中位置靠后的记录
+func liveRecords(records []*storage.IndexRecord, unique bool) []*storage.IndexRecord {
+	var (
+		lives []*storage.IndexRecord
+		keys  = map[string]*storage.IndexRecord{}
+	)
+	for _, record := range records {
+		keys[record.MD516Key] = record
+	}
+	for _, record := range records {
+		if (!unique || keys[record.MD516Key] == record) && !record.Tombstone() {
+			lives = append(lives, record)
+		}
+	}
+	return lives
+}
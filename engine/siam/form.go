@@ -32,7 +32,6 @@ import (
 	"github.com/aberic/lilydb/engine/siam/index"
 	"github.com/aberic/lilydb/engine/siam/storage"
 	"github.com/aberic/lilydb/engine/siam/utils"
-	"reflect"
 	"strconv"
 	"strings"
 	"sync"
@@ -58,10 +57,37 @@ func NewForm(databaseID, formID, formName, comment string) *Form {
 		id:         formID,
 		comment:    comment,
 		indexes:    map[string]*index.Index{},
+		rows:       map[int64]uint64{},
 		formType:   api.FormType_Siam,
 		databaseID: databaseID,
 	}
-	fm.NewIndex(indexAutoID, true) // 创建默认自增主键
+	fm.NewIndex(indexAutoID, true, true) // 创建默认自增主键
+	return fm
+}
+
+// RecoverForm 根据引导文件中记录的表结构恢复表，索引ID沿用记录中的值，不再新建默认主键
+//
+// 所属数据库ID
+//
+// apiForm 引导文件中记录的表结构
+func RecoverForm(databaseID string, apiForm *api.Form) *Form {
+	var autoID uint64 = 0
+	fm := &Form{
+		autoID:     &autoID,
+		name:       apiForm.Name,
+		id:         apiForm.ID,
+		comment:    apiForm.Comment,
+		indexes:    map[string]*index.Index{},
+		rows:       map[int64]uint64{},
+		formType:   api.FormType_Siam,
+		databaseID: databaseID,
+	}
+	for _, idx := range apiForm.Indexes {
+		fm.indexes[idx.ID] = index.NewIndex(databaseID, apiForm.ID, idx.ID, idx.KeyStructure, idx.Primary, idx.Unique)
+		if idx.Building { // 回填未完成，需由Resume重新回填
+			fm.indexes[idx.ID].Backfill(idx.Total)
+		}
+	}
 	return fm
 }
 
@@ -72,15 +98,13 @@ type Form struct {
 	autoID     *uint64                 // 自增id
 	comment    string                  // 描述
 	formType   api.FormType            // 表类型 siam
-	indexes    map[string]*index.Index // 索引ID集合
+	indexes    map[string]*index.Index // 索引ID集合，变更时需同时持有mu及swapMu
+	rows       map[int64]uint64        // 数据行集合，value在文件中的起始位置=自增ID，用于删除时定位自增主键
 	databaseID string                  // 所属数据库ID
+	sync       func() error            // 表结构变化后同步引导文件
 
-	mu sync.RWMutex
-}
-
-// AtomicAddAutoID 自增ID
-func (f *Form) atomicAddAutoID() uint64 {
-	return atomic.AddUint64(f.autoID, 1)
+	mu     sync.RWMutex
+	swapMu sync.RWMutex // 压缩替换文件或变更索引集合时阻塞检索
 }
 
 // AutoID 返回表当前自增ID值
@@ -103,6 +127,27 @@ func (f *Form) Comment() string {
 	return f.comment
 }
 
+// Rename 修改表名，表唯一ID不变
+func (f *Form) Rename(name string) {
+	defer f.mu.Unlock()
+	f.mu.Lock()
+	f.name = name
+}
+
+// SetComment 修改表描述
+func (f *Form) SetComment(comment string) {
+	defer f.mu.Unlock()
+	f.mu.Lock()
+	f.comment = comment
+}
+
+// SetSync 设置表结构变化后同步引导文件的方法
+func (f *Form) SetSync(sync func() error) {
+	defer f.mu.Unlock()
+	f.mu.Lock()
+	f.sync = sync
+}
+
 // FormType 获取表类型
 func (f *Form) FormType() api.FormType {
 	return f.formType
@@ -110,23 +155,113 @@ func (f *Form) FormType() api.FormType {
 
 // Indexes 获取索引api集合
 func (f *Form) Indexes() map[string]*api.Index {
+	defer f.swapMu.RUnlock()
+	f.swapMu.RLock()
 	var idx = make(map[string]*api.Index)
 	for _, i := range f.indexes {
-		idx[i.ID()] = &api.Index{ID: i.ID(), Primary: i.Primary(), KeyStructure: i.KeyStructure()}
+		done, total := i.Progress()
+		idx[i.ID()] = &api.Index{ID: i.ID(), Primary: i.Primary(), KeyStructure: i.KeyStructure(), Unique: i.Unique(), Building: i.Building(), Done: done, Total: total}
 	}
 	return idx
 }
 
 // NewIndex 新建索引
 //
-// keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'
+// keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'，组合索引的各字段名称间通过','分隔，如'tenant,createdAt'
 //
 // primary 是否主键
-func (f *Form) NewIndex(keyStructure string, primary bool) {
-	defer f.mu.Unlock()
+//
+// unique 是否唯一索引，非唯一索引的同一key可对应多行数据
+//
+// 已设置引导文件同步方法时，新建后同步引导文件
+func (f *Form) NewIndex(keyStructure string, primary, unique bool) error {
 	f.mu.Lock()
 	indexID := f.name2ID4Index(strings.Join([]string{f.name, keyStructure}, "_"))
-	f.indexes[indexID] = index.NewIndex(f.databaseID, f.id, indexID, keyStructure, primary)
+	f.swapMu.Lock()
+	f.indexes[indexID] = index.NewIndex(f.databaseID, f.id, indexID, keyStructure, primary, unique)
+	f.swapMu.Unlock()
+	sync := f.sync
+	f.mu.Unlock()
+	if nil == sync {
+		return nil
+	}
+	return sync()
+}
+
+// DropIndex 删除索引，默认自增主键不可删除
+//
+// keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'
+func (f *Form) DropIndex(keyStructure string) error {
+	if keyStructure == indexAutoID {
+		return comm.ErrIndexProtected
+	}
+	defer f.mu.Unlock()
+	f.mu.Lock()
+	for indexID, idx := range f.indexes {
+		if idx.KeyStructure() != keyStructure {
+			continue
+		}
+		f.swapMu.Lock()
+		delete(f.indexes, indexID)
+		f.swapMu.Unlock()
+		return storage.Obtain().DropIndex(f.databaseID, f.id, indexID)
+	}
+	return comm.ErrIndexNotFound
+}
+
+// Drop 删除表数据及索引文件
+func (f *Form) Drop() error {
+	defer f.mu.Unlock()
+	f.mu.Lock()
+	defer f.swapMu.Unlock()
+	f.swapMu.Lock()
+	f.indexes = map[string]*index.Index{}
+	f.rows = map[int64]uint64{}
+	return storage.Obtain().DropForm(f.databaseID, f.id)
+}
+
+// Recover 并行恢复表下所有索引，并根据自增主键索引中已恢复的最大ID重置表自增ID
+//
+// 返回 各索引恢复过程中出现的错误，key为索引ID，索引文件不存在视为空索引
+func (f *Form) Recover() map[string]error {
+	var (
+		wg   sync.WaitGroup
+		errs = map[string]error{}
+		eMu  sync.Mutex
+	)
+	defer f.mu.Unlock()
+	f.mu.Lock()
+	for _, idx := range f.indexes {
+		wg.Add(1)
+		go func(idx *index.Index) {
+			defer wg.Done()
+			if idx.Building() { // 回填未完成的索引文件不完整，将由Resume重新回填
+				return
+			}
+			autoID, err := idx.Recover()
+			if nil != err {
+				if err == index.ErrIndexFileNotFound {
+					return
+				}
+				eMu.Lock()
+				errs[idx.ID()] = err
+				eMu.Unlock()
+			}
+			if idx.KeyStructure() == indexAutoID && nil != autoID {
+				atomic.StoreUint64(f.autoID, *autoID)
+			}
+		}(idx)
+	}
+	wg.Wait()
+	for _, idx := range f.indexes {
+		if idx.KeyStructure() == indexAutoID {
+			idx.Range(func(link *index.Link) bool {
+				f.rows[link.SeekStart()] = link.HashKey()
+				return true
+			})
+		}
+	}
+	return errs
 }
 
 // name2ID4Index 确保表下索引唯一ID不重复
@@ -194,16 +329,67 @@ func (f *Form) Update(value interface{}) (uint64, error) {
 //
 // return err 检索错误信息，如果有
 func (f *Form) Select(selectorBytes []byte) (int32, []interface{}, error) {
+	count, values, _, err := f.Page(selectorBytes)
+	return count, values, err
+}
+
+// Page 根据条件分页检索
+//
+// databaseID 数据库唯一ID
+//
+// selectorBytes 选择器字节数组，自定义转换策略，可包含上一页返回的分页游标Cursor
+//
+// return count 检索结果总条数
+//
+// return values 检索结果集合
+//
+// return cursor 下一页的分页游标，已无更多数据时为空
+//
+// return err 检索错误信息，如果有
+func (f *Form) Page(selectorBytes []byte) (int32, []interface{}, string, error) {
+	defer f.swapMu.RUnlock()
+	f.swapMu.RLock()
 	var indexes []*index.Index
 	for _, idx := range f.indexes {
-		indexes = append(indexes, idx)
+		if !idx.Building() { // 回填完成前不参与检索
+			indexes = append(indexes, idx)
+		}
 	}
 	selector, err := index.NewSelector(selectorBytes, indexes, f.databaseID, f.id, false)
 	if nil != err {
-		return 0, nil, err
+		return 0, nil, "", err
 	}
 	count, values := selector.Run()
-	return count, values, nil
+	if err = selector.Err(); nil != err {
+		return 0, nil, "", err
+	}
+	return count, values, selector.NextCursor(), nil
+}
+
+// Stream 根据条件流式检索，检索结果在遍历索引的过程中逐条交由handler处理
+//
+// selectorBytes 选择器字节数组，自定义转换策略，Limit为0时不限制数量
+//
+// handler 检索结果处理方法，返回false时停止检索
+//
+// return count 停止前已检索的结果条数
+//
+// return err 检索错误信息，如果有
+func (f *Form) Stream(selectorBytes []byte, handler func(value interface{}) bool) (int32, error) {
+	defer f.swapMu.RUnlock()
+	f.swapMu.RLock()
+	var indexes []*index.Index
+	for _, idx := range f.indexes {
+		if !idx.Building() { // 回填完成前不参与检索
+			indexes = append(indexes, idx)
+		}
+	}
+	selector, err := index.NewSelector(selectorBytes, indexes, f.databaseID, f.id, false)
+	if nil != err {
+		return 0, err
+	}
+	count := selector.Range(handler)
+	return count, selector.Err()
 }
 
 // Delete 根据条件删除
@@ -216,56 +402,254 @@ func (f *Form) Select(selectorBytes []byte) (int32, []interface{}, error) {
 //
 // return err 删除错误信息，如果有
 func (f *Form) Delete(selectorBytes []byte) (int32, error) {
+	defer f.mu.Unlock()
+	f.mu.Lock()
 	var indexes []*index.Index
 	for _, idx := range f.indexes {
-		indexes = append(indexes, idx)
+		if !idx.Building() { // 回填完成前不参与检索
+			indexes = append(indexes, idx)
+		}
 	}
 	selector, err := index.NewSelector(selectorBytes, indexes, f.databaseID, f.id, true)
 	if nil != err {
 		return 0, err
 	}
-	count, _ := selector.Run()
+	if selector.Run(); nil != selector.Err() {
+		return 0, selector.Err()
+	}
+	var count int32
+	for _, row := range selector.Rows() {
+		if err = f.remove(row.Link.SeekStart(), row.Link.SeekLast(), row.Value); nil != err {
+			return count, err
+		}
+		count++
+	}
 	return count, nil
 }
 
-// rangeIndexes 遍历表索引ID集合，检索并计算所有索引返回对象集合
+// Compact 压缩表数据文件，仅保留自增主键索引引用的数据，并重写所有索引文件
+//
+// 压缩期间写入将被阻塞，检索仅在替换文件的瞬间被阻塞
+func (f *Form) Compact() error {
+	defer f.mu.Unlock()
+	f.mu.Lock()
+	var (
+		segments   []*storage.Segment
+		seekStarts = map[int64]*storage.Segment{}
+		indexIDs   []string
+		rows       = map[int64]uint64{}
+	)
+	for _, idx := range f.indexes {
+		if idx.KeyStructure() != indexAutoID {
+			continue
+		}
+		idx.Range(func(link *index.Link) bool {
+			if link.SeekStartIndex() < 0 { // 尚未写入成功的link
+				return true
+			}
+			segment := &storage.Segment{SeekStart: link.SeekStart(), SeekLast: link.SeekLast()}
+			segments = append(segments, segment)
+			seekStarts[link.SeekStart()] = segment
+			return true
+		})
+	}
+	for _, idx := range f.indexes {
+		var (
+			autoIndex = idx.KeyStructure() == indexAutoID
+			dangling  []*index.Link
+		)
+		indexIDs = append(indexIDs, idx.ID())
+		idx.Range(func(link *index.Link) bool {
+			segment, exist := seekStarts[link.SeekStart()]
+			if !exist || link.SeekStartIndex() < 0 { // 未被自增主键引用的link不再保留
+				dangling = append(dangling, link)
+				return true
+			}
+			segment.Writes = append(segment.Writes, &storage.Write{
+				IndexID:           idx.ID(),
+				FormIndexFilePath: utils.PathFormIndexFile(f.databaseID, f.id, idx.ID()),
+				MD516Key:          link.MD516Key(),
+				HashKey:           link.HashKey(),
+				Version:           link.Version(),
+				Handler: func(SeekStartIndex int64, SeekStart int64, SeekLast int) {
+					link.Fit(SeekStartIndex, SeekStart, SeekLast, link.Version())
+					if autoIndex {
+						rows[SeekStart] = link.HashKey()
+					}
+				},
+			})
+			return true
+		})
+		for _, link := range dangling {
+			_ = idx.Remove(link)
+		}
+	}
+	if err := storage.Obtain().Compact(f.databaseID, f.id, indexIDs, segments, &f.swapMu); nil != err {
+		return err
+	}
+	f.rows = rows
+	return nil
+}
+
+// Verify 校验自增主键索引引用的所有数据，返回损坏数据在文件中的起始位置
+func (f *Form) Verify() ([]int64, error) {
+	var segments []*storage.Segment
+	f.mu.RLock()
+	for _, idx := range f.indexes {
+		if idx.KeyStructure() != indexAutoID {
+			continue
+		}
+		idx.Range(func(link *index.Link) bool {
+			if link.SeekStartIndex() >= 0 {
+				segments = append(segments, &storage.Segment{SeekStart: link.SeekStart(), SeekLast: link.SeekLast()})
+			}
+			return true
+		})
+	}
+	f.mu.RUnlock()
+	defer f.swapMu.RUnlock()
+	f.swapMu.RLock()
+	return storage.Obtain().Verify(utils.PathFormFile(f.databaseID, f.id), segments)
+}
+
+// remove 将指定数据行从所有索引中移除，并在索引文件中写入墓碑记录
+//
+// seekStart value在文件中的起始位置，同一行数据在各索引中一致
+//
+// seekLast value在文件中的持续长度
+//
+// value 数据对象，用于计算自定义索引key
+func (f *Form) remove(seekStart int64, seekLast int, value interface{}) error {
+	var writes []*storage.Write
+	for _, idx := range f.indexes {
+		var (
+			key     string
+			hashKey uint64
+			err     error
+		)
+		if idx.KeyStructure() == indexAutoID {
+			autoID, exist := f.rows[seekStart]
+			if !exist {
+				continue
+			}
+			hashKey = autoID
+			key = strconv.FormatUint(hashKey, 10)
+		} else if key, hashKey, err = f.getCustomIndex(idx, value); nil != err {
+			continue
+		}
+		md516Key := gnomon.HashMD516(key)
+		for _, link := range idx.Links(md516Key, hashKey) {
+			if link.SeekStart() != seekStart { // 索引已指向其它数据行
+				continue
+			}
+			if err = idx.Remove(link); nil != err {
+				continue
+			}
+			writes = append(writes, &storage.Write{
+				IndexID:           idx.ID(),
+				FormIndexFilePath: utils.PathFormIndexFile(f.databaseID, f.id, idx.ID()),
+				MD516Key:          md516Key,
+				HashKey:           hashKey,
+				SeekStartIndex:    link.SeekStartIndex(),
+			})
+		}
+	}
+	delete(f.rows, seekStart)
+	return storage.Obtain().Remove(f.databaseID, f.id, seekStart, seekLast, writes)
+}
+
+// superseded 获取更新操作将覆盖的原数据行，以唯一索引中已写入且仍被自增主键引用的link为准
+//
+// value 更新数据对象
+//
+// 返回原数据在文件中的起始位置、持续长度及其自增ID
+func (f *Form) superseded(value interface{}) (seekStart int64, seekLast int, autoID uint64, exist bool) {
+	for _, idx := range f.indexes {
+		if idx.KeyStructure() == indexAutoID || !idx.Unique() {
+			continue
+		}
+		key, hashKey, err := f.getCustomIndex(idx, value)
+		if nil != err {
+			continue
+		}
+		link := idx.Get(gnomon.HashMD516(key), hashKey)
+		if nil == link || link.SeekStartIndex() < 0 {
+			continue
+		}
+		if autoID, exist = f.rows[link.SeekStart()]; exist {
+			return link.SeekStart(), link.SeekLast(), autoID, true
+		}
+	}
+	return 0, 0, 0, false
+}
+
+// store 遍历表索引ID集合，检索并计算当前索引所在文件位置，存储结果
+//
+// 更新已存在的数据行时沿用原自增ID，自增主键指向新数据，原数据不再被引用，可在压缩时回收
+//
+// 任一索引拒绝该行或存储失败时，移除本次新建的link，且不消耗自增ID
 func (f *Form) store(value interface{}, update bool) error {
 	var (
-		wg     sync.WaitGroup
-		writes []*storage.Write
-		wMu    sync.Mutex
-		err    error
+		wg           sync.WaitGroup
+		writes       []*storage.Write
+		created      = map[*index.Link]*index.Index{} // 本次新建的link，失败时移除
+		wMu          sync.Mutex
+		autoLink     *index.Link
+		oldSeekStart int64
+		oldSeekLast  int
+		autoID       uint64
+		replace      bool
+		err          error
 	)
+	if update {
+		oldSeekStart, oldSeekLast, autoID, replace = f.superseded(value)
+	}
+	if !replace {
+		autoID = atomic.LoadUint64(f.autoID) + 1 // 存储成功后自增，写入由表锁串行
+	}
+	fail := func(e error) { // 调用方需持有wMu
+		if nil == err {
+			err = e
+		}
+	}
 	// 遍历表索引ID集合，检索并计算当前索引所在文件位置
 	for _, idx := range f.indexes {
 		wg.Add(1)
-		go func(index *index.Index) {
+		go func(idx *index.Index) {
 			defer wg.Done()
 			var (
 				key     string
 				hashKey uint64
+				keyErr  error
 			)
-			//gnomon.Log().Debug("rangeIndexes", gnomon.Log().Field("index.id", index.getID()), gnomon.Log().Field("index.keyStructure", index.getKeyStructure()))
-			if index.KeyStructure() == indexAutoID {
-				hashKey = f.atomicAddAutoID() // ID自增
+			if idx.KeyStructure() == indexAutoID {
+				hashKey = autoID
 				key = strconv.FormatUint(hashKey, 10)
 			} else {
-				key, hashKey, err = f.getCustomIndex(index, value)
-				if nil != err {
-					return
-				}
+				key, hashKey, keyErr = f.getCustomIndex(idx, value)
 			}
-			md516Key := gnomon.HashMD516(key)
-			link, exist, _ := f.indexes[index.ID()].Put(md516Key, hashKey, 0)
-			if !update && exist { // 如果当前是插入操作，且已存在对应key的值
-				err = fmt.Errorf("the same key %s already exist", index.KeyStructure())
+			if nil != keyErr {
+				wMu.Lock()
+				fail(keyErr)
+				wMu.Unlock()
 				return
 			}
+			md516Key := gnomon.HashMD516(key)
+			link, exist, _ := idx.Put(md516Key, hashKey, 0)
 			defer wMu.Unlock()
 			wMu.Lock()
+			if !exist {
+				created[link] = idx
+			} else if !update { // 如果当前是插入操作，且已存在对应key的值
+				fail(fmt.Errorf("the same key %s already exist", idx.KeyStructure()))
+				return
+			}
+			if idx.KeyStructure() == indexAutoID {
+				autoLink = link
+			}
 			writes = append(writes, &storage.Write{
-				IndexID:           index.ID(),
-				FormIndexFilePath: utils.PathFormIndexFile(f.databaseID, f.id, index.ID()),
+				IndexID:           idx.ID(),
+				FormIndexFilePath: utils.PathFormIndexFile(f.databaseID, f.id, idx.ID()),
 				MD516Key:          md516Key,
 				HashKey:           hashKey,
 				SeekStartIndex:    link.SeekStartIndex(),
@@ -273,68 +657,68 @@ func (f *Form) store(value interface{}, update bool) error {
 					link.Fit(SeekStartIndex, SeekStart, SeekLast, 0)
 				},
 			})
-
 		}(idx)
 	}
 	wg.Wait()
+	if nil == err {
+		err = storage.Obtain().Store(f.databaseID, f.id, value, writes)
+	}
 	if nil != err {
+		for link, idx := range created {
+			_ = idx.Remove(link)
+		}
 		return err
 	}
-	return storage.Obtain().Store(f.databaseID, f.id, value, writes)
+	if !replace {
+		atomic.StoreUint64(f.autoID, autoID)
+	}
+	if nil != autoLink {
+		f.rows[autoLink.SeekStart()] = autoLink.HashKey()
+	}
+	if !replace {
+		return nil
+	}
+	// 原数据已不被自增主键及唯一索引引用，移除其在非唯一索引中残留的link
+	delete(f.rows, oldSeekStart)
+	oldValue, err := storage.Obtain().Take(utils.PathFormFile(f.databaseID, f.id), oldSeekStart, oldSeekLast)
+	if nil != err {
+		return nil // 残留link将在压缩时清理
+	}
+	return f.remove(oldSeekStart, oldSeekLast, oldValue)
 }
 
 // getCustomIndex 获取自定义索引预插入返回对象
+//
+// 组合索引依次获取各字段的索引信息，并通过 utils.CompositeKey 组合为保序的key，任一字段无效则该行不写入组合索引
 func (f *Form) getCustomIndex(idx *index.Index, value interface{}) (key string, hashKey uint64, err error) {
-	reflectValue := reflect.ValueOf(value) // 反射对象，通过reflectObj获取存储在里面的值，还可以去改变值
-	params := strings.Split(idx.KeyStructure(), ".")
-	switch kind := reflectValue.Kind(); kind {
-	default:
-		err = fmt.Errorf("index %s with type is invalid", idx.KeyStructure())
-		return
-	case reflect.Map:
-		var (
-			item      interface{}
-			paramsLen = len(params)
-			position  int
-			itemMap   = value.(map[string]interface{})
-		)
-		for _, param := range params {
-			position++
-			item = itemMap[param]
-			if position == paramsLen { // 表示没有后续参数
-				break
-			}
-			switch item := item.(type) {
-			default:
-				err = fmt.Errorf("index %s with map is invalid", idx.KeyStructure())
-				return
-			case map[string]interface{}:
-				itemMap = item
-				continue
-			}
-		}
-		if keyNew, hashKeyNew, valid := utils.Type2index(item); valid {
-			return keyNew, hashKeyNew, nil
-		}
-		err = fmt.Errorf("index %s with map is invalid", idx.KeyStructure())
-		return
-	case reflect.Ptr:
-		checkValue := reflectValue
-		for _, param := range params {
-			checkNewValue := checkValue.Elem().FieldByName(param)
-			if checkNewValue.IsValid() { // 子字段有效
-				checkValue = checkNewValue
-				continue
-			}
-			err = fmt.Errorf("index %s with ptr is invalid", idx.KeyStructure())
+	if !idx.Composite() {
+		return f.getCustomKey(idx.KeyStructure(), value)
+	}
+	keyStructures := idx.KeyStructures()
+	keys, hashKeys := make([]string, len(keyStructures)), make([]uint64, len(keyStructures))
+	for position, keyStructure := range keyStructures {
+		if keys[position], hashKeys[position], err = f.getCustomKey(keyStructure, value); nil != err {
 			return
 		}
-		if keyNew, hashKeyNew, valid := utils.ValueType2index(&checkValue); valid {
-			return keyNew, hashKeyNew, nil
-		}
-		err = fmt.Errorf("index %s with ptr is invalid", idx.KeyStructure())
+	}
+	key, hashKey = utils.CompositeKey(keys, hashKeys)
+	return
+}
+
+// getCustomKey 获取单个索引字段的索引信息
+//
+// keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'
+func (f *Form) getCustomKey(keyStructure string, value interface{}) (key string, hashKey uint64, err error) {
+	checkValue, ok := utils.ReflectByParams(value, strings.Split(keyStructure, "."))
+	if !ok {
+		err = fmt.Errorf("index %s with type is invalid", keyStructure)
 		return
 	}
+	if keyNew, hashKeyNew, valid := utils.ValueType2index(&checkValue); valid {
+		return keyNew, hashKeyNew, nil
+	}
+	err = fmt.Errorf("index %s with type is invalid", keyStructure)
+	return
 }
 
 // Put 新增数据
@@ -368,7 +752,29 @@ func (f *Form) Get(_ string) (interface{}, error) { return nil, comm.ErrFormNotF
 
 // Del 删除数据
 //
-// key 指定的key
+// key 指定的自增ID
 //
 // 返回 删除的数据对象
-func (f *Form) Del(_ string) (interface{}, error) { return nil, comm.ErrFormNotFoundOrSupport }
+func (f *Form) Del(key string) (interface{}, error) {
+	hashKey, err := strconv.ParseUint(key, 10, 64)
+	if nil != err {
+		return nil, comm.ErrKeyNotFound
+	}
+	defer f.mu.Unlock()
+	f.mu.Lock()
+	for _, idx := range f.indexes {
+		if idx.KeyStructure() != indexAutoID {
+			continue
+		}
+		link := idx.Get(gnomon.HashMD516(key), hashKey)
+		if nil == link {
+			return nil, comm.ErrKeyNotFound
+		}
+		value, err := storage.Obtain().Take(utils.PathFormFile(f.databaseID, f.id), link.SeekStart(), link.SeekLast())
+		if nil != err {
+			return nil, err
+		}
+		return value, f.remove(link.SeekStart(), link.SeekLast(), value)
+	}
+	return nil, comm.ErrKeyNotFound
+}
@@ -26,8 +26,6 @@ package index
 
 import (
 	"bufio"
-	"context"
-	"errors"
 	"github.com/aberic/gnomon"
 	"github.com/aberic/gnomon/log"
 	"github.com/aberic/lilydb/engine/siam/utils"
@@ -45,17 +43,21 @@ import (
 //
 // id 索引唯一ID
 //
-// keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'
+// keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'，组合索引的各字段名称间通过','分隔，如'tenant,createdAt'
 //
 // primary 是否主键
-func NewIndex(databaseID, formID, id, keyStructure string, primary bool) *Index {
+//
+// unique 是否唯一索引，主键总是唯一索引，非唯一索引的同一key可对应多行数据
+func NewIndex(databaseID, formID, id, keyStructure string, primary, unique bool) *Index {
 	return &Index{
-		id:           id,
-		primary:      primary,
-		keyStructure: keyStructure,
-		node:         &node{level: 1, degreeIndex: 0, preNode: nil, nodes: []*node{}},
-		databaseID:   databaseID,
-		formID:       formID,
+		id:            id,
+		primary:       primary,
+		unique:        primary || unique,
+		keyStructure:  keyStructure,
+		keyStructures: utils.KeyStructures(keyStructure),
+		node:          &node{level: 1, degreeIndex: 0, preNode: nil, nodes: []*node{}},
+		databaseID:    databaseID,
+		formID:        formID,
 	}
 }
 
@@ -63,12 +65,17 @@ func NewIndex(databaseID, formID, id, keyStructure string, primary bool) *Index
 //
 // 5位key及16位md5后key及5位起始seek和4位持续seek
 type Index struct {
-	id           string // id 索引唯一ID
-	primary      bool   // 是否主键
-	keyStructure string // keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'
-	node         *node  // 节点
-	databaseID   string // 所属数据库ID
-	formID       string // 所属表ID
+	id            string   // id 索引唯一ID
+	primary       bool     // 是否主键
+	unique        bool     // 是否唯一索引
+	keyStructure  string   // keyStructure 按照规范结构组成的索引字段名称，由对象结构层级字段通过'.'组成，如'i','in.s'
+	keyStructures []string // keyStructures 组合索引按声明顺序拆分的各字段名称，单字段索引仅包含keyStructure
+	node          *node    // 节点
+	databaseID    string   // 所属数据库ID
+	formID        string   // 所属表ID
+	building      int32    // 是否正在回填已存在的数据，回填完成前不参与检索
+	done          uint64   // 已回填数据行数
+	total         uint64   // 需回填数据行数
 }
 
 // ID 索引唯一ID
@@ -81,11 +88,62 @@ func (i *Index) Primary() bool {
 	return i.primary
 }
 
+// Unique 是否唯一索引
+func (i *Index) Unique() bool {
+	return i.unique
+}
+
 // KeyStructure 索引字段名称，由对象结构层级字段通过'.'组成，如
 func (i *Index) KeyStructure() string {
 	return i.keyStructure
 }
 
+// KeyStructures 按声明顺序拆分的各字段名称，组合索引按首字段有序
+func (i *Index) KeyStructures() []string {
+	return i.keyStructures
+}
+
+// Composite 是否由多个字段组成的组合索引
+func (i *Index) Composite() bool {
+	return len(i.keyStructures) > 1
+}
+
+// Rows 索引中的数据行数，作为检索计划估算代价的统计信息
+func (i *Index) Rows() int64 {
+	return atomic.LoadInt64(&i.node.count)
+}
+
+// Backfill 标记索引正在回填已存在的数据
+//
+// total 需回填数据行数
+func (i *Index) Backfill(total uint64) {
+	atomic.StoreUint64(&i.total, total)
+	atomic.StoreUint64(&i.done, 0)
+	atomic.StoreInt32(&i.building, 1)
+}
+
+// Advance 记录已回填数据行数
+func (i *Index) Advance(delta uint64) {
+	atomic.AddUint64(&i.done, delta)
+}
+
+// Complete 回填完成，索引开始参与检索
+func (i *Index) Complete() {
+	atomic.StoreInt32(&i.building, 0)
+}
+
+// Building 是否正在回填已存在的数据
+func (i *Index) Building() bool {
+	return atomic.LoadInt32(&i.building) == 1
+}
+
+// Progress 回填进度
+//
+// 返回 done 已回填数据行数，total 需回填数据行数
+func (i *Index) Progress() (done, total uint64) {
+	return atomic.LoadUint64(&i.done), atomic.LoadUint64(&i.total)
+}
+
 // Put 插入数据
 //
 // key 必须string类型
@@ -95,8 +153,9 @@ func (i *Index) KeyStructure() string {
 // value 存储对象
 //
 // update 本次是否执行更新操作
+// 非唯一索引总是新建link，exist恒为false
 func (i *Index) Put(md516Key string, hashKey uint64, version int) (link *Link, exist, versionGT bool) {
-	return i.node.put(md516Key, hashKey, hashKey, version)
+	return i.node.put(md516Key, hashKey, hashKey, version, i.unique)
 }
 
 // Get 获取数据，返回存储对象
@@ -108,7 +167,39 @@ func (i *Index) Get(md516Key string, hashKey uint64) *Link {
 	return i.node.get(md516Key, hashKey, hashKey)
 }
 
+// Del 删除数据，返回被删除的link
+//
+// md516Key md516Key，必须string类型
+//
+// hashKey 索引key，可通过hash转换string生成
+func (i *Index) Del(md516Key string, hashKey uint64) (*Link, error) {
+	return i.node.del(md516Key, hashKey, hashKey)
+}
+
+// Links 获取指定key对应的所有link，唯一索引至多返回一个
+//
+// md516Key md516Key，必须string类型
+//
+// hashKey 索引key，可通过hash转换string生成
+func (i *Index) Links(md516Key string, hashKey uint64) []*Link {
+	return i.node.links4Key(md516Key, hashKey, hashKey)
+}
+
+// Remove 移除指定link，非唯一索引据此仅移除某一行数据的引用
+func (i *Index) Remove(link *Link) error {
+	return i.node.remove(link, link.hashKey)
+}
+
+// Range 按索引顺序遍历所有link，handler返回false时终止遍历
+func (i *Index) Range(handler func(link *Link) bool) {
+	i.node.rangeLinks(handler)
+}
+
 // Recover 重置索引数据
+//
+// 返回 autoID 索引中已恢复的最大hashKey，自增主键索引可据此恢复表自增ID
+//
+// 返回 err 恢复过程中任一分块出错时返回首个错误，其余分块仍会继续恢复
 func (i *Index) Recover() (autoID *uint64, err error) {
 	indexFilePath := utils.PathFormIndexFile(i.databaseID, i.formID, i.id)
 	if gnomon.FilePathExists(indexFilePath) { // 索引文件存在才继续恢复
@@ -125,19 +216,22 @@ func (i *Index) Recover() (autoID *uint64, err error) {
 		// 获取将索引分块后的数量，20/18=1
 		pieceCount = indexContentSize / utils.LenPeekOnce64
 		var (
-			wg          sync.WaitGroup
-			offset      int64
-			ctx, cancel = context.WithCancel(context.Background())
+			wg     sync.WaitGroup
+			errMu  sync.Mutex
+			offset int64
 		)
-		defer cancel()                                   // 确保所有路径都取消了上下文，以避免上下文泄漏
 		for offset = pieceCount; offset >= 0; offset-- { // 将索引进行分块恢复，倒序恢复能尽量避免重复赋值，提升效率
 			wg.Add(1)
-			go func(ctx context.Context, indexFilePath string, offset int64) {
+			go func(indexFilePath string, offset int64) {
 				defer wg.Done()
-				if err := i.read(ctx, autoID, indexFilePath, offset*utils.LenPeekOnce64); nil != err {
-					cancel()
+				if errRead := i.read(autoID, indexFilePath, offset*utils.LenPeekOnce64); nil != errRead {
+					defer errMu.Unlock()
+					errMu.Lock()
+					if nil == err {
+						err = errRead
+					}
 				}
-			}(ctx, indexFilePath, offset)
+			}(indexFilePath, offset)
 		}
 		wg.Wait()
 		return
@@ -145,58 +239,43 @@ func (i *Index) Recover() (autoID *uint64, err error) {
 	return nil, ErrIndexFileNotFound
 }
 
-func (i *Index) read(ctx context.Context, autoID *uint64, indexFilePath string, offset int64) (err error) {
-	var (
-		data    []byte
-		success = make(chan struct{})
-	)
+func (i *Index) read(autoID *uint64, indexFilePath string, offset int64) (err error) {
+	var data []byte
 	if data, err = i.readAppointData(indexFilePath, offset); nil != err {
-		if io.EOF == err {
-			if len(data) == 0 {
-				return
-			}
-			if len(data)%utils.LenIndex != 0 { // 单条索引默认占用长度
-				return errors.New("index lens does't match")
-			}
-		} else {
+		if io.EOF != err {
 			return
 		}
-	}
-	go func(data []byte) {
-		var (
-			wg          sync.WaitGroup
-			haveNext          = true
-			indexStr          = string(data)
-			indexStrLen       = int64(len(indexStr))
-			position    int64 = 0
-		)
-		for haveNext {
-			wg.Add(1)
-			go func(position int64, indexStr string) {
-				defer wg.Done()
-				// 恢复索引中link数据，同时对路径上的node进行恢复
-				i.recoverLink(autoID, position, indexStr)
-			}(position, indexStr)
-			position += utils.LenIndex64 // 单条索引默认占用长度
-			if indexStrLen < position+utils.LenIndex64 {
-				haveNext = false
-			}
+		if len(data) == 0 {
+			return nil
 		}
-		wg.Wait()
-		success <- struct{}{}
-	}(data)
-	for {
-		select {
-		case <-ctx.Done(): // 上下文停止，直接返回
-			return
-		case <-success: // 处理完成，返回
-			return
+		if len(data)%utils.LenIndex != 0 { // 单条索引默认占用长度
+			return ErrIndexLenNotMatch
 		}
 	}
+	var (
+		wg          sync.WaitGroup
+		indexStr          = string(data)
+		indexStrLen       = int64(len(indexStr))
+		position    int64 = 0
+	)
+	for ; position+utils.LenIndex64 <= indexStrLen; position += utils.LenIndex64 { // 单条索引默认占用长度
+		wg.Add(1)
+		go func(position int64) {
+			defer wg.Done()
+			// 恢复索引中link数据，同时对路径上的node进行恢复
+			i.recoverLink(autoID, offset, position, indexStr)
+		}(position)
+	}
+	wg.Wait()
+	return nil
 }
 
 // recoverLink 恢复索引中link数据，同时对路径上的node进行恢复
-func (i *Index) recoverLink(autoID *uint64, position int64, indexStr string) {
+//
+// offset 当前分块在索引文件中的起始位置
+//
+// position 当前索引在分块中的起始位置
+func (i *Index) recoverLink(autoID *uint64, offset, position int64, indexStr string) {
 	var p0, p1, p2, p3, p4, p5 int64
 	// 读取 11位hashKey + 16位md5Key + 11位起始seek + 4位持续seek + 4位版本号 = 46
 	p0 = position
@@ -211,10 +290,14 @@ func (i *Index) recoverLink(autoID *uint64, position int64, indexStr string) {
 	seekLast := int(gnomon.ScaleDDuoStringToInt64(indexStr[p3:p4])) // value最终存储在文件中的持续长度
 	version := int(gnomon.ScaleDDuoStringToInt64(indexStr[p4:p5]))
 	//log.Debug("read", log.Field("i", i), log.Field("node", i.node))
-	link, _, versionGT := i.node.put(md516Key, hashKey, hashKey, version)
-	if versionGT {
-		link.Fit(p0, seekStart, seekLast, version)
-		atomic.AddUint64(autoID, 1) // ID自增
+	if version != utils.VersionTombstone { // 墓碑记录表示数据已被删除，无需恢复
+		i.node.leaf(hashKey).recoverLink(md516Key, hashKey, version, i.unique, offset+p0, seekStart, seekLast)
+	}
+	for { // 记录已恢复的最大hashKey
+		max := atomic.LoadUint64(autoID)
+		if hashKey <= max || atomic.CompareAndSwapUint64(autoID, max, hashKey) {
+			break
+		}
 	}
 }
 
@@ -228,7 +311,7 @@ func (i *Index) readAppointData(indexFilePath string, offset int64) (data []byte
 		log.Error("index recover multi read failed", log.Err(err))
 		return
 	}
-
+	defer func() { _ = file.Close() }()
 	// 将file下标移动到指定位置作为读取数据的起始位置
 	if _, err = file.Seek(offset, io.SeekStart); nil != err { //表示文件的起始位置，从第二个字符往后写入。
 		return
@@ -26,11 +26,21 @@ package index
 
 import (
 	"encoding/json"
+	"github.com/aberic/gnomon"
+	"github.com/aberic/lilydb/config"
+	"github.com/aberic/lilydb/engine/comm"
+	"github.com/aberic/lilydb/engine/siam/storage"
+	"github.com/aberic/lilydb/engine/siam/utils"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 )
 
 func newIndex(databaseID, formID string) *Index {
-	return NewIndex(databaseID, formID, "indexID", "indexID", true)
+	return NewIndex(databaseID, formID, "indexID", "indexID", true, true)
 }
 
 func linkFit() *Link {
@@ -66,7 +76,7 @@ func TestLink_Version(t *testing.T) {
 }
 
 func TestNewIndex(t *testing.T) {
-	t.Log(NewIndex("database", "form", "indexID", "id", true))
+	t.Log(NewIndex("database", "form", "indexID", "id", true, true))
 }
 
 func TestIndex_ID(t *testing.T) {
@@ -123,6 +133,43 @@ func TestIndex_RecoverNoFile(t *testing.T) {
 	t.Skip(idx.Recover())
 }
 
+// TestIndex_RecoverVersions 同一key的多个版本分布在不同分块中并发恢复，需配合 -race 运行
+func TestIndex_RecoverVersions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lilydb-index")
+	if nil != err {
+		t.Fatal(err)
+	}
+	dataDir := config.Obtain().DataDir
+	config.Obtain().DataDir = dir
+	defer func() {
+		config.Obtain().DataDir = dataDir
+		_ = os.RemoveAll(dir)
+	}()
+	var (
+		records []*storage.IndexRecord
+		count   = 3 * utils.LenPeekOnce / utils.LenIndex // 跨越多个分块
+	)
+	for i := 0; i < count; i++ {
+		records = append(records, &storage.IndexRecord{HashKey: 1, MD516Key: "0123456789abcdef", SeekStart: int64(i), SeekLast: 1, Version: i})
+	}
+	rand.Shuffle(len(records), func(i, j int) { records[i], records[j] = records[j], records[i] })
+	idx := newIndex("database", "form")
+	indexFilePath := utils.PathFormIndexFile("database", "form", idx.ID())
+	if err = os.MkdirAll(filepath.Dir(indexFilePath), os.ModePerm); nil != err {
+		t.Fatal(err)
+	}
+	if err = storage.RewriteIndex(indexFilePath, records); nil != err {
+		t.Fatal(err)
+	}
+	if _, err = idx.Recover(); nil != err {
+		t.Fatal(err)
+	}
+	links := idx.Links("0123456789abcdef", 1)
+	if len(links) != 1 || links[0].Version() != count-1 || links[0].SeekStart() != int64(count-1) {
+		t.Error("recover versions failed", links)
+	}
+}
+
 var selectorJSONString = `{
 		"Conditions":[
 			{
@@ -246,7 +293,7 @@ func TestSelector_RunStruct(t *testing.T) {
 
 func TestSelector_RunStructWithKeyStructure(t *testing.T) {
 	var (
-		idx     = NewIndex("database", "form", "indexID", "Age", true)
+		idx     = NewIndex("database", "form", "indexID", "Age", true, true)
 		indexes = []*Index{idx}
 	)
 	if autoID, err := idx.Recover(); nil != err {
@@ -279,7 +326,7 @@ func TestSelector_RunStructWithKeyStructure(t *testing.T) {
 
 func TestSelector_RunStructWithKeyStructureAsc(t *testing.T) {
 	var (
-		idx     = NewIndex("database", "form", "indexID", "Age", true)
+		idx     = NewIndex("database", "form", "indexID", "Age", true, true)
 		indexes = []*Index{idx}
 	)
 	if autoID, err := idx.Recover(); nil != err {
@@ -312,7 +359,7 @@ func TestSelector_RunStructWithKeyStructureAsc(t *testing.T) {
 
 func TestSelector_RunStructWithKeyStructureDelete(t *testing.T) {
 	var (
-		idx     = NewIndex("database", "form", "indexID", "Age", true)
+		idx     = NewIndex("database", "form", "indexID", "Age", true, true)
 		indexes = []*Index{idx}
 	)
 	if autoID, err := idx.Recover(); nil != err {
@@ -345,7 +392,7 @@ func TestSelector_RunStructWithKeyStructureDelete(t *testing.T) {
 
 func TestSelector_RunStructWithKeyStructureAscDelete(t *testing.T) {
 	var (
-		idx     = NewIndex("database", "form", "indexID", "Age", true)
+		idx     = NewIndex("database", "form", "indexID", "Age", true, true)
 		indexes = []*Index{idx}
 	)
 	if autoID, err := idx.Recover(); nil != err {
@@ -375,3 +422,81 @@ func TestSelector_RunStructWithKeyStructureAscDelete(t *testing.T) {
 	count, values := selector.Run()
 	t.Log(count, values)
 }
+
+func TestSelector_IndexComposite(t *testing.T) {
+	var (
+		single    = NewIndex("database", "form", "singleID", "Name", false, false)
+		composite = NewIndex("database", "form", "compositeID", utils.CompositeKeyStructure("Name", "Age"), false, true)
+	)
+	var selector = &Selector{
+		indexes: []*Index{single, composite},
+		Conditions: []*condition{
+			{Param: "Name", Cond: "eq", Value: "a"},
+			{Param: "Age", Cond: "gt", Value: 3},
+		},
+	}
+	if idx, _, _, _, _ := selector.index(); idx != composite {
+		t.Error("composite index not chosen", idx.KeyStructure())
+	}
+	selector.Conditions = selector.Conditions[:1]
+	if idx, _, _, _, _ := selector.index(); idx != single {
+		t.Error("single index not chosen", idx.KeyStructure())
+	}
+	selector.Conditions = []*condition{{Param: "Age", Cond: "gt", Value: 3}}
+	if idx, _, nc, _, _ := selector.index(); nil != nc {
+		t.Error("composite index used without its leading field", idx.KeyStructure())
+	}
+}
+
+func TestSelector_IndexGroups(t *testing.T) {
+	var (
+		auto = NewIndex("database", "form", "autoID", "auto", true, true)
+		name = NewIndex("database", "form", "nameID", "Name", false, false)
+	)
+	var selector = &Selector{
+		indexes: []*Index{auto, name},
+		Groups: []*group{
+			{Logic: logicOr, Conditions: []*condition{{Param: "Age", Cond: "eq", Value: 1}}},
+			{Logic: logicAnd, Conditions: []*condition{{Param: "Name", Cond: "eq", Value: "a"}}},
+		},
+	}
+	if idx, _, nc, _, _ := selector.index(); idx != name || nil == nc {
+		t.Error("and group index not chosen", idx.KeyStructure())
+	}
+	selector.Groups = selector.Groups[:1]
+	selector.Groups[0].Conditions[0].Param = "Name"
+	if idx, _, _, _, _ := selector.index(); idx != auto {
+		t.Error("or group should not choose index", idx.KeyStructure())
+	}
+}
+
+func TestSelector_Plan(t *testing.T) {
+	var (
+		auto = NewIndex("database", "form", "autoID", "auto", true, true)
+		name = NewIndex("database", "form", "nameID", "Name", false, true)
+	)
+	for position, key := range []string{"aazz", "abcd", "ba", "zz"} {
+		_, hashKey, _ := utils.Type2index(key)
+		name.Put(gnomon.HashMD516(key), hashKey, 0)
+		auto.Put(gnomon.HashMD516(strconv.Itoa(position)), uint64(position), 0)
+	}
+	var selector = &Selector{
+		indexes:    []*Index{name, auto},
+		Conditions: []*condition{{Param: "Name", Cond: "ge", Value: "abcd"}},
+	}
+	if idx, _, nc, _, _ := selector.index(); idx != name || nil == nc || selector.candidates[0].Rows != 3 {
+		t.Error("range estimate failed", idx.KeyStructure(), selector.candidates[0].Rows)
+	}
+	selector.Conditions = []*condition{{Param: "Name", Cond: "in", Value: []interface{}{"ba", "zz", "no"}}}
+	if selector.index(); selector.candidates[0].Rows != 2 {
+		t.Error("in estimate failed", selector.candidates[0].Rows)
+	}
+	selector.Conditions = nil
+	if idx, _, _, _, _ := selector.index(); idx != auto || len(selector.candidates) != 1 {
+		t.Error("full scan should use primary index", idx.KeyStructure())
+	}
+	selector.indexes = nil // 检索期间表被删除
+	if _, _, _, _, err := selector.index(); err != comm.ErrIndexNotFound {
+		t.Error("empty indexes should fail", err)
+	}
+}
@@ -27,10 +27,11 @@ package index
 // Link 叶子节点下的链表对象接口
 type Link struct {
 	md516Key       string
-	seekStartIndex int64 // 索引最终存储在文件中的起始位置
-	seekStart      int64 // value最终存储在文件中的起始位置
-	seekLast       int   // value最终存储在文件中的持续长度
-	version        int   // 当前索引数据版本号
+	hashKey        uint64 // 索引key
+	seekStartIndex int64  // 索引最终存储在文件中的起始位置
+	seekStart      int64  // value最终存储在文件中的起始位置
+	seekLast       int    // value最终存储在文件中的持续长度
+	version        int    // 当前索引数据版本号
 }
 
 // Fit 填充数据
@@ -54,6 +55,11 @@ func (l *Link) MD516Key() string {
 	return l.md516Key
 }
 
+// HashKey 获取索引key
+func (l *Link) HashKey() uint64 {
+	return l.hashKey
+}
+
 // SeekStartIndex 索引最终存储在文件中的起始位置
 func (l *Link) SeekStartIndex() int64 {
 	return l.seekStartIndex
@@ -73,3 +79,8 @@ func (l *Link) SeekLast() int {
 func (l *Link) Version() int {
 	return l.version
 }
+
+// row 数据行标识，同一时刻各数据行在文件中的起始位置互不相同
+func (l *Link) row() uint64 {
+	return uint64(l.seekStart)
+}
@@ -40,9 +40,26 @@ type condition struct {
 	//	}
 	//
 	// key可取'i','in.s'
-	Param string      `json:"Param"`
-	Cond  string      `json:"Cond"`  // 条件 gt/lt/eq/dif 大于/小于/等于/不等
-	Value interface{} `json:"Value"` // 比较对象，支持int、string、float和bool
+	Param string `json:"Param"`
+	// 条件 gt/lt/ge/le/eq/dif 大于/小于/大于等于/小于等于/等于/不等；
+	// in/nin 属于/不属于Value数组；between 介于Value数组的两个值之间，包含边界；
+	// prefix/contains/regex 字符串以Value开头/包含Value/匹配Value正则表达式；
+	// exists/null Value为true时表示字段存在/字段不存在或值为空，为false时取反
+	Cond  string      `json:"Cond"`
+	Value interface{} `json:"Value"` // 比较对象，支持int、string、float和bool，in/nin/between为数组
+}
+
+const (
+	logicAnd = "and" // logicAnd 组内条件及子条件组全部满足
+	logicOr  = "or"  // logicOr 组内条件及子条件组任一满足
+	logicNot = "not" // logicNot 组内条件及子条件组不全部满足，即全部满足的结果取反
+)
+
+// group 逻辑条件组，可嵌套组成如'(status eq "open" OR status eq "pending") AND NOT owner eq ""'的条件树
+type group struct {
+	Logic      string       `json:"Logic"`      // 逻辑关系 and/or/not，默认为and
+	Conditions []*condition `json:"Conditions"` // 组内条件
+	Groups     []*group     `json:"Groups"`     // 子条件组
 }
 
 // rank 排序方式
@@ -60,7 +77,8 @@ type rank struct {
 	//
 	// key可取'i','in.s'
 	Param string `json:"Param"`
-	ASC   bool   `json:"Asc"` // 是否升序
+	ASC   bool   `json:"Asc"`   // 是否升序
+	Nulls string `json:"Nulls"` // 字段不存在或值为空的数据排在最前first或最后last，默认视为最小值，即升序时在前、倒序时在后
 }
 
 // nodeCondition 多个相同Param条件检索预匹配的节点单元
@@ -75,6 +93,8 @@ type nodeSelector struct {
 	degreeIndex uint16 // 当前节点所在集合中的索引下标，该坐标不一定在数组中的正确位置，但一定是逻辑正确的
 	nextNode    *nodeSelector
 	cond        *condition
+	// hashKeys 条件各值的hashKey，条件in的任一值满足即可，其余条件仅有一个值
+	hashKeys []uint64
 }
 
 // paramCondition 参数条件结构
@@ -82,3 +102,12 @@ type paramCondition struct {
 	paramType  paramType   // paramType 参数类型
 	paramValue interface{} // paramValue 参数对应指定类型的值
 }
+
+// rowHandler 流式检索的数据处理方法，返回false时停止检索
+type rowHandler func(value interface{}) bool
+
+// Row 删除检索命中的数据行
+type Row struct {
+	Link  *Link       // 命中数据在所用索引中的link
+	Value interface{} // 命中数据对象
+}
@@ -26,9 +26,13 @@ package index
 
 import (
 	"errors"
+	"github.com/aberic/lilydb/engine/comm"
+	"github.com/aberic/lilydb/engine/plan"
+	"math"
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 // node 手提袋
@@ -44,6 +48,7 @@ type node struct {
 	preNode     *node  // node 所属 trolley
 	nodes       []*node
 	links       []*Link
+	count       int64 // 当前节点下的link数量，作为检索计划估算命中行数的统计信息
 	mu          sync.RWMutex
 }
 
@@ -57,28 +62,29 @@ type node struct {
 //
 // version 当前索引数据版本号
 //
+// unique 是否唯一索引，非唯一索引总是新建link
+//
 // return exist 返回是否存在
-func (n *node) put(md516Key string, hashKey, flexibleKey uint64, version int) (link *Link, exist, versionGT bool) {
+func (n *node) put(md516Key string, hashKey, flexibleKey uint64, version int, unique bool) (link *Link, exist, versionGT bool) {
+	return n.leaf(flexibleKey).link(md516Key, hashKey, version, unique)
+}
+
+// leaf 创建或获取指定key所在的叶子节点
+//
+// flexibleKey 下一级最左最小树所对应真实key
+func (n *node) leaf(flexibleKey uint64) *node {
+	if n.level >= 5 {
+		return n
+	}
 	var (
-		nextDegree      uint16 // 下一节点所在当前节点下度的坐标
-		nextFlexibleKey uint64 // 下一级最左最小树所对应真实key
-		distance        uint64 // 指定Level层级节点内各个子节点之前的差
-		nd              *node
+		distance        = levelDistance(n.level)                    // 指定Level层级节点内各个子节点之前的差
+		nextDegree      = uint16(flexibleKey / distance)            // 下一节点所在当前节点下度的坐标
+		nextFlexibleKey = flexibleKey - uint64(nextDegree)*distance // 下一级最左最小树所对应真实key
 	)
-	if n.level < 5 {
-		distance = levelDistance(n.level)
-		//gnomon.Log().Debug("put", gnomon.Log().Field("key", key), gnomon.Log().Field("distance", distance))
-		nextDegree = uint16(flexibleKey / distance)
-		nextFlexibleKey = flexibleKey - uint64(nextDegree)*distance
-		if n.level == 4 {
-			nd = n.createOrTakeLeaf(nextDegree) // 创建或获取下一个叶子节点
-		} else {
-			nd = n.createOrTakeNode(nextDegree) // 创建或获取下一个子节点
-		}
-	} else {
-		return n.link(md516Key, version)
+	if n.level == 4 {
+		return n.createOrTakeLeaf(nextDegree) // 创建或获取下一个叶子节点
 	}
-	return nd.put(md516Key, hashKey, nextFlexibleKey, version)
+	return n.createOrTakeNode(nextDegree).leaf(nextFlexibleKey) // 创建或获取下一个子节点
 }
 
 // get 获取数据，返回存储数据的可检索对象
@@ -111,6 +117,106 @@ func (n *node) get(md516Key string, hashKey, flexibleKey uint64) *Link {
 	return nil
 }
 
+// del 删除数据，返回被删除的link
+//
+// md516Key md516Key，必须string类型
+//
+// hashKey 索引key，可通过hash转换string生成
+//
+// flexibleKey 下一级最左最小树所对应真实key
+func (n *node) del(md516Key string, hashKey, flexibleKey uint64) (*Link, error) {
+	var (
+		nextDegree      uint16 // 下一节点所在当前节点下度的坐标
+		nextFlexibleKey uint64 // 下一级最左最小树所对应真实key
+		distance        uint64 // 指定Level层级节点内各个子节点之前的差
+	)
+	if n.level < 5 {
+		distance = levelDistance(n.level)
+		nextDegree = uint16(flexibleKey / distance)
+		nextFlexibleKey = flexibleKey - uint64(nextDegree)*distance
+	} else {
+		return n.removeLink(md516Key)
+	}
+	if realIndex, err := n.existNode(nextDegree); nil == err {
+		return n.nodes[realIndex].del(md516Key, hashKey, nextFlexibleKey)
+	}
+	return nil, comm.ErrLinkNotFound
+}
+
+// links4Key 获取指定key对应的所有link
+//
+// md516Key md516Key，必须string类型
+//
+// hashKey 索引key，可通过hash转换string生成
+//
+// flexibleKey 下一级最左最小树所对应真实key
+func (n *node) links4Key(md516Key string, hashKey, flexibleKey uint64) []*Link {
+	if n.level < 5 {
+		distance := levelDistance(n.level)
+		nextDegree := uint16(flexibleKey / distance)
+		if realIndex, err := n.existNode(nextDegree); nil == err {
+			return n.nodes[realIndex].links4Key(md516Key, hashKey, flexibleKey-uint64(nextDegree)*distance)
+		}
+		return nil
+	}
+	defer n.mu.RUnlock()
+	n.mu.RLock()
+	var links []*Link
+	for _, link := range n.links {
+		if strings.EqualFold(link.MD516Key(), md516Key) {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// remove 移除指定link
+//
+// flexibleKey 下一级最左最小树所对应真实key
+func (n *node) remove(lk *Link, flexibleKey uint64) error {
+	if n.level < 5 {
+		distance := levelDistance(n.level)
+		nextDegree := uint16(flexibleKey / distance)
+		if realIndex, err := n.existNode(nextDegree); nil == err {
+			return n.nodes[realIndex].remove(lk, flexibleKey-uint64(nextDegree)*distance)
+		}
+		return comm.ErrLinkNotFound
+	}
+	defer n.mu.Unlock()
+	n.mu.Lock()
+	for index, link := range n.links {
+		if link == lk {
+			links := make([]*Link, 0, len(n.links)-1)
+			links = append(links, n.links[:index]...)
+			n.links = append(links, n.links[index+1:]...)
+			n.addCount(-1)
+			return nil
+		}
+	}
+	return comm.ErrLinkNotFound
+}
+
+// rangeLinks 顺序遍历当前节点下所有link，handler返回false时终止遍历
+func (n *node) rangeLinks(handler func(link *Link) bool) bool {
+	if n.level < 5 {
+		for _, nd := range n.nodes {
+			if !nd.rangeLinks(handler) {
+				return false
+			}
+		}
+		return true
+	}
+	n.mu.RLock()
+	links := n.links
+	n.mu.RUnlock()
+	for _, link := range links {
+		if !handler(link) {
+			return false
+		}
+	}
+	return true
+}
+
 func (n *node) existNode(index uint16) (realIndex int, err error) {
 	return n.binaryMatchData(index)
 }
@@ -159,8 +265,20 @@ func (n *node) createOrTakeLeaf(index uint16) *node {
 //
 // md516Key 索引md516Key
 //
+// hashKey 索引key
+//
 // version 当前索引数据版本号
-func (n *node) link(md516Key string, version int) (lk *Link, exist, versionGT bool) {
+//
+// unique 是否唯一索引，非唯一索引总是新建link
+func (n *node) link(md516Key string, hashKey uint64, version int, unique bool) (lk *Link, exist, versionGT bool) {
+	if !unique {
+		defer n.mu.Unlock()
+		n.mu.Lock()
+		lk = &Link{md516Key: md516Key, hashKey: hashKey, seekStartIndex: -1, version: version}
+		n.links = append(n.links, lk)
+		n.addCount(1)
+		return lk, false, true
+	}
 	if pos, exist := n.existLink(md516Key); exist {
 		lk = n.links[pos]
 		if version > lk.version {
@@ -170,11 +288,45 @@ func (n *node) link(md516Key string, version int) (lk *Link, exist, versionGT bo
 	}
 	defer n.mu.Unlock()
 	n.mu.Lock()
-	lk = &Link{md516Key: md516Key, version: version}
+	for _, link := range n.links { // 并发恢复时可能已被其它协程创建
+		if strings.EqualFold(link.MD516Key(), md516Key) {
+			return link, true, version > link.version
+		}
+	}
+	lk = &Link{md516Key: md516Key, hashKey: hashKey, seekStartIndex: -1, version: version} // 新link尚未写入索引文件
+
 	n.links = append(n.links, lk)
+	n.addCount(1)
 	return lk, false, true
 }
 
+// recoverLink 以索引文件中的记录恢复link，查找、比较版本号及赋值均在节点锁内完成，避免并发恢复同一key时相互覆盖
+//
+// 唯一索引已存在的link仅在记录版本号更高时更新，非唯一索引总是新建link
+//
+// seekStartIndex 记录在索引文件中的起始位置
+//
+// seekStart value最终存储在文件中的起始位置
+//
+// seekLast value最终存储在文件中的持续长度
+func (n *node) recoverLink(md516Key string, hashKey uint64, version int, unique bool, seekStartIndex, seekStart int64, seekLast int) {
+	defer n.mu.Unlock()
+	n.mu.Lock()
+	if unique {
+		for _, link := range n.links {
+			if strings.EqualFold(link.MD516Key(), md516Key) {
+				if version > link.version {
+					link.Fit(seekStartIndex, seekStart, seekLast, version)
+				}
+				return
+			}
+		}
+	}
+	n.links = append(n.links, &Link{md516Key: md516Key, hashKey: hashKey, seekStartIndex: seekStartIndex,
+		seekStart: seekStart, seekLast: seekLast, version: version})
+	n.addCount(1)
+}
+
 func (n *node) existLink(md516Key string) (int, bool) {
 	defer n.mu.RUnlock()
 	n.mu.RLock()
@@ -187,6 +339,22 @@ func (n *node) existLink(md516Key string) (int, bool) {
 	return 0, false
 }
 
+// removeLink 移除link，新建切片以避免影响正在遍历原切片的检索
+func (n *node) removeLink(md516Key string) (*Link, error) {
+	defer n.mu.Unlock()
+	n.mu.Lock()
+	for index, link := range n.links {
+		if strings.EqualFold(link.MD516Key(), md516Key) {
+			links := make([]*Link, 0, len(n.links)-1)
+			links = append(links, n.links[:index]...)
+			n.links = append(links, n.links[index+1:]...)
+			n.addCount(-1)
+			return link, nil
+		}
+	}
+	return nil, comm.ErrLinkNotFound
+}
+
 func (n *node) appendNodal(node *node) *node {
 	nodesLen := len(n.nodes)
 	if nodesLen == 0 {
@@ -244,3 +412,48 @@ func (n *node) binaryMatchData(matchIndex uint16) (realIndex int, err error) {
 	}
 	return 0, errors.New("index is nil")
 }
+
+// Count 当前节点下的link数量
+func (n *node) Count() int64 {
+	return atomic.LoadInt64(&n.count)
+}
+
+// Children 子节点集合，叶子节点返回nil
+func (n *node) Children() []plan.Tree {
+	nodes := n.nodes
+	if nil == nodes {
+		return nil
+	}
+	children := make([]plan.Tree, len(nodes))
+	for i, nd := range nodes {
+		children[i] = nd
+	}
+	return children
+}
+
+// addCount 变更当前节点及各上级节点下的link数量
+func (n *node) addCount(delta int64) {
+	for nd := n; nil != nd; nd = nd.preNode {
+		atomic.AddInt64(&nd.count, delta)
+	}
+}
+
+// path 自第二层级节点至当前节点各节点的degreeIndex，用于生成分页游标
+func (n *node) path() []uint16 {
+	path := make([]uint16, 4)
+	for nd := n; nil != nd.preNode; nd = nd.preNode {
+		path[nd.level-2] = nd.degreeIndex
+	}
+	return path
+}
+
+// scope 当前节点所能容纳的hashKey范围，叶子节点仅容纳一个hashKey
+func (n *node) scope() (lo, hi uint64) {
+	if n.level == 1 {
+		return 0, math.MaxUint64
+	}
+	for nd := n; nd.level > 1; nd = nd.preNode {
+		lo += uint64(nd.degreeIndex) * levelDistance(nd.level-1)
+	}
+	return lo, lo + levelDistance(n.level-1) - 1
+}
@@ -25,11 +25,18 @@
 package index
 
 import (
+	"bytes"
 	"encoding/json"
 	"github.com/aberic/gnomon/log"
+	"github.com/aberic/lilydb/config"
+	api "github.com/aberic/lilydb/connector/grpc"
+	"github.com/aberic/lilydb/engine/comm"
+	"github.com/aberic/lilydb/engine/plan"
 	"github.com/aberic/lilydb/engine/siam/storage"
 	"github.com/aberic/lilydb/engine/siam/utils"
+	"math"
 	"reflect"
+	"regexp"
 	"strings"
 )
 
@@ -46,13 +53,30 @@ import (
 // delete 是否删除检索结果
 func NewSelector(selectorBytes []byte, indexes []*Index, databaseID, formID string, delete bool) (*Selector, error) {
 	selector := &Selector{}
-	if err := json.Unmarshal(selectorBytes, selector); nil != err {
+	decoder := json.NewDecoder(bytes.NewReader(selectorBytes))
+	decoder.UseNumber() // 条件值中的数字保留原文，避免超出2^53的整数经由float64丢失精度
+	if err := decoder.Decode(selector); nil != err {
 		return nil, err
 	}
+	conditionNumbers(selector.Conditions, selector.Groups)
 	selector.indexes = indexes
 	selector.databaseID = databaseID
 	selector.formID = formID
 	selector.delete = delete
+	if err := selector.ranks(); nil != err {
+		return nil, err
+	}
+	if nil != selector.Aggregate && !delete { // 删除模式不进行聚合
+		if err := selector.Aggregate.Verify(); nil != err {
+			return nil, err
+		}
+		selector.aggregator = utils.NewAggregator(selector.Aggregate)
+	}
+	if selector.Cursor != "" && !delete && nil == selector.aggregator { // 分页游标仅用于检索
+		if err := selector.seekCursor(); nil != err {
+			return nil, err
+		}
+	}
 	return selector, nil
 }
 
@@ -60,14 +84,48 @@ func NewSelector(selectorBytes []byte, indexes []*Index, databaseID, formID stri
 //
 // 查询顺序 scope -> match -> conditions -> skip -> sort -> limit
 type Selector struct {
-	indexes    []*Index     // indexes 指定表下索引集合
-	Conditions []*condition `json:"Conditions"` // Conditions 条件查询
-	Skip       uint32       `json:"Skip"`       // Skip 结果集跳过数量
-	Sort       *rank        `json:"Sort"`       // Sort 排序方式
-	Limit      uint32       `json:"Limit"`      // Limit 结果集顺序数量
-	databaseID string       // 数据库唯一ID
-	formID     string       // 表唯一ID
-	delete     bool         // 是否删除检索结果
+	indexes    []*Index                  // indexes 指定表下索引集合
+	Conditions []*condition              `json:"Conditions"` // Conditions 条件查询
+	Groups     []*group                  `json:"Groups"`     // Groups 逻辑条件组，各组之间及与Conditions之间为与关系
+	Skip       uint32                    `json:"Skip"`       // Skip 结果集跳过数量
+	Sort       *rank                     `json:"Sort"`       // Sort 排序方式
+	Sorts      []*rank                   `json:"Sorts"`      // Sorts 多字段排序方式，按先后顺序依次比较，存在时替代Sort
+	Limit      uint32                    `json:"Limit"`      // Limit 结果集顺序数量
+	Fields     []string                  `json:"Fields"`     // Fields 返回字段投影，由对象结构层级字段通过'.'组成，以'-'开头表示去除该字段
+	Aggregate  *utils.Aggregation        `json:"Aggregate"`  // Aggregate 聚合方式，存在时返回各分组的聚合结果
+	Cursor     string                    `json:"Cursor"`     // Cursor 上一页返回的分页游标，存在时自游标位置之后继续检索
+	Explain    bool                      `json:"Explain"`    // Explain 仅返回检索计划*api.Plan，不执行检索
+	databaseID string                    // 数据库唯一ID
+	formID     string                    // 表唯一ID
+	delete     bool                      // 是否删除检索结果
+	ands       []*condition              // 检索结果必须满足的条件集合，用于选择索引
+	regexps    map[string]*regexp.Regexp // 已编译的正则表达式
+	aggregator *utils.Aggregator         // 聚合计算器
+	seek       *plan.Cursor              // 解析后的分页游标，遍历越过游标位置后置空
+	next       *plan.Cursor              // 本页最后一条数据所在位置，用于生成下一页游标
+	nextLeaf   *node                     // 本页最后一条数据所在叶子节点
+	candidates []*plan.Candidate         // 检索计划的各候选索引，首个为所选索引
+	sortKeys   utils.SortKeys            // 由Sorts转换的多字段排序方式
+	sorter     *utils.Sorter             // 全量排序器，所选索引无法保证排序结果时保留全部检索结果
+	sortLinks  []Link                    // 全量排序时各检索结果所在link的副本，排序后按其位置重新读取数据
+	err        error                     // 检索过程中的错误
+	handler    rowHandler                // 流式检索的数据处理方法，返回false时停止检索
+	stopped    bool                      // 流式检索的数据处理方法是否已要求停止检索
+	rows       []*Row                    // 删除检索命中的数据行，由表负责从各索引中移除并持久化
+}
+
+// conditionNumbers 将条件及条件组中以json.Number解析的比较对象转换为对应数值原值
+func conditionNumbers(conditions []*condition, groups []*group) {
+	for _, cond := range conditions {
+		if nil != cond {
+			cond.Value = utils.JSONNumber(cond.Value)
+		}
+	}
+	for _, g := range groups {
+		if nil != g {
+			conditionNumbers(g.Conditions, g.Groups)
+		}
+	}
 }
 
 // Run 执行富查询
@@ -78,176 +136,467 @@ type Selector struct {
 //
 // return err 检索错误信息，如果有
 func (s *Selector) Run() (int32, []interface{}) {
-	idx, asc, nc, pcs := s.index() // 根据检索条件获取使用索引对象等信息
-	log.Debug("query", log.Field("index", idx.KeyStructure()))
 	if s.Limit == 0 { // 默认限制查询1000条数据
 		s.Limit = 1000
 	}
+	if s.Explain {
+		if p := s.explain(); nil != p {
+			return 0, []interface{}{p}
+		}
+		return 0, nil
+	}
+	if nil != s.aggregator {
+		return s.aggregate()
+	}
+	count, values := s.query()
+	if uint32(len(values)) < s.Limit { // 未达到Limit表示已无更多数据
+		s.next = nil
+	}
+	return count, s.project(values)
+}
+
+// Range 流式执行富查询，检索结果在遍历索引的过程中逐叶子节点交由handler逐条处理，不在内存中保留全部结果
+//
+// 未指定Limit时不限制数量；Sort字段为所用索引首字段时各叶子节点内的数据排序后处理，需要全量排序时待全部检索结果排序后再逐条处理
+//
+// handler 检索结果处理方法，返回false时停止检索
+//
+// return count 停止前已检索的结果条数，聚合查询时为分组总数
+func (s *Selector) Range(handler rowHandler) int32 {
+	if s.Limit == 0 {
+		s.Limit = math.MaxUint32
+	}
+	if s.Explain {
+		if p := s.explain(); nil != p {
+			handler(p)
+		}
+		return 0
+	}
+	if nil != s.aggregator {
+		count, values := s.aggregate()
+		for _, value := range values {
+			if !handler(value) {
+				break
+			}
+		}
+		return count
+	}
+	s.handler = handler
+	count, _ := s.query()
+	return count
+}
+
+// NextCursor 下一页的分页游标，将其作为下一次检索的Cursor即可继续检索，已无更多数据时返回空字符串
+//
+// 游标按所用索引的顺序续查，需要全量排序时排序结果与索引位置无关，不返回游标
+func (s *Selector) NextCursor() string {
+	if nil == s.next || nil == s.nextLeaf {
+		return ""
+	}
+	return s.next.Encode(s.nextLeaf.path())
+}
+
+// Err 检索过程中的错误，如排序临时文件读取失败
+func (s *Selector) Err() error {
+	return s.err
+}
+
+// ranks 整理排序方式，Sorts存在时替代Sort，Sort始终为首个排序字段以便选择索引
+func (s *Selector) ranks() error {
+	if len(s.Sorts) > 0 {
+		s.Sort = s.Sorts[0]
+	} else if nil != s.Sort {
+		s.Sorts = []*rank{s.Sort}
+	}
+	for _, r := range s.Sorts {
+		key, err := utils.NewSortKey(r.Param, r.ASC, r.Nulls)
+		if nil != err {
+			return err
+		}
+		s.sortKeys = append(s.sortKeys, key)
+	}
+	return nil
+}
+
+// seekCursor 解析分页游标，并限定检索使用游标所在索引
+func (s *Selector) seekCursor() error {
+	c, err := plan.Decode(s.Cursor)
+	if nil != err {
+		return err
+	}
+	for _, idx := range s.indexes {
+		if idx.ID() == c.IndexID {
+			s.indexes, s.seek = []*Index{idx}, c
+			return nil
+		}
+	}
+	return comm.ErrCursorInvalid
+}
+
+// query 根据检索条件选择索引并执行检索
+func (s *Selector) query() (int32, []interface{}) {
+	idx, asc, nc, pcs, err := s.index() // 根据检索条件获取使用索引对象等信息
+	if nil != err {
+		s.err = err
+		return 0, nil
+	}
+	log.Debug("query", log.Field("index", idx.KeyStructure()))
+	if nil != s.seek { // 沿用上一页的检索方向
+		asc = s.seek.Asc
+	}
+	if nil == s.aggregator && !s.delete {
+		if len(s.sortKeys) > 1 || (len(s.sortKeys) == 1 && !s.candidates[0].Sorted) {
+			return s.sortQuery(idx, asc, nc, pcs)
+		}
+		s.next = &plan.Cursor{IndexID: idx.ID(), Asc: asc, Unique: idx.Unique()}
+	}
 	if asc { // 是否顺序查询
 		return s.leftQueryIndex(idx, nc, pcs)
 	}
 	return s.rightQueryIndex(idx, nc, pcs)
 }
 
-// getIndex 根据检索条件获取使用索引对象
-//
-// index 已获取索引对象
+// sortQuery 全量检索后按多字段排序，超出内存预算的数据借助DataDir下的临时文件归并排序，跳过及限制数量作用于排序后的结果
 //
-// asc 是否顺序查询
+// 排序结果与索引位置无关，因此忽略分页游标且不返回下一页游标
+func (s *Selector) sortQuery(idx *Index, asc bool, nc *nodeCondition, pcs map[string]*paramCondition) (int32, []interface{}) {
+	skip, limit := s.Skip, s.Limit
+	s.Skip, s.Limit, s.seek = 0, math.MaxUint32, nil
+	s.sorter = utils.NewSorter(s.sortKeys, int(config.Obtain().SortBuffer))
+	if asc {
+		s.leftQueryIndex(idx, nc, pcs)
+	} else {
+		s.rightQueryIndex(idx, nc, pcs)
+	}
+	s.Skip, s.Limit = skip, limit
+	values := make([]interface{}, 0)
+	if err := s.sorter.Range(skip, limit, func(position int) bool {
+		link := s.sortLinks[position]
+		value, err := storage.Obtain().Take(utils.PathFormFile(s.databaseID, s.formID), link.seekStart, link.seekLast)
+		if nil != err {
+			s.err = err
+			return false
+		}
+		if nil != s.handler {
+			s.emit([]interface{}{value})
+			return !s.stopped
+		}
+		values = append(values, value)
+		return true
+	}); nil != err {
+		s.err = err
+	}
+	return int32(s.sorter.Count()), values
+}
+
+// aggregate 执行聚合查询
 //
-// cond 条件对象
-func (s *Selector) index() (index *Index, asc bool, nc *nodeCondition, pcs map[string]*paramCondition) {
-	// 优先尝试采用条件作为索引，缩小索引范围以提高检索效率
-	index, asc, nc, pcs = s.indexCondition()
-	if index != nil { // 如果存在条件查询，则优先条件查询
-		return
+// 满足条件的全部数据逐行累加，不受Skip及Limit限制；排序、跳过及限制数量作用于各分组的聚合结果，count为分组总数
+func (s *Selector) aggregate() (int32, []interface{}) {
+	sort, skip, limit := s.Sort, s.Skip, s.Limit
+	s.Sort, s.Skip, s.Limit = nil, 0, math.MaxUint32
+	s.query()
+	s.Sort, s.Skip, s.Limit = sort, skip, limit
+	values := s.aggregator.Result()
+	count := int32(len(values))
+	if nil != s.Sort {
+		values = s.shellSort(values)
+	}
+	if uint32(len(values)) > s.Skip {
+		values = values[s.Skip:]
+	} else {
+		values = values[len(values):]
+	}
+	if uint32(len(values)) > s.Limit {
+		values = values[:s.Limit]
+	}
+	return count, s.project(values)
+}
+
+// full 是否已命中限制数量或流式检索已被要求停止
+func (s *Selector) full(limit uint32) bool {
+	return limit >= s.Limit || s.stopped
+}
+
+// emit 流式检索时将叶子节点中的检索结果排序并投影后逐条交由handler处理，handler返回false时停止检索
+func (s *Selector) emit(is []interface{}) {
+	if len(is) > 1 && len(s.sortKeys) > 0 && nil == s.sorter {
+		is = s.shellSort(is)
 	}
-	for _, index = range s.indexes { // 如果存在排序查询，则优先排序查询
-		if nil != index && s.Sort != nil && s.Sort.Param == index.KeyStructure() {
+	for _, value := range is {
+		if s.stopped {
 			return
 		}
+		if len(s.Fields) > 0 {
+			value = utils.Project(value, s.Fields)
+		}
+		if !s.handler(value) {
+			s.stopped = true
+		}
 	}
-	// 取值默认索引来进行查询操作
-	index = s.indexes[0]
-	return
 }
 
-// indexCondition 优先尝试采用条件作为索引，缩小索引范围以提高检索效率
-//
-// 优先匹配有多个相同Param参数的条件，如果相同数量一样，则按照先后顺序选择最先匹配的
-func (s *Selector) indexCondition() (index *Index, asc bool, nc *nodeCondition, pcs map[string]*paramCondition) {
-	pcs = make(map[string]*paramCondition)
-	ncs := make(map[string]*nodeCondition)
-	asc = true
-	for _, condition := range s.Conditions { // 遍历检索条件
-		for _, idx := range s.indexes { // 遍历检索索引
-			if condition.Param == idx.KeyStructure() { // 匹配条件是否存在已有索引，如没有，进入下一轮循环
-				if nil != s.Sort && s.Sort.Param == idx.KeyStructure() { // 如果有，则继续判断该索引是否存在排序需求
-					index = idx
-					asc = s.Sort.ASC
-					if ncs[condition.Param] == nil {
-						ncs[condition.Param] = &nodeCondition{nss: []*nodeSelector{}}
-					}
-					s.conditionNode(ncs[condition.Param], condition)
-					break
-				}
-				if index != nil {
-					continue
-				}
-				index = idx
-				if ncs[condition.Param] == nil {
-					ncs[condition.Param] = &nodeCondition{nss: []*nodeSelector{}}
-				}
-				s.conditionNode(ncs[condition.Param], condition)
-			}
+// project 过滤及排序完成后再投影返回字段
+func (s *Selector) project(values []interface{}) []interface{} {
+	if len(s.Fields) > 0 {
+		for position, value := range values {
+			values[position] = utils.Project(value, s.Fields)
 		}
+	}
+	return values
+}
 
+// Rows 删除检索命中的数据行，仅在删除模式下有效
+func (s *Selector) Rows() []*Row {
+	return s.rows
+}
+
+// index 根据检索条件及各索引统计信息选择索引，选择过程见plan
+//
+// index 已获取索引对象
+//
+// asc 是否顺序查询
+//
+// nc 下推至索引树的条件节点单元
+//
+// pcs 各条件转换后的参数条件
+//
+// err 没有可用索引时返回错误
+func (s *Selector) index() (index *Index, asc bool, nc *nodeCondition, pcs map[string]*paramCondition, err error) {
+	s.ands = s.andConditions()
+	pcs = make(map[string]*paramCondition)
+	for _, condition := range s.Conditions {
 		paramType, paramValue, support := s.formatParam(condition.Value)
 		if support {
 			pcs[s.pcMapName(condition)] = &paramCondition{paramType: paramType, paramValue: paramValue}
 		}
 	}
-	var nodeCount = 0
-	for _, ncNow := range ncs {
-		ncNowCount := len(ncNow.nss)
-		if ncNowCount > nodeCount {
-			nodeCount = ncNowCount
-			nc = ncNow
-		}
+	if s.candidates, err = s.plan(); nil != err {
+		return
+	}
+	chosen := s.candidates[0]
+	index, asc = chosen.Index.(*Index), chosen.Asc
+	if chosen.Pushed {
+		nc = s.indexNodeCondition(index)
 	}
 	return
 }
 
-// conditionNode 根据条件匹配节点单元
-//
-// 该方法可以用更优雅或正确的方式实现，但烧脑，性能影响不大，先这样吧
-func (s *Selector) conditionNode(nc *nodeCondition, cond *condition) {
+// plan 为各可用索引估算检索代价并排序，首个候选即为所选索引，没有可用索引时返回错误
+func (s *Selector) plan() ([]*plan.Candidate, error) {
 	var (
-		hashKey, flexibleKey, nextFlexibleKey, distance uint64
-		nextDegree                                      uint16
-		ok                                              bool
+		indexes   = make([]plan.Index, len(s.indexes))
+		ands      = make([]string, len(s.ands))
+		sortParam string
+		sortAsc   bool
 	)
-	if _, hashKey, ok = utils.Type2index(cond.Value); !ok {
-		return
+	for i, idx := range s.indexes {
+		indexes[i] = idx
+	}
+	for i, condition := range s.ands {
+		ands[i] = condition.Param
+	}
+	if nil != s.Sort {
+		sortParam, sortAsc = s.Sort.Param, s.Sort.ASC
+	}
+	return plan.Rank(indexes, ands, sortParam, sortAsc, func(idx plan.Index) int64 {
+		return s.estimate(idx.(*Index), s.indexNodeCondition(idx.(*Index)))
+	})
+}
+
+// indexNodeCondition 将索引首字段上的所有条件组成检索索引树的节点单元，条件值均无法转换为索引时返回nil，仅在叶子节点中过滤
+func (s *Selector) indexNodeCondition(idx *Index) *nodeCondition {
+	nc := &nodeCondition{nss: []*nodeSelector{}}
+	for _, condition := range s.ands {
+		if condition.Param == idx.KeyStructures()[0] {
+			s.conditionNode(nc, condition)
+		}
+	}
+	if len(nc.nss) == 0 {
+		return nil
+	}
+	return nc
+}
+
+// estimate 估算满足下推条件的行数，各层级节点按条件节点单元中对应层级的条件匹配
+func (s *Selector) estimate(idx *Index, nc *nodeCondition) int64 {
+	if nil == nc {
+		return idx.Rows()
+	}
+	var levels []*nodeCondition
+	for level := nc; nil != level; level = level.nextNode {
+		levels = append(levels, level)
 	}
+	return plan.Estimate(idx.node, func(nd plan.Tree, depth int, leaf bool) bool {
+		if leaf {
+			return s.leafConditions(nd.(*node), levels[depth].nss)
+		}
+		return s.nodeConditions(nd.(*node), levels[depth].nss)
+	})
+}
 
-	nodeLevel1 := &nodeSelector{level: 1, degreeIndex: 0, cond: cond}
-	nc.nss = append(nc.nss, nodeLevel1)
-	flexibleKey = hashKey
-	distance = levelDistance(nodeLevel1.level)
-	nextDegree = uint16(flexibleKey / distance)
-	nextFlexibleKey = flexibleKey - uint64(nextDegree)*distance
+// explain 检索计划，没有可用索引时记录错误并返回nil
+func (s *Selector) explain() *api.Plan {
+	idx, asc, nc, _, err := s.index()
+	if nil != err {
+		s.err = err
+		return nil
+	}
+	var pushed, filtered []string
+	for _, condition := range s.Conditions {
+		if nil != nc && condition.Param == idx.KeyStructures()[0] && s.indexable(condition) {
+			pushed = append(pushed, s.formatCondition(condition))
+		} else {
+			filtered = append(filtered, s.formatCondition(condition))
+		}
+	}
+	for _, g := range s.Groups {
+		if nil != nc && (g.Logic == "" || g.Logic == logicAnd) { // 逻辑与组中首字段上的条件同样下推至索引树
+			for _, condition := range g.Conditions {
+				if condition.Param == idx.KeyStructures()[0] && s.indexable(condition) {
+					pushed = append(pushed, s.formatCondition(condition))
+				}
+			}
+		}
+		filtered = append(filtered, s.formatGroup(g))
+	}
+	if nil != s.seek {
+		asc = s.seek.Asc
+	}
+	return plan.Explain(s.candidates, asc, len(s.sortKeys) > 1 || (nil != s.Sort && !s.candidates[0].Sorted), pushed, filtered)
+}
 
-	nodeLevel2 := &nodeSelector{level: 2, degreeIndex: nextDegree, cond: cond}
-	nodeLevel1.nextNode = nodeLevel2
-	if nil == nc.nextNode {
-		nc.nextNode = &nodeCondition{nss: []*nodeSelector{}}
+// formatCondition 条件的可读形式
+func (s *Selector) formatCondition(cond *condition) string {
+	return plan.FormatCondition(cond.Param, cond.Cond, cond.Value)
+}
+
+// formatGroup 条件组的可读形式
+func (s *Selector) formatGroup(g *group) string {
+	var items []string
+	for _, condition := range g.Conditions {
+		items = append(items, s.formatCondition(condition))
 	}
-	nc.nextNode.nss = append(nc.nextNode.nss, nodeLevel2)
-	flexibleKey = nextFlexibleKey
-	distance = levelDistance(nodeLevel2.level)
-	nextDegree = uint16(flexibleKey / distance)
-	nextFlexibleKey = flexibleKey - uint64(nextDegree)*distance
+	for _, sub := range g.Groups {
+		items = append(items, s.formatGroup(sub))
+	}
+	return plan.FormatGroup(g.Logic, items)
+}
 
-	nodeLevel3 := &nodeSelector{level: 3, degreeIndex: nextDegree, cond: cond}
-	nodeLevel2.nextNode = nodeLevel3
-	if nil == nc.nextNode.nextNode {
-		nc.nextNode.nextNode = &nodeCondition{nss: []*nodeSelector{}}
+// andConditions 检索结果必须满足且可借助索引检索的条件集合，即顶层条件及逐层逻辑与组中的条件，逻辑或及逻辑非组中的条件不能用于选择索引
+func (s *Selector) andConditions() []*condition {
+	conditions := append([]*condition{}, s.Conditions...)
+	groups := s.Groups
+	for len(groups) > 0 {
+		var next []*group
+		for _, g := range groups {
+			if g.Logic == "" || g.Logic == logicAnd {
+				conditions = append(conditions, g.Conditions...)
+				next = append(next, g.Groups...)
+			}
+		}
+		groups = next
 	}
-	nc.nextNode.nextNode.nss = append(nc.nextNode.nextNode.nss, nodeLevel3)
-	flexibleKey = nextFlexibleKey
-	distance = levelDistance(nodeLevel3.level)
-	nextDegree = uint16(flexibleKey / distance)
-	nextFlexibleKey = flexibleKey - uint64(nextDegree)*distance
+	var indexables []*condition
+	for _, cond := range conditions {
+		if s.indexable(cond) {
+			indexables = append(indexables, cond)
+		}
+	}
+	return indexables
+}
 
-	nodeLevel4 := &nodeSelector{level: 4, degreeIndex: nextDegree, cond: cond}
-	nodeLevel3.nextNode = nodeLevel4
-	if nil == nc.nextNode.nextNode.nextNode {
-		nc.nextNode.nextNode.nextNode = &nodeCondition{nss: []*nodeSelector{}}
+// indexable 条件是否可借助索引检索，满足比较类条件的数据行其字段值必然已写入该字段的索引
+func (s *Selector) indexable(cond *condition) bool {
+	switch cond.Cond {
+	case "gt", "lt", "eq", "dif", "ge", "le", "between", "in":
+		return true
 	}
-	nc.nextNode.nextNode.nextNode.nss = append(nc.nextNode.nextNode.nextNode.nss, nodeLevel4)
-	flexibleKey = nextFlexibleKey
-	distance = levelDistance(nodeLevel4.level)
-	nextDegree = uint16(flexibleKey / distance)
+	return false
+}
 
-	nodeLevel5 := &nodeSelector{level: 5, degreeIndex: nextDegree, cond: cond}
-	nodeLevel4.nextNode = nodeLevel5
-	nodeLevel3.nextNode = nodeLevel4
-	if nil == nc.nextNode.nextNode.nextNode.nextNode {
-		nc.nextNode.nextNode.nextNode.nextNode = &nodeCondition{nss: []*nodeSelector{}}
+// conditionNode 根据条件匹配节点单元
+//
+// between拆分为ge及le两个条件分别匹配，in在各层级匹配其所有值所在下标的集合
+func (s *Selector) conditionNode(nc *nodeCondition, cond *condition) {
+	switch cond.Cond {
+	default:
+		if _, hashKey, ok := utils.Type2index(cond.Value); ok {
+			s.conditionChain(nc, cond, []uint64{hashKey})
+		}
+	case "between":
+		if values, ok := cond.Value.([]interface{}); ok && len(values) == 2 {
+			s.conditionNode(nc, &condition{Param: cond.Param, Cond: "ge", Value: values[0]})
+			s.conditionNode(nc, &condition{Param: cond.Param, Cond: "le", Value: values[1]})
+		}
+	case "in":
+		values, ok := cond.Value.([]interface{})
+		if !ok || len(values) == 0 {
+			return
+		}
+		hashKeys := make([]uint64, len(values))
+		for position, value := range values {
+			if _, hashKeys[position], ok = utils.Type2index(value); !ok {
+				return
+			}
+		}
+		s.conditionChain(nc, cond, hashKeys)
+	}
+}
+
+// conditionChain 根据条件值的hashKey集合自上而下逐层组成节点单元
+func (s *Selector) conditionChain(nc *nodeCondition, cond *condition, hashKeys []uint64) {
+	var previous *nodeSelector
+	for level := uint8(1); ; level++ {
+		ns := &nodeSelector{level: level, degreeIndex: degreeIndex(hashKeys[0], level), cond: cond, hashKeys: hashKeys}
+		if nil != previous {
+			previous.nextNode = ns
+		}
+		nc.nss = append(nc.nss, ns)
+		if level == 5 {
+			return
+		}
+		if nil == nc.nextNode {
+			nc.nextNode = &nodeCondition{nss: []*nodeSelector{}}
+		}
+		nc, previous = nc.nextNode, ns
 	}
-	nc.nextNode.nextNode.nextNode.nextNode.nss = append(nc.nextNode.nextNode.nextNode.nextNode.nss, nodeLevel5)
 }
 
 const (
-	paramInt64 paramType = iota
-	paramUint64
-	paramFloat64
+	paramNumber paramType = iota
 	paramString
 	paramBool
+	paramList
 )
 
 type paramType int
 
 // formatParam 梳理param的类型及值
 //
-// 类型：int=0;int64=1;uint64=2;string=3;bool=4
+// 类型：int、uint及float=0，值为 utils.Number;string=1;bool=2;数组=3，值为各元素的[]*paramCondition
 func (s *Selector) formatParam(paramValue interface{}) (paramType paramType, value interface{}, support bool) {
+	reflectValue := reflect.ValueOf(paramValue)
+	if number, ok := utils.ValueNumber(&reflectValue); ok {
+		return paramNumber, number, true
+	}
 	switch paramValue := paramValue.(type) {
 	default:
 		return -1, nil, false
-	case int:
-		return paramInt64, int64(paramValue), true
-	case int8, int16, int32, int64:
-		return paramInt64, paramValue.(int64), true
-	case uint8, uint16, uint32, uint, uint64, uintptr:
-		return paramUint64, paramValue.(uint64), true
-	case float32, float64:
-		return paramFloat64, paramValue.(float64), true
 	case string:
 		return paramString, paramValue, true
 	case bool:
 		return paramBool, paramValue, true
+	case []interface{}:
+		list := make([]*paramCondition, len(paramValue))
+		for position, item := range paramValue {
+			itemType, itemValue, itemSupport := s.formatParam(item)
+			if !itemSupport {
+				return -1, nil, false
+			}
+			list[position] = &paramCondition{paramType: itemType, paramValue: itemValue}
+		}
+		return paramList, list, true
 	}
 }
 
@@ -268,19 +617,24 @@ func (s *Selector) leftQueryIndex(index *Index, ns *nodeCondition, pcs map[strin
 		limitIn   uint32
 	)
 	for _, node := range index.node.nodes {
+		if s.passed(node) {
+			continue
+		}
 		if nil == ns {
 			skipIn, limitIn, nc, nis = s.leftQueryNode(skipIn, limitIn, node, nil, pcs)
-		} else {
+		} else if s.nodeConditions(node, ns.nextNode.nss) {
 			skipIn, limitIn, nc, nis = s.leftQueryNode(skipIn, limitIn, node, ns.nextNode, pcs)
+		} else {
+			continue
 		}
 
 		count += nc
 		is = append(is, nis...)
-		if limitIn >= s.Limit {
+		if s.full(limitIn) {
 			break
 		}
 	}
-	if s.Sort == nil {
+	if len(s.sortKeys) == 0 || nil != s.sorter {
 		return count, is
 	}
 	return count, s.shellSort(is)
@@ -300,6 +654,9 @@ func (s *Selector) leftQueryNode(skipIn, limitIn uint32, node *node, ns *nodeCon
 	)
 	if nodes := node.nodes; nil != nodes {
 		for _, nd := range nodes {
+			if s.passed(nd) {
+				continue
+			}
 			var (
 				nc  int32
 				nis []interface{}
@@ -311,7 +668,7 @@ func (s *Selector) leftQueryNode(skipIn, limitIn uint32, node *node, ns *nodeCon
 			}
 			count += nc
 			is = append(is, nis...)
-			if limit >= s.Limit {
+			if s.full(limit) {
 				break
 			}
 		}
@@ -335,11 +692,16 @@ func (s *Selector) leftQueryLeaf(skip, limit uint32, leaf *node, ns *nodeConditi
 		is    = make([]interface{}, 0)
 	)
 	if (nil != ns && s.leafConditions(leaf, ns.nss)) || nil == ns { // 满足等于与不等于条件
-		if limit >= s.Limit {
+		if s.full(limit) {
 			return skip, limit, 0, is
 		}
-		for position, link := range leaf.links {
-			if nil == pcs || len(pcs) == 0 {
+		links := leaf.links
+		for position := s.leafStart(links, true); position < len(links); position++ {
+			if s.full(limit) { // 本页已满，确保游标位置为本页最后一条数据
+				break
+			}
+			link := links[position]
+			if (nil == pcs || len(pcs) == 0) && len(s.Groups) == 0 { // 无需过滤时直接跳过
 				if skip > 0 {
 					skip--
 					continue
@@ -353,13 +715,27 @@ func (s *Selector) leftQueryLeaf(skip, limit uint32, leaf *node, ns *nodeConditi
 					continue
 				}
 				limit++
+				s.mark(leaf, position, link)
 				if s.delete {
-					leaf.links = append(leaf.links[:position], leaf.links[position+1:]...)
+					s.rows = append(s.rows, &Row{Link: link, Value: value})
+				}
+				if nil != s.aggregator { // 聚合查询逐行累加，无需保留数据
+					s.aggregator.Add(value)
+					continue
+				}
+				if nil != s.sorter { // 全量排序时交由排序器保留排序值，数据按位置重新读取
+					s.sorter.Add(value, len(s.sortLinks))
+					s.sortLinks = append(s.sortLinks, *link)
+					continue
 				}
 				is = append(is, value)
 			}
 		}
 	}
+	if nil != s.handler && len(is) > 0 { // 流式检索逐叶子节点处理，无需向上传递
+		s.emit(is)
+		is = is[:0]
+	}
 	return skip, limit, count, is
 }
 
@@ -376,18 +752,26 @@ func (s *Selector) rightQueryIndex(index *Index, ns *nodeCondition, pcs map[stri
 	)
 	lenNode := len(index.node.nodes)
 	for i := lenNode - 1; i >= 0; i-- {
+		if s.passed(index.node.nodes[i]) {
+			continue
+		}
 		if nil == ns {
 			skipIn, limitIn, nc, nis = s.rightQueryNode(skipIn, limitIn, index.node.nodes[i], nil, pcs)
-		} else {
+		} else if s.nodeConditions(index.node.nodes[i], ns.nextNode.nss) {
 			skipIn, limitIn, nc, nis = s.rightQueryNode(skipIn, limitIn, index.node.nodes[i], ns.nextNode, pcs)
+		} else {
+			continue
 		}
 		count += nc
 		is = append(is, nis...)
-		if limitIn >= s.Limit {
+		if s.full(limitIn) {
 			break
 		}
 	}
-	return count, is
+	if len(s.sortKeys) == 0 || nil != s.sorter {
+		return count, is
+	}
+	return count, s.shellSort(is)
 }
 
 // rightQueryNode 节点倒序检索
@@ -405,6 +789,9 @@ func (s *Selector) rightQueryNode(skipIn, limitIn uint32, node *node, ns *nodeCo
 	if nodes := node.nodes; nil != nodes {
 		lenNode := len(nodes)
 		for i := lenNode - 1; i >= 0; i-- {
+			if s.passed(nodes[i]) {
+				continue
+			}
 			var (
 				nc  int32
 				nis []interface{}
@@ -416,6 +803,9 @@ func (s *Selector) rightQueryNode(skipIn, limitIn uint32, node *node, ns *nodeCo
 			}
 			count += nc
 			is = append(is, nis...)
+			if s.full(limit) {
+				break
+			}
 		}
 	} else {
 		if ns == nil {
@@ -436,19 +826,22 @@ func (s *Selector) rightQueryLeaf(skip, limit uint32, leaf *node, ns *nodeCondit
 		count int32
 		is    = make([]interface{}, 0)
 	)
-	lenLink := len(leaf.links)
 	if (nil != ns && s.leafConditions(leaf, ns.nss)) || nil == ns { // 满足等于与不等于条件
-		if limit >= s.Limit {
+		if s.full(limit) {
 			return skip, limit, 0, is
 		}
-		for i := lenLink - 1; i >= 0; i-- {
-			if nil == pcs || len(pcs) == 0 {
+		links := leaf.links
+		for position := s.leafStart(links, false); position >= 0; position-- {
+			if s.full(limit) { // 本页已满，确保游标位置为本页最后一条数据
+				break
+			}
+			if (nil == pcs || len(pcs) == 0) && len(s.Groups) == 0 { // 无需过滤时直接跳过
 				if skip > 0 {
 					skip--
 					continue
 				}
 			}
-			link := leaf.links[i]
+			link := links[position]
 			value, err := storage.Obtain().Take(utils.PathFormFile(s.databaseID, s.formID), link.seekStart, link.seekLast)
 			if nil == err && s.isConditionNoIndexLeaf(ns, pcs, value) {
 				count++
@@ -457,16 +850,64 @@ func (s *Selector) rightQueryLeaf(skip, limit uint32, leaf *node, ns *nodeCondit
 					continue
 				}
 				limit++
+				s.mark(leaf, position, link)
 				if s.delete {
-					leaf.links = append(leaf.links[:i], leaf.links[i+1:]...)
+					s.rows = append(s.rows, &Row{Link: link, Value: value})
+				}
+				if nil != s.aggregator { // 聚合查询逐行累加，无需保留数据
+					s.aggregator.Add(value)
+					continue
+				}
+				if nil != s.sorter { // 全量排序时交由排序器保留排序值，数据按位置重新读取
+					s.sorter.Add(value, len(s.sortLinks))
+					s.sortLinks = append(s.sortLinks, *link)
+					continue
 				}
 				is = append(is, value)
 			}
 		}
 	}
+	if nil != s.handler && len(is) > 0 { // 流式检索逐叶子节点处理，无需向上传递
+		s.emit(is)
+		is = is[:0]
+	}
 	return skip, limit, count, is
 }
 
+// passed 节点是否已在之前的分页中遍历过，遍历至游标之后的节点时游标失效
+func (s *Selector) passed(nd *node) bool {
+	if nil == s.seek || s.seek.OnPath(nd.level, nd.degreeIndex) {
+		return false
+	}
+	if s.seek.Before(nd.level, nd.degreeIndex) {
+		return true
+	}
+	s.seek = nil
+	return false
+}
+
+// leafStart 叶子节点中开始遍历的下标，游标所在叶子节点自游标之后开始，随后游标失效
+func (s *Selector) leafStart(links []*Link, asc bool) int {
+	if nil != s.seek { // 游标未失效时遍历至的叶子节点必然位于游标路径上
+		start := s.seek.Resume(len(links), func(position int) (string, uint64) {
+			return links[position].MD516Key(), links[position].row()
+		})
+		s.seek = nil
+		return start
+	}
+	if asc {
+		return 0
+	}
+	return len(links) - 1
+}
+
+// mark 记录本页最后一条数据所在位置
+func (s *Selector) mark(leaf *node, position int, link *Link) {
+	if nil != s.next {
+		s.nextLeaf, s.next.Position, s.next.MD516Key, s.next.Row = leaf, position, link.MD516Key(), link.row()
+	}
+}
+
 // nodeConditions 判断当前条件集合是否满足
 func (s *Selector) nodeConditions(node *node, nss []*nodeSelector) bool {
 	for _, ns := range nss {
@@ -478,41 +919,54 @@ func (s *Selector) nodeConditions(node *node, nss []*nodeSelector) bool {
 }
 
 // isConditionNode 判断当前条件是否满足
+//
+// 按节点所能容纳的hashKey范围判断，范围内存在可能满足条件的hashKey即需继续向下检索
 func (s *Selector) isConditionNode(node *node, ns *nodeSelector) bool {
 	if ns != nil {
-		for _, cond := range s.Conditions {
-			if cond != ns.cond {
-				continue
-			}
-			switch cond.Cond {
-			case "gt":
-				return s.conditionGT(node, ns)
-			case "lt":
-				return s.conditionLT(node, ns)
+		lo, hi := node.scope()
+		switch ns.cond.Cond {
+		case "gt", "ge":
+			return s.conditionGT(hi, ns)
+		case "lt", "le":
+			return s.conditionLT(lo, ns)
+		case "eq", "in":
+			for _, hashKey := range ns.hashKeys {
+				if lo <= hashKey && hashKey <= hi {
+					return true
+				}
 			}
+			return false
 		}
 	}
 	return true
 }
 
-// conditionGT 条件大于判断
-func (s *Selector) conditionGT(node *node, ns *nodeSelector) bool {
-	switch ns.level {
-	default:
-		return ns.degreeIndex < node.degreeIndex
-	case 1, 2, 3, 4:
-		return ns.degreeIndex <= node.degreeIndex
+// conditionGT 条件大于及大于等于判断，hi为节点所能容纳的最大hashKey
+//
+// 字符串及数值hashKey可能对应多个原值，hashKey相同的叶子节点需包含在内并在叶子节点中比对原值
+func (s *Selector) conditionGT(hi uint64, ns *nodeSelector) bool {
+	if !s.exact(ns.cond) || ns.cond.Cond == "ge" {
+		return ns.hashKeys[0] <= hi
 	}
+	return ns.hashKeys[0] < hi
 }
 
-// conditionLT 条件小于判断
-func (s *Selector) conditionLT(node *node, ns *nodeSelector) bool {
-	switch ns.level {
-	default:
-		return ns.degreeIndex > node.degreeIndex
-	case 1, 2, 3, 4:
-		return ns.degreeIndex >= node.degreeIndex
+// conditionLT 条件小于及小于等于判断，lo为节点所能容纳的最小hashKey
+//
+// 字符串及数值hashKey可能对应多个原值，hashKey相同的叶子节点需包含在内并在叶子节点中比对原值
+func (s *Selector) conditionLT(lo uint64, ns *nodeSelector) bool {
+	if !s.exact(ns.cond) || ns.cond.Cond == "le" {
+		return ns.hashKeys[0] >= lo
 	}
+	return ns.hashKeys[0] > lo
+}
+
+// exact 条件值所得hashKey是否与原值一一对应
+//
+// 字符串hashKey仅由前缀组成，数值hashKey由float64组成，超出float64精度的整数可能得到相同hashKey，仅布尔值的比较类条件与hashKey一一对应
+func (s *Selector) exact(cond *condition) bool {
+	_, isBool := cond.Value.(bool)
+	return isBool && s.indexable(cond)
 }
 
 // leafConditions 判断当前条件集合是否满足
@@ -525,28 +979,34 @@ func (s *Selector) leafConditions(node *node, nss []*nodeSelector) bool {
 	return true
 }
 
-// conditionLeaf 判断当前条件是否满足
+// conditionLeaf 判断当前条件是否满足，叶子节点仅容纳一个hashKey
 func (s *Selector) isConditionLeaf(node *node, ns *nodeSelector) bool {
 	if ns != nil {
-		for _, cond := range s.Conditions {
-			if cond != ns.cond {
-				continue
+		hashKey, _ := node.scope()
+		switch ns.cond.Cond {
+		case "eq", "in":
+			for _, key := range ns.hashKeys {
+				if key == hashKey {
+					return true
+				}
 			}
-			switch cond.Cond {
-			case "eq":
-				return ns.level == 5 && ns.degreeIndex == node.degreeIndex
-			case "dif":
-				return ns.level == 5 && ns.degreeIndex != node.degreeIndex
+			return false
+		case "dif":
+			if !s.exact(ns.cond) { // hashKey相同的不同原值在同一叶子节点中，由叶子节点中比对原值
+				return true
 			}
+			return ns.hashKeys[0] != hashKey
 		}
 	}
 	return true
 }
 
 // isConditionNoIndexLeaf 判断当前条件是否满足
+//
+// 索引条件已在检索索引树时满足，但字符串及数值条件仅比对了hashKey，需在此比对原值
 func (s *Selector) isConditionNoIndexLeaf(ns *nodeCondition, pcs map[string]*paramCondition, value interface{}) bool {
 	for _, cond := range s.Conditions {
-		if nil != ns && cond.Param == ns.nss[0].cond.Param {
+		if nil != ns && cond.Param == ns.nss[0].cond.Param && s.exact(cond) {
 			continue
 		}
 		pc := pcs[s.pcMapName(cond)]
@@ -557,24 +1017,120 @@ func (s *Selector) isConditionNoIndexLeaf(ns *nodeCondition, pcs map[string]*par
 			return false
 		}
 	}
+	for _, g := range s.Groups {
+		if !s.matchGroup(g, value) {
+			return false
+		}
+	}
 	return true
 }
 
+// matchGroup 判断当前逻辑条件组是否满足
+func (s *Selector) matchGroup(g *group, value interface{}) bool {
+	switch g.Logic {
+	default:
+		return s.matchAll(g, value)
+	case logicOr:
+		for _, cond := range g.Conditions {
+			if s.matchCondition(cond, value) {
+				return true
+			}
+		}
+		for _, sub := range g.Groups {
+			if s.matchGroup(sub, value) {
+				return true
+			}
+		}
+		return false
+	case logicNot:
+		return !s.matchAll(g, value)
+	}
+}
+
+// matchAll 判断逻辑条件组中的条件及子条件组是否全部满足
+func (s *Selector) matchAll(g *group, value interface{}) bool {
+	for _, cond := range g.Conditions {
+		if !s.matchCondition(cond, value) {
+			return false
+		}
+	}
+	for _, sub := range g.Groups {
+		if !s.matchGroup(sub, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchCondition 判断逻辑条件组中的单个条件是否满足，条件值类型不支持时视为不满足
+func (s *Selector) matchCondition(cond *condition, value interface{}) bool {
+	paramType, paramValue, support := s.formatParam(cond.Value)
+	if !support {
+		return false
+	}
+	return s.conditionValue(cond.Cond, strings.Split(cond.Param, "."), paramType, paramValue, value)
+}
+
 // conditionValue 判断当前条件是否满足
+//
+// exists判断字段是否存在，值为空亦视为存在；null判断字段是否不存在或值为空；其余条件在字段不存在或值为空时均不满足
 func (s *Selector) conditionValue(cond string, params []string, paramType paramType, paramValue, objValue interface{}) bool {
-	var value interface{}
-	if value = s.valueFromParams(params, objValue); nil == value {
+	value, exist := utils.ValueByParams(objValue, params)
+	switch cond {
+	case "exists":
+		return paramType == paramBool && exist == paramValue.(bool)
+	case "null":
+		return paramType == paramBool && (nil == value) == paramValue.(bool)
+	}
+	if nil == value {
 		return false
 	}
+	switch cond {
+	case "in", "nin":
+		if paramType != paramList {
+			return false
+		}
+		in := false
+		for _, pc := range paramValue.([]*paramCondition) {
+			if s.compareValue("eq", pc.paramType, pc.paramValue, value) {
+				in = true
+				break
+			}
+		}
+		return in == (cond == "in")
+	case "between":
+		if paramType != paramList {
+			return false
+		}
+		pcs := paramValue.([]*paramCondition)
+		return len(pcs) == 2 && s.compareValue("ge", pcs[0].paramType, pcs[0].paramValue, value) &&
+			s.compareValue("le", pcs[1].paramType, pcs[1].paramValue, value)
+	case "prefix", "contains", "regex":
+		valueString, isString := value.(string)
+		if !isString || paramType != paramString {
+			return false
+		}
+		switch cond {
+		case "prefix":
+			return strings.HasPrefix(valueString, paramValue.(string))
+		case "contains":
+			return strings.Contains(valueString, paramValue.(string))
+		}
+		expr := s.compileRegexp(paramValue.(string))
+		return nil != expr && expr.MatchString(valueString)
+	}
+	return s.compareValue(cond, paramType, paramValue, value)
+}
+
+// compareValue 判断字段值是否满足比较类条件 gt/lt/ge/le/eq/dif
+func (s *Selector) compareValue(cond string, paramType paramType, paramValue, value interface{}) bool {
+	reflectValue := reflect.ValueOf(value)
+	if number, ok := utils.ValueNumber(&reflectValue); ok {
+		return conditionValueNumber(cond, paramType, paramValue, number)
+	}
 	switch value := value.(type) {
 	default:
 		return false
-	case int, int8, int16, int32, int64:
-		return conditionValueInt64(cond, paramType, paramValue, value)
-	case uint8, uint16, uint32, uint, uint64, uintptr:
-		return conditionValueUint64(cond, paramType, paramValue, value)
-	case float32, float64:
-		return conditionValueFloat64(cond, paramType, paramValue, value)
 	case string:
 		return conditionValueString(cond, paramType, paramValue, value)
 	case bool:
@@ -585,69 +1141,29 @@ func (s *Selector) conditionValue(cond string, params []string, paramType paramT
 	}
 }
 
-// getValueFromParams 根据索引描述获取当前value
-func (s *Selector) valueFromParams(params []string, value interface{}) interface{} {
-	reflectObj := reflect.ValueOf(value) // 反射对象，通过reflectObj获取存储在里面的值，还可以去改变值
-	if reflectObj.Kind() == reflect.Map {
-		interMap := value.(map[string]interface{})
-		lenParams := len(params)
-		var valueResult interface{}
-		for i, param := range params {
-			if i == lenParams-1 {
-				valueResult = interMap[param]
-				break
-			}
-			interMap = interMap[param].(map[string]interface{})
-		}
-		return valueResult
-	}
-	log.Debug("getValueFromParams", log.Field("kind", reflectObj.Kind()), log.Field("support", false))
-	return nil
-}
-
-// shellSort 希尔排序
-func (s *Selector) shellSort(is []interface{}) []interface{} {
-	log.Debug("shellSort 希尔排序", log.Field("s.Sort", s.Sort))
-	if s.Sort.ASC {
-		log.Debug("shellAsc 希尔顺序排序")
-		return s.shellAsc(is)
+// compileRegexp 获取已编译的正则表达式，同一检索中相同表达式仅编译一次，表达式无效时返回nil
+func (s *Selector) compileRegexp(expr string) *regexp.Regexp {
+	if nil == s.regexps {
+		s.regexps = map[string]*regexp.Regexp{}
 	}
-	log.Debug("shellDesc 希尔倒序排序")
-	return s.shellDesc(is)
-}
-
-// shellAsc 希尔顺序排序
-func (s *Selector) shellAsc(is []interface{}) []interface{} {
-	length := len(is)
-	gap := length / 2
-	params := strings.Split(s.Sort.Param, ".")
-	for gap > 0 {
-		for i := gap; i < length; i++ {
-			tempI := is[i]
-			temp := s.hashKeyFromValue(params, is[i])
-			preIndex := i - gap
-			for preIndex >= 0 && s.hashKeyFromValue(params, is[preIndex]) > temp {
-				is[preIndex+gap] = is[preIndex]
-				preIndex -= gap
-			}
-			is[preIndex+gap] = tempI
-		}
-		gap /= 2
+	compiled, exist := s.regexps[expr]
+	if !exist {
+		compiled, _ = regexp.Compile(expr)
+		s.regexps[expr] = compiled
 	}
-	return is
+	return compiled
 }
 
-// shellDesc 希尔倒序排序
-func (s *Selector) shellDesc(is []interface{}) []interface{} {
+// shellSort 希尔排序，按多字段排序方式依次比较各字段值
+func (s *Selector) shellSort(is []interface{}) []interface{} {
+	log.Debug("shellSort 希尔排序", log.Field("s.Sorts", s.Sorts))
 	length := len(is)
 	gap := length / 2
-	params := strings.Split(s.Sort.Param, ".")
 	for gap > 0 {
 		for i := gap; i < length; i++ {
 			tempI := is[i]
-			temp := s.hashKeyFromValue(params, is[i])
 			preIndex := i - gap
-			for preIndex >= 0 && s.hashKeyFromValue(params, is[preIndex]) < temp {
+			for preIndex >= 0 && s.sortKeys.Compare(is[preIndex], tempI) > 0 {
 				is[preIndex+gap] = is[preIndex]
 				preIndex -= gap
 			}
@@ -657,34 +1173,3 @@ func (s *Selector) shellDesc(is []interface{}) []interface{} {
 	}
 	return is
 }
-
-// hashKeyFromValue 通过Param获取该参数所属hashKey
-func (s *Selector) hashKeyFromValue(params []string, value interface{}) uint64 {
-	hashKey, support := s.getInterValue(params, value)
-	if !support {
-		return 0
-	}
-	return hashKey
-}
-
-// getInterValue 根据索引描述和当前检索到的value对象获取当前value对象所在索引的hashKey
-func (s *Selector) getInterValue(params []string, value interface{}) (hashKey uint64, support bool) {
-	reflectObj := reflect.ValueOf(value) // 反射对象，通过reflectObj获取存储在里面的值，还可以去改变值
-	if reflectObj.Kind() == reflect.Map {
-		interMap := value.(map[string]interface{})
-		lenParams := len(params)
-		var valueResult interface{}
-		for i, param := range params {
-			if i == lenParams-1 {
-				valueResult = interMap[param]
-				break
-			}
-			interMap = interMap[param].(map[string]interface{})
-		}
-		//log.Debug("getInterValue", log.Field("valueResult", valueResult))
-		checkValue := reflect.ValueOf(valueResult)
-		return utils.Value2hashKey(&checkValue)
-	}
-	log.Debug("getInterValue", log.Field("kind", reflectObj.Kind()), log.Field("support", false))
-	return 0, false
-}
@@ -24,7 +24,35 @@
 
 package siam
 
-import "testing"
+import (
+	"fmt"
+	"github.com/aberic/lilydb/config"
+	api "github.com/aberic/lilydb/connector/grpc"
+	"github.com/aberic/lilydb/engine/comm"
+	"github.com/aberic/lilydb/engine/siam/utils"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// TestMain 将数据目录指向临时目录，测试结束后删除
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "lilydb-siam")
+	if nil != err {
+		panic(err)
+	}
+	conf := config.Obtain()
+	conf.RootDir = dir
+	conf.DataDir = filepath.Join(dir, "data")
+	conf.LilyLockFilePath = filepath.Join(dir, "lily.lock")
+	conf.LilyBootstrapFilePath = filepath.Join(conf.DataDir, "lily.sync")
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
 
 func form() *Form {
 	return NewForm("databaseID", "formID", "formName", "comment")
@@ -55,7 +83,7 @@ func TestForm_FormType(t *testing.T) {
 }
 
 func TestForm_NewIndex(t *testing.T) {
-	form().NewIndex("id", true)
+	form().NewIndex("id", true, true)
 }
 
 type Value struct {
@@ -96,3 +124,691 @@ func TestForm_Select(t *testing.T) {
 	}
 	t.Log(fm.Select([]byte(selectorJSONString)))
 }
+
+func TestForm_Recover(t *testing.T) {
+	fm := NewForm("databaseID", "formRecoverID", "formRecover", "comment")
+	defer func() { _ = fm.Drop() }()
+	for i := 0; i < 3; i++ {
+		if _, err := fm.Insert(&Value{Name: "name", Age: i}); nil != err {
+			t.Error(err)
+		}
+	}
+	fmRecover := RecoverForm("databaseID", &api.Form{ID: fm.ID(), Name: fm.Name(), Comment: fm.Comment(), Indexes: fm.Indexes()})
+	t.Log(fmRecover.Recover())
+	if *fmRecover.AutoID() < *fm.AutoID() {
+		t.Error("auto id recover failed")
+	}
+	t.Log(*fmRecover.AutoID())
+}
+
+func TestForm_Delete(t *testing.T) {
+	fm := NewForm("databaseID", "formDeleteID", "formDelete", "comment")
+	defer func() { _ = fm.Drop() }()
+	fm.NewIndex("Name", false, true)
+	for i := 0; i < 5; i++ {
+		if _, err := fm.Insert(&Value{Name: strconv.Itoa(i), Age: i}); nil != err {
+			t.Error(err)
+		}
+	}
+	t.Log(fm.Delete([]byte(`{"Conditions":[{"Param":"Age","Cond":"gt","Value":2}]}`)))
+	t.Log(fm.Del("1"))
+	fmRecover := RecoverForm("databaseID", &api.Form{ID: fm.ID(), Name: fm.Name(), Comment: fm.Comment(), Indexes: fm.Indexes()})
+	t.Log(fmRecover.Recover())
+	count, values, err := fmRecover.Select([]byte(`{"Conditions":[{"Param":"Age","Cond":"gt","Value":-1}]}`))
+	if nil != err {
+		t.Error(err)
+	}
+	if count != 2 {
+		t.Error("delete recover failed", count, values)
+	}
+	t.Log(count, values)
+}
+
+func TestForm_Compact(t *testing.T) {
+	fm := NewForm("databaseID", "formCompactID", "formCompact", "comment")
+	defer func() { _ = fm.Drop() }()
+	fm.NewIndex("Name", false, true)
+	for i := 0; i < 5; i++ {
+		if _, err := fm.Insert(&Value{Name: strconv.Itoa(i), Age: i}); nil != err {
+			t.Error(err)
+		}
+	}
+	t.Log(fm.Del("1"))
+	t.Log(fm.Del("2"))
+	if err := fm.Compact(); nil != err {
+		t.Fatal(err)
+	}
+	count, values, err := fm.Select([]byte(`{"Conditions":[{"Param":"Age","Cond":"gt","Value":-1}]}`))
+	if nil != err || count != 3 {
+		t.Error("compact failed", count, values, err)
+	}
+	if _, err = fm.Insert(&Value{Name: "5", Age: 5}); nil != err {
+		t.Error(err)
+	}
+	fmRecover := RecoverForm("databaseID", &api.Form{ID: fm.ID(), Name: fm.Name(), Comment: fm.Comment(), Indexes: fm.Indexes()})
+	t.Log(fmRecover.Recover())
+	count, values, err = fmRecover.Select([]byte(`{"Conditions":[{"Param":"Age","Cond":"gt","Value":-1}]}`))
+	if nil != err || count != 4 {
+		t.Error("compact recover failed", count, values, err)
+	}
+	t.Log(count, values)
+}
+
+func TestForm_CompactUpdate(t *testing.T) {
+	fm := NewForm("databaseID", "formCompactUpdateID", "formCompactUpdate", "comment")
+	defer func() { _ = fm.Drop() }()
+	fm.NewIndex("Name", false, true)
+	fm.NewIndex("Age", false, false)
+	for i := 0; i < 5; i++ {
+		if _, err := fm.Update(&Value{Name: "lily", Age: i}); nil != err {
+			t.Fatal(err)
+		}
+	}
+	count, values, err := fm.Select([]byte(`{"Conditions":[{"Param":"Age","Cond":"gt","Value":-1}]}`))
+	if nil != err || count != 1 {
+		t.Error("update select failed", count, values, err)
+	}
+	before, err := os.Stat(utils.PathFormFile("databaseID", "formCompactUpdateID"))
+	if nil != err {
+		t.Fatal(err)
+	}
+	if err = fm.Compact(); nil != err {
+		t.Fatal(err)
+	}
+	after, err := os.Stat(utils.PathFormFile("databaseID", "formCompactUpdateID"))
+	if nil != err {
+		t.Fatal(err)
+	}
+	if after.Size()*5 != before.Size() {
+		t.Error("compact update failed", before.Size(), after.Size())
+	}
+	count, values, err = fm.Select([]byte(`{"Conditions":[{"Param":"Age","Cond":"gt","Value":-1}]}`))
+	if nil != err || count != 1 || fmt.Sprint(values[0].(map[string]interface{})["Age"]) != "4" {
+		t.Error("compact update select failed", count, values, err)
+	}
+	t.Log(before.Size(), after.Size(), values)
+}
+
+func TestForm_Verify(t *testing.T) {
+	fm := NewForm("databaseID", "formVerifyID", "formVerify", "comment")
+	defer func() { _ = fm.Drop() }()
+	for i := 0; i < 3; i++ {
+		if _, err := fm.Insert(&Value{Name: strconv.Itoa(i), Age: i}); nil != err {
+			t.Error(err)
+		}
+	}
+	corrupts, err := fm.Verify()
+	if nil != err || len(corrupts) != 0 {
+		t.Error("verify failed", corrupts, err)
+	}
+	var seekStart int64 = -1 // 本测试写入的最后一条数据
+	for rowSeekStart := range fm.rows {
+		if rowSeekStart > seekStart {
+			seekStart = rowSeekStart
+		}
+	}
+	file, err := os.OpenFile(utils.PathFormFile("databaseID", "formVerifyID"), os.O_RDWR, 0644)
+	if nil != err {
+		t.Fatal(err)
+	}
+	b := make([]byte, 1)
+	_, _ = file.ReadAt(b, seekStart+12)
+	_, _ = file.WriteAt([]byte{^b[0]}, seekStart+12) // 破坏该条数据内容
+	_ = file.Close()
+	corrupts, err = fm.Verify()
+	if nil != err || len(corrupts) != 1 || corrupts[0] != seekStart {
+		t.Error("verify corrupt failed", corrupts, err)
+	}
+	t.Log(corrupts)
+}
+
+func TestForm_Check(t *testing.T) {
+	fm := NewForm("databaseID", "formCheckID", "formCheck", "comment")
+	defer func() { _ = fm.Drop() }()
+	fm.NewIndex("Name", false, true)
+	for i := 0; i < 3; i++ {
+		if _, err := fm.Insert(&Value{Name: strconv.Itoa(i), Age: i}); nil != err {
+			t.Error(err)
+		}
+	}
+	check, err := fm.Check(false)
+	if nil != err || !check.Healthy() || check.Rows != 3 {
+		t.Fatal("check failed", check, err)
+	}
+	var nameIndexID string
+	for indexID, ic := range check.Indexes {
+		if ic.KeyStructure == "Name" {
+			nameIndexID = indexID
+		}
+	}
+	file, err := os.OpenFile(utils.PathFormIndexFile("databaseID", "formCheckID", nameIndexID), os.O_WRONLY|os.O_TRUNC, 0644)
+	if nil != err {
+		t.Fatal(err)
+	}
+	_, _ = file.WriteString("broken") // 索引文件仅剩不完整的记录
+	_ = file.Close()
+	if check, err = fm.Check(false); nil != err || check.Healthy() || check.Indexes[nameIndexID].Tail != 6 || check.Indexes[nameIndexID].Missing != 3 {
+		t.Fatal("check broken failed", check.Indexes[nameIndexID], err)
+	}
+	if check, err = fm.Check(true); nil != err || !check.Healthy() || !check.Indexes[nameIndexID].Rebuilt || check.Indexes[nameIndexID].Records != 3 {
+		t.Fatal("check repair failed", check.Indexes[nameIndexID], err)
+	}
+	t.Log(check)
+}
+
+func TestForm_Drop(t *testing.T) {
+	fm := NewForm("databaseID", "formDropID", "formDrop", "comment")
+	fm.NewIndex("Name", false, true)
+	for i := 0; i < 3; i++ {
+		if _, err := fm.Insert(&Value{Name: strconv.Itoa(i), Age: i}); nil != err {
+			t.Error(err)
+		}
+	}
+	if err := fm.DropIndex(indexAutoID); err != comm.ErrIndexProtected {
+		t.Error("drop auto index should be protected", err)
+	}
+	if err := fm.DropIndex("Age"); err != comm.ErrIndexNotFound {
+		t.Error("drop not exist index should fail", err)
+	}
+	var nameIndexID string
+	for indexID, idx := range fm.Indexes() {
+		if idx.KeyStructure == "Name" {
+			nameIndexID = indexID
+		}
+	}
+	if err := fm.DropIndex("Name"); nil != err {
+		t.Error(err)
+	}
+	if _, err := os.Stat(utils.PathFormIndexFile("databaseID", "formDropID", nameIndexID)); !os.IsNotExist(err) {
+		t.Error("index file should be removed", err)
+	}
+	if len(fm.Indexes()) != 1 {
+		t.Error("indexes should only remain auto index", fm.Indexes())
+	}
+	if err := fm.Drop(); nil != err {
+		t.Error(err)
+	}
+	if _, err := os.Stat(utils.PathFormFile("databaseID", "formDropID")); !os.IsNotExist(err) {
+		t.Error("form file should be removed", err)
+	}
+}
+
+func TestForm_CreateIndex(t *testing.T) {
+	fm := NewForm("databaseID", "formCreateIndexID", "formCreateIndex", "comment")
+	defer func() { _ = fm.Drop() }()
+	for i := 0; i < 5; i++ {
+		if _, err := fm.Insert(&Value{Name: strconv.Itoa(i), Age: i}); nil != err {
+			t.Error(err)
+		}
+	}
+	t.Log(fm.Del("2"))
+	built := make(chan error, 1)
+	if err := fm.CreateIndex("Name", false, true, func(err error) { built <- err }); nil != err {
+		t.Fatal(err)
+	}
+	if err := fm.CreateIndex("Name", false, true, nil); err != comm.ErrIndexExist {
+		t.Error("create exist index should fail", err)
+	}
+	if _, err := fm.Insert(&Value{Name: "5", Age: 5}); nil != err {
+		t.Error(err)
+	}
+	if err := <-built; nil != err {
+		t.Fatal(err)
+	}
+	for _, idx := range fm.Indexes() {
+		if idx.KeyStructure == "Name" && (idx.Building || idx.Done != idx.Total) {
+			t.Error("index should be built", idx)
+		}
+	}
+	check, err := fm.Check(false)
+	if nil != err || !check.Healthy() || check.Rows != 5 {
+		t.Fatal("backfill check failed", check, err)
+	}
+	for _, ic := range check.Indexes {
+		if ic.Records != 5 {
+			t.Error("backfill records not match", ic)
+		}
+	}
+}
+
+func TestForm_CreateIndexRejected(t *testing.T) {
+	fm := NewForm("databaseID", "formCreateRejectedID", "formCreateRejected", "comment")
+	defer func() { _ = fm.Drop() }()
+	for _, value := range []interface{}{&Value{Name: "a", Age: 1}, &Value{Name: "a", Age: 2}, map[string]interface{}{"Age": 3}} {
+		if _, err := fm.Insert(value); nil != err {
+			t.Fatal(err)
+		}
+	}
+	built := make(chan error, 1)
+	if err := fm.CreateIndex("Name", false, true, func(err error) { built <- err }); nil != err {
+		t.Fatal(err)
+	}
+	if err := <-built; err != comm.ErrIndexDuplicate {
+		t.Error("backfill duplicate unique key should fail", err)
+	}
+	if err := fm.CreateIndex("Name", false, false, func(err error) { built <- err }); nil != err {
+		t.Fatal(err)
+	}
+	if err := <-built; nil == err {
+		t.Error("backfill without index field should fail")
+	}
+	for _, idx := range fm.Indexes() {
+		if idx.KeyStructure == "Name" {
+			t.Error("failed index should be dropped", idx)
+		}
+	}
+}
+
+func TestForm_NonUniqueIndex(t *testing.T) {
+	fm := NewForm("databaseID", "formNonUniqueID", "formNonUnique", "comment")
+	defer func() { _ = fm.Drop() }()
+	fm.NewIndex("Name", false, false)
+	for i, name := range []string{"a", "a", "b", "a"} {
+		if _, err := fm.Insert(&Value{Name: name, Age: i}); nil != err {
+			t.Error(err)
+		}
+	}
+	selector := []byte(`{"Conditions":[{"Param":"Name","Cond":"eq","Value":"a"}]}`)
+	count, values, err := fm.Select(selector)
+	if nil != err || count != 3 {
+		t.Error("non unique select failed", count, values, err)
+	}
+	if count, err = fm.Delete([]byte(`{"Conditions":[{"Param":"Age","Cond":"gt","Value":2}]}`)); nil != err || count != 1 {
+		t.Error("non unique delete failed", count, err)
+	}
+	fmRecover := RecoverForm("databaseID", &api.Form{ID: fm.ID(), Name: fm.Name(), Comment: fm.Comment(), Indexes: fm.Indexes()})
+	t.Log(fmRecover.Recover())
+	count, values, err = fmRecover.Select(selector)
+	if nil != err || count != 2 {
+		t.Error("non unique recover failed", count, values, err)
+	}
+	t.Log(count, values)
+}
+
+func TestForm_InsertRejected(t *testing.T) {
+	fm := NewForm("databaseID", "formRejectedID", "formRejected", "comment")
+	defer func() { _ = fm.Drop() }()
+	fm.NewIndex("Name", false, true)
+	fm.NewIndex("Age", false, false)
+	if _, err := fm.Insert(&Value{Name: "a", Age: 1}); nil != err {
+		t.Fatal(err)
+	}
+	if _, err := fm.Insert(&Value{Name: "a", Age: 2}); nil == err { // 唯一索引重复
+		t.Error("insert duplicate unique key should fail")
+	}
+	if _, err := fm.Insert(map[string]interface{}{"Age": 3}); nil == err { // 缺少索引字段
+		t.Error("insert without index field should fail")
+	}
+	for _, idx := range fm.indexes {
+		if idx.Rows() != 1 {
+			t.Error("rejected insert left links", idx.KeyStructure(), idx.Rows())
+		}
+	}
+	if autoID, err := fm.Insert(&Value{Name: "b", Age: 4}); nil != err || autoID != 2 {
+		t.Error("rejected insert consumed auto id", autoID, err)
+	}
+	count, values, err := fm.Select([]byte(`{"Conditions":[{"Param":"Age","Cond":"ge","Value":0}]}`))
+	if nil != err || count != 2 {
+		t.Error("rejected insert select failed", count, values, err)
+	}
+}
+
+func TestForm_CompositeIndex(t *testing.T) {
+	fm := NewForm("databaseID", "formCompositeID", "formComposite", "comment")
+	defer func() { _ = fm.Drop() }()
+	fm.NewIndex(utils.CompositeKeyStructure("Name", "Age"), false, true)
+	for _, value := range []*Value{{Name: "a", Age: 1}, {Name: "a", Age: 2}, {Name: "b", Age: 1}, {Name: "a", Age: 3}} {
+		if _, err := fm.Insert(value); nil != err {
+			t.Error(err)
+		}
+	}
+	if _, err := fm.Insert(&Value{Name: "a", Age: 2}); nil == err {
+		t.Error("composite unique index accepted a repeated key")
+	}
+	selector := []byte(`{"Conditions":[{"Param":"Name","Cond":"eq","Value":"a"},{"Param":"Age","Cond":"gt","Value":1}]}`)
+	count, values, err := fm.Select(selector)
+	if nil != err || count != 2 {
+		t.Error("composite select failed", count, values, err)
+	}
+	fmRecover := RecoverForm("databaseID", &api.Form{ID: fm.ID(), Name: fm.Name(), Comment: fm.Comment(), Indexes: fm.Indexes()})
+	t.Log(fmRecover.Recover())
+	count, values, err = fmRecover.Select(selector)
+	if nil != err || count != 2 {
+		t.Error("composite recover failed", count, values, err)
+	}
+	t.Log(count, values)
+}
+
+func TestForm_StringRange(t *testing.T) {
+	fm := NewForm("databaseID", "formStringRangeID", "formStringRange", "comment")
+	defer func() { _ = fm.Drop() }()
+	fm.NewIndex("Name", false, true)
+	for i, name := range []string{"category-c", "category-a", "category-b", "book"} {
+		if _, err := fm.Insert(&Value{Name: name, Age: i}); nil != err {
+			t.Error(err)
+		}
+	}
+	count, values, err := fm.Select([]byte(`{"Conditions":[{"Param":"Name","Cond":"gt","Value":"category-a"}],"Sort":{"Param":"Name","Asc":true}}`))
+	if nil != err || count != 2 {
+		t.Error("string range select failed", count, values, err)
+	}
+	for i, name := range []string{"category-b", "category-c"} {
+		if i < len(values) && values[i].(map[string]interface{})["Name"] != name {
+			t.Error("string range sort failed", values)
+		}
+	}
+	if count, values, err = fm.Select([]byte(`{"Conditions":[{"Param":"Name","Cond":"dif","Value":"category-b"}]}`)); nil != err || count != 3 {
+		t.Error("string dif select failed", count, values, err)
+	}
+}
+
+func TestForm_NumberIndex(t *testing.T) {
+	fm := NewForm("databaseID", "formNumberID", "formNumber", "comment")
+	defer func() { _ = fm.Drop() }()
+	fm.NewIndex("Score", false, true)
+	fm.NewIndex("Total", false, true)
+	for _, value := range []map[string]interface{}{
+		{"Score": 0.123451, "Total": uint64(1 << 63)},
+		{"Score": 0.123456, "Total": uint64(1<<64 - 1)},
+		{"Score": -2.5, "Total": uint64(1)},
+	} {
+		if _, err := fm.Insert(value); nil != err {
+			t.Error(err)
+		}
+	}
+	count, values, err := fm.Select([]byte(`{"Conditions":[{"Param":"Score","Cond":"gt","Value":0.123452}]}`))
+	if nil != err || count != 1 {
+		t.Error("float select failed", count, values, err)
+	}
+	count, values, err = fm.Select([]byte(`{"Conditions":[{"Param":"Total","Cond":"gt","Value":9223372036854775808}]}`))
+	if nil != err || count != 1 {
+		t.Error("uint64 select failed", count, values, err)
+	}
+}
+
+func TestForm_SelectGroups(t *testing.T) {
+	fm := NewForm("databaseID", "formGroupsID", "formGroups", "comment")
+	defer func() { _ = fm.Drop() }()
+	fm.NewIndex("Name", false, false)
+	for i, name := range []string{"open", "pending", "closed", "open", "pending"} {
+		if _, err := fm.Insert(&Value{Name: name, Age: i}); nil != err {
+			t.Error(err)
+		}
+	}
+	count, values, err := fm.Select([]byte(`{"Groups":[
+		{"Logic":"or","Conditions":[{"Param":"Name","Cond":"eq","Value":"open"},{"Param":"Name","Cond":"eq","Value":"pending"}]},
+		{"Logic":"not","Conditions":[{"Param":"Age","Cond":"eq","Value":3}]}]}`))
+	if nil != err || count != 3 {
+		t.Error("groups select failed", count, values, err)
+	}
+	count, values, err = fm.Select([]byte(`{"Groups":[{"Conditions":[{"Param":"Name","Cond":"eq","Value":"pending"}],
+		"Groups":[{"Logic":"or","Conditions":[{"Param":"Age","Cond":"lt","Value":2},{"Param":"Age","Cond":"gt","Value":3}]}]}]}`))
+	if nil != err || count != 2 {
+		t.Error("and group select failed", count, values, err)
+	}
+}
+
+func TestForm_SelectOperators(t *testing.T) {
+	fm := NewForm("databaseID", "formOperatorsID", "formOperators", "comment")
+	defer func() { _ = fm.Drop() }()
+	fm.NewIndex("Name", false, true)
+	fm.NewIndex("Age", false, false)
+	for i, name := range []string{"apple", "apricot", "banana", "blueberry", "cherry"} {
+		if _, err := fm.Insert(map[string]interface{}{"Name": name, "Age": i, "Owner": map[string]interface{}{"ID": i % 2}}); nil != err {
+			t.Error(err)
+		}
+	}
+	if _, err := fm.Insert(map[string]interface{}{"Name": "date", "Age": 5, "Owner": nil}); nil != err {
+		t.Error(err)
+	}
+	for selector, want := range map[string]int32{
+		`{"Conditions":[{"Param":"Age","Cond":"ge","Value":2}]}`:                          4,
+		`{"Conditions":[{"Param":"Age","Cond":"le","Value":2}]}`:                          3,
+		`{"Conditions":[{"Param":"Age","Cond":"between","Value":[1,3]}]}`:                 3,
+		`{"Conditions":[{"Param":"Name","Cond":"in","Value":["banana","cherry","fig"]}]}`: 2,
+		`{"Conditions":[{"Param":"Age","Cond":"in","Value":[0,4]}]}`:                      2,
+		`{"Conditions":[{"Param":"Name","Cond":"nin","Value":["banana","cherry"]}]}`:      4,
+		`{"Conditions":[{"Param":"Name","Cond":"prefix","Value":"ap"}]}`:                  2,
+		`{"Conditions":[{"Param":"Name","Cond":"contains","Value":"err"}]}`:               2,
+		`{"Conditions":[{"Param":"Name","Cond":"regex","Value":"^b.*a$"}]}`:               1,
+		`{"Conditions":[{"Param":"Owner.ID","Cond":"exists","Value":true}]}`:              5,
+		`{"Conditions":[{"Param":"Owner","Cond":"exists","Value":true}]}`:                 6,
+		`{"Conditions":[{"Param":"Owner","Cond":"null","Value":true}]}`:                   1,
+		`{"Conditions":[{"Param":"Owner.ID","Cond":"null","Value":false}]}`:               5,
+	} {
+		count, values, err := fm.Select([]byte(selector))
+		if nil != err || count != want {
+			t.Error("operator select failed", selector, count, values, err)
+		}
+	}
+}
+
+func TestForm_SelectFields(t *testing.T) {
+	fm := NewForm("databaseID", "formFieldsID", "formFields", "comment")
+	defer func() { _ = fm.Drop() }()
+	for i := 0; i < 3; i++ {
+		if _, err := fm.Insert(map[string]interface{}{"Name": strconv.Itoa(i), "Age": i, "Owner": map[string]interface{}{"ID": i, "Email": "mail"}}); nil != err {
+			t.Error(err)
+		}
+	}
+	count, values, err := fm.Select([]byte(`{"Conditions":[{"Param":"Owner.ID","Cond":"ge","Value":1}],"Fields":["Name","Owner.ID"]}`))
+	if nil != err || count != 2 {
+		t.Error("fields select failed", count, values, err)
+	}
+	for _, value := range values {
+		value := value.(map[string]interface{})
+		if _, exist := value["Age"]; exist || len(value["Owner"].(map[string]interface{})) != 1 {
+			t.Error("fields include failed", value)
+		}
+	}
+	if _, values, err = fm.Select([]byte(`{"Fields":["-Owner.Email"]}`)); nil != err || len(values) != 3 {
+		t.Error("fields exclude select failed", values, err)
+	}
+	for _, value := range values {
+		value := value.(map[string]interface{})
+		if _, exist := value["Age"]; !exist || len(value["Owner"].(map[string]interface{})) != 1 {
+			t.Error("fields exclude failed", value)
+		}
+	}
+}
+
+func TestForm_SelectAggregate(t *testing.T) {
+	fm := NewForm("databaseID", "formAggregateID", "formAggregate", "comment")
+	defer func() { _ = fm.Drop() }()
+	for i := 0; i < 6; i++ {
+		if _, err := fm.Insert(map[string]interface{}{"City": []string{"a", "b"}[i%2], "Age": i, "Score": float64(i) / 2}); nil != err {
+			t.Error(err)
+		}
+	}
+	count, values, err := fm.Select([]byte(`{"Conditions":[{"Param":"Age","Cond":"ge","Value":1}],"Limit":1,"Sort":{"Param":"City","Asc":true},
+"Aggregate":{"GroupBy":["City"],"Accumulators":[{"Op":"count"},{"Name":"total","Op":"sum","Param":"Age"},{"Op":"avg","Param":"Score"},{"Op":"max","Param":"Age"},{"Op":"distinct","Param":"City"}]}}`))
+	if nil != err || count != 2 || len(values) != 1 {
+		t.Fatal("aggregate select failed", count, values, err)
+	}
+	value := values[0].(map[string]interface{})
+	if value["City"] != "a" || value["count"] != int64(2) || value["total"] != int64(6) || value["avg_Score"] != 1.5 || value["distinct_City"] != int64(1) {
+		t.Error("aggregate result failed", value)
+	}
+	if compare, ok := utils.CompareValue(value["max_Age"], 4); !ok || compare != 0 { // msgpack解码后的整数类型不定
+		t.Error("aggregate max failed", value)
+	}
+	if _, _, err = fm.Select([]byte(`{"Aggregate":{"Accumulators":[{"Op":"median","Param":"Age"}]}}`)); nil == err {
+		t.Error("aggregate op should not be supported")
+	}
+}
+
+func TestForm_SelectSorts(t *testing.T) {
+	fm := NewForm("databaseID", "formSortsID", "formSorts", "comment")
+	defer func() { _ = fm.Drop() }()
+	for i := 0; i < 9; i++ {
+		value := map[string]interface{}{"City": []string{"a", "b", "c"}[i%3], "Age": i}
+		if i == 4 {
+			delete(value, "City")
+		}
+		if _, err := fm.Insert(value); nil != err {
+			t.Error(err)
+		}
+	}
+	buffer := config.Obtain().SortBuffer
+	config.Obtain().SortBuffer = 2 // 每2条写入一个临时文件
+	defer func() { config.Obtain().SortBuffer = buffer }()
+	count, values, err := fm.Select([]byte(`{"Sorts":[{"Param":"City","Asc":false,"Nulls":"last"},{"Param":"Age","Asc":false}],"Skip":1,"Limit":5}`))
+	if nil != err || count != 9 || len(values) != 5 {
+		t.Fatal("sorts select failed", count, values, err)
+	}
+	for position, age := range []int{5, 2, 7, 1, 6} { // c组8、5、2，b组7、1，a组6、3、0，无City的4排在最后
+		if compare, ok := utils.CompareValue(values[position].(map[string]interface{})["Age"], age); !ok || compare != 0 {
+			t.Error("sorts order failed", position, values)
+		}
+	}
+	_, rows, err := fm.Select([]byte(`{"Conditions":[{"Param":"Age","Cond":"eq","Value":5}]}`))
+	if nil != err || len(rows) != 1 || !reflect.DeepEqual(rows[0], values[0]) { // 排序结果与普通检索结果的数据类型一致
+		t.Error("sorts value type failed", rows, values[0], err)
+	}
+}
+
+func TestForm_Stream(t *testing.T) {
+	fm := NewForm("databaseID", "formStreamID", "formStream", "comment")
+	defer func() { _ = fm.Drop() }()
+	for i := 0; i < 1200; i++ {
+		if _, err := fm.Insert(map[string]interface{}{"Name": strconv.Itoa(i), "Age": i % 7}); nil != err {
+			t.Error(err)
+		}
+	}
+	var rows int
+	count, err := fm.Stream([]byte(`{"Conditions":[{"Param":"Age","Cond":"ge","Value":0}]}`), func(value interface{}) bool {
+		rows++
+		return true
+	})
+	if nil != err || rows != 1200 || count != 1200 { // Limit为0时不限制数量
+		t.Fatal("stream failed", count, rows, err)
+	}
+	var ages []interface{}
+	_, err = fm.Stream([]byte(`{"Sort":{"Param":"Age","Asc":false},"Fields":["Age"]}`), func(value interface{}) bool {
+		ages = append(ages, value.(map[string]interface{})["Age"])
+		return len(ages) < 3
+	})
+	if nil != err || len(ages) != 3 {
+		t.Fatal("stream stop failed", ages, err)
+	}
+	for _, age := range ages {
+		if compare, ok := utils.CompareValue(age, 6); !ok || compare != 0 {
+			t.Error("stream sort failed", ages)
+		}
+	}
+}
+
+func TestForm_SelectPage(t *testing.T) {
+	fm := NewForm("databaseID", "formPageID", "formPage", "comment")
+	defer func() { _ = fm.Drop() }()
+	for i := 0; i < 25; i++ {
+		if _, err := fm.Insert(map[string]interface{}{"Name": strconv.Itoa(i)}); nil != err {
+			t.Error(err)
+		}
+	}
+	var (
+		seen   = map[string]bool{}
+		cursor string
+	)
+	for pages := 0; pages < 10; pages++ {
+		_, values, next, err := fm.Page([]byte(`{"Limit":10,"Cursor":"` + cursor + `"}`))
+		if nil != err {
+			t.Fatal(err)
+		}
+		for _, value := range values {
+			name := value.(map[string]interface{})["Name"].(string)
+			if seen[name] {
+				t.Error("page repeated", name)
+			}
+			seen[name] = true
+		}
+		if pages == 0 { // 翻页之间新增数据及删除上一页最后一条数据
+			for i := 25; i < 30; i++ {
+				if _, err = fm.Insert(map[string]interface{}{"Name": strconv.Itoa(i)}); nil != err {
+					t.Error(err)
+				}
+			}
+			last := values[len(values)-1].(map[string]interface{})["Name"].(string)
+			if _, err = fm.Delete([]byte(`{"Conditions":[{"Param":"Name","Cond":"eq","Value":"` + last + `"}]}`)); nil != err {
+				t.Error(err)
+			}
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	if len(seen) != 30 {
+		t.Error("page missed", len(seen))
+	}
+	if _, _, _, err := fm.Page([]byte(`{"Cursor":"invalid"}`)); err != comm.ErrCursorInvalid {
+		t.Error("invalid cursor accepted", err)
+	}
+}
+
+func TestForm_SelectPageNonUnique(t *testing.T) {
+	fm := NewForm("databaseID", "formPageNonUniqueID", "formPageNonUnique", "comment")
+	defer func() { _ = fm.Drop() }()
+	fm.NewIndex("Status", false, false)
+	for i := 0; i < 25; i++ {
+		if _, err := fm.Insert(map[string]interface{}{"Name": strconv.Itoa(i), "Status": "open"}); nil != err {
+			t.Error(err)
+		}
+	}
+	var (
+		seen   = map[string]bool{}
+		cursor string
+	)
+	for pages := 0; pages < 10; pages++ {
+		_, values, next, err := fm.Page([]byte(`{"Conditions":[{"Param":"Status","Cond":"eq","Value":"open"}],"Sort":{"Param":"Status","Asc":true},"Limit":10,"Cursor":"` + cursor + `"}`))
+		if nil != err {
+			t.Fatal(err)
+		}
+		for _, value := range values {
+			name := value.(map[string]interface{})["Name"].(string)
+			if seen[name] {
+				t.Error("page repeated", name)
+			}
+			seen[name] = true
+		}
+		if pages == 0 { // 翻页之间删除上一页已返回的首条数据，同一叶子节点中其后的数据前移
+			first := values[0].(map[string]interface{})["Name"].(string)
+			if count, err := fm.Delete([]byte(`{"Conditions":[{"Param":"Name","Cond":"eq","Value":"` + first + `"}]}`)); nil != err || count != 1 {
+				t.Fatal("delete failed", count, err)
+			}
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	if len(seen) != 25 {
+		t.Error("page missed", len(seen))
+	}
+}
+
+func TestForm_SelectExplain(t *testing.T) {
+	fm := NewForm("databaseID", "formExplainID", "formExplain", "comment")
+	defer func() { _ = fm.Drop() }()
+	fm.NewIndex("Age", false, true)
+	fm.NewIndex("Status", false, false)
+	for i := 0; i < 10; i++ {
+		if _, err := fm.Insert(map[string]interface{}{"Age": i, "Status": []string{"open", "closed"}[i%2], "Name": "name" + strconv.Itoa(i)}); nil != err {
+			t.Error(err)
+		}
+	}
+	_, values, err := fm.Select([]byte(`{"Explain":true,"Conditions":[{"Param":"Status","Cond":"eq","Value":"open"},{"Param":"Age","Cond":"ge","Value":8},{"Param":"Name","Cond":"prefix","Value":"name"}]}`))
+	if nil != err || len(values) != 1 {
+		t.Fatal("explain select failed", values, err)
+	}
+	plan := values[0].(*api.Plan)
+	if plan.KeyStructure != "Age" || plan.EstimatedRows != 2 || plan.TotalRows != 10 || len(plan.Pushed) != 1 || len(plan.Filtered) != 2 {
+		t.Error("explain plan failed", plan)
+	}
+	if len(plan.Candidates) != 3 || plan.Candidates[1].KeyStructure != "Status" || plan.Candidates[1].EstimatedRows != 5 {
+		t.Error("explain candidates failed", plan.Candidates)
+	}
+	if count, _, err := fm.Select([]byte(`{"Conditions":[{"Param":"Status","Cond":"eq","Value":"open"},{"Param":"Age","Cond":"ge","Value":8}]}`)); nil != err || count != 1 {
+		t.Error("planned select failed", count, err)
+	}
+}
@@ -0,0 +1,148 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"github.com/aberic/gnomon"
+	"github.com/aberic/lilydb/engine/siam/utils"
+	"io/ioutil"
+	"os"
+)
+
+// IndexRecord 索引文件中的单条索引记录
+type IndexRecord struct {
+	Position  int64  // 记录在索引文件中的起始位置
+	HashKey   uint64 // 索引hashKey
+	MD516Key  string // 索引对应字符串key
+	SeekStart int64  // value在文件中的起始位置
+	SeekLast  int    // value在文件中的持续长度
+	Version   int    // 索引数据版本号
+}
+
+// Tombstone 是否为墓碑记录
+func (i *IndexRecord) Tombstone() bool {
+	return i.Version == utils.VersionTombstone
+}
+
+// ReadIndex 读取索引文件中的所有索引记录
+//
+// filePath 索引文件路径
+//
+// 返回 索引记录集合，以及文件末尾不足单条索引长度的字节数
+func ReadIndex(filePath string) ([]*IndexRecord, int, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if nil != err {
+		return nil, 0, err
+	}
+	var (
+		records  []*IndexRecord
+		position int
+	)
+	// 读取 11位hashKey + 16位md5Key + 11位起始seek + 4位持续seek + 4位版本号 = 46
+	for ; position+utils.LenIndex <= len(data); position += utils.LenIndex {
+		p0 := position
+		p1 := p0 + utils.LenHashKey
+		p2 := p1 + utils.LenMD5Key
+		p3 := p2 + utils.LenSeekStart
+		p4 := p3 + utils.LenSeekLast
+		p5 := p4 + utils.LenVersion
+		records = append(records, &IndexRecord{
+			Position:  int64(position),
+			HashKey:   gnomon.ScaleDDuoStringToUint64(string(data[p0:p1])),
+			MD516Key:  string(data[p1:p2]),
+			SeekStart: gnomon.ScaleDDuoStringToInt64(string(data[p2:p3])),
+			SeekLast:  int(gnomon.ScaleDDuoStringToInt64(string(data[p3:p4]))),
+			Version:   int(gnomon.ScaleDDuoStringToInt64(string(data[p4:p5]))),
+		})
+	}
+	return records, len(data) - position, nil
+}
+
+// RewriteIndex 以指定索引记录重写索引文件，记录的Position将被更新为新文件中的位置
+//
+// 先写入临时文件并落盘，再替换原索引文件，仅可在服务未启动时调用
+//
+// filePath 索引文件路径
+//
+// records 按顺序写入的索引记录
+func RewriteIndex(filePath string, records []*IndexRecord) error {
+	tmpFilePath := filePath + compactSuffix
+	file, err := os.OpenFile(tmpFilePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if nil != err {
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	for position, record := range records {
+		write := &Write{MD516Key: record.MD516Key, HashKey: record.HashKey, Version: record.Version}
+		if _, err = writer.WriteString(indexRecord(record.SeekStart, record.SeekLast, write)); nil != err {
+			break
+		}
+		record.Position = int64(position) * utils.LenIndex64
+	}
+	if nil == err {
+		err = writer.Flush()
+	}
+	if nil == err {
+		err = file.Sync()
+	}
+	if errClose := file.Close(); nil == err {
+		err = errClose
+	}
+	if nil != err {
+		_ = os.Remove(tmpFilePath)
+		return err
+	}
+	return os.Rename(tmpFilePath, filePath)
+}
+
+// ScanRecords 按记录头顺序遍历表数据文件中的数据段
+//
+// 早期未带记录头的数据无法确定长度，遍历将在此处停止
+//
+// filePath 表数据文件路径
+//
+// 返回 遍历得到的数据段，以及未能遍历部分的起始位置，全部遍历完成时为文件长度
+func ScanRecords(filePath string) ([]*Segment, int64, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if nil != err {
+		return nil, 0, err
+	}
+	var (
+		segments []*Segment
+		offset   int
+	)
+	for offset+recordHeadLen <= len(data) && bytes.Equal(data[offset:offset+2], recordMagic) {
+		seekLast := recordHeadLen + int(binary.BigEndian.Uint32(data[offset+2:offset+6]))
+		if offset+seekLast > len(data) {
+			break
+		}
+		segments = append(segments, &Segment{SeekStart: int64(offset), SeekLast: seekLast})
+		offset += seekLast
+	}
+	return segments, int64(offset), nil
+}
@@ -0,0 +1,299 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package storage
+
+import (
+	"bufio"
+	"github.com/aberic/gnomon"
+	"github.com/aberic/gnomon/log"
+	"github.com/aberic/lilydb/config"
+	"github.com/aberic/lilydb/engine/comm"
+	"github.com/aberic/lilydb/engine/siam/utils"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	// compactSuffix 压缩过程中新文件的后缀
+	compactSuffix = ".compact"
+	// compactMarker 压缩标记文件名，存在时表示新文件均已完整落盘，重启时需继续完成替换
+	compactMarker = "compact.lock"
+)
+
+// Segment 压缩时需要保留的数据段
+type Segment struct {
+	SeekStart int64    // value在原文件中的起始位置
+	SeekLast  int      // value在原文件中的持续长度
+	Writes    []*Write // 引用该数据段的各索引记录，Handler将在替换文件时以新坐标回调
+}
+
+// Compact 压缩表数据文件，仅保留segments中的数据段，并重写所有索引文件
+//
+// 新文件均写入完成并落盘后，持有swap锁替换原文件，随后回调各索引记录的Handler更新内存中的坐标
+//
+// 调用方需保证压缩期间不会有新的写入
+//
+// databaseID 数据库唯一id
+//
+// formID 表唯一id
+//
+// indexIDs 表下所有索引ID，不存在任何存活记录的索引文件将被清空
+//
+// segments 按顺序写入新文件的存活数据段
+//
+// swap 替换文件期间需要阻塞读取的锁
+func (s *Storage) Compact(databaseID, formID string, indexIDs []string, segments []*Segment, swap sync.Locker) error {
+	var (
+		formFilePath = utils.PathFormFile(databaseID, formID)
+		newPaths     = []string{formFilePath}
+		indexPaths   = map[string]string{} // 索引ID=索引文件路径
+		err          error
+	)
+	if err = s.syncForm(databaseID, formID); nil != err { // 确保原文件已落盘，此前的预写日志方可废弃
+		return err
+	}
+	for _, indexID := range indexIDs {
+		indexPaths[indexID] = utils.PathFormIndexFile(databaseID, formID, indexID)
+		newPaths = append(newPaths, indexPaths[indexID])
+	}
+	if err = compactFiles(formFilePath, indexPaths, segments); nil != err {
+		removeCompactFiles(newPaths)
+		return err
+	}
+	w, err := s.wal(databaseID)
+	if nil != err {
+		removeCompactFiles(newPaths)
+		return err
+	}
+	if err = w.discard(formID); nil != err {
+		removeCompactFiles(newPaths)
+		return err
+	}
+	markerPath := filepath.Join(filepath.Dir(formFilePath), compactMarker)
+	if err = writeAtSync(markerPath, []byte(strings.Join(newPaths, "\n")), 0); nil != err {
+		removeCompactFiles(newPaths)
+		return err
+	}
+	swap.Lock()
+	s.closeForm(databaseID, formID)
+	for _, newPath := range newPaths {
+		if err = os.Rename(newPath+compactSuffix, newPath); nil != err {
+			swap.Unlock()
+			return err // 标记文件仍存在，重启时将继续完成替换
+		}
+	}
+	for _, segment := range segments {
+		for _, write := range segment.Writes {
+			if nil != write.Handler {
+				write.Handler(write.SeekStartIndex, segment.SeekStart, segment.SeekLast)
+			}
+		}
+	}
+	swap.Unlock()
+	return os.Remove(markerPath)
+}
+
+// compactFiles 将存活数据段及索引记录写入新文件并落盘，同时将新坐标记录在segments中
+//
+// indexPaths 索引ID=索引文件路径
+func compactFiles(formFilePath string, indexPaths map[string]string, segments []*Segment) error {
+	var (
+		formNew    *os.File
+		formOld    *os.File
+		indexFiles = map[string]*os.File{}
+		writers    = map[string]*bufio.Writer{}
+		positions  = map[string]int64{} // 索引ID=新索引文件当前长度
+		seekNew    int64
+		err        error
+	)
+	defer func() {
+		for _, file := range []*os.File{formNew, formOld} {
+			if nil != file {
+				_ = file.Close()
+			}
+		}
+		for _, file := range indexFiles {
+			_ = file.Close()
+		}
+	}()
+	if formNew, err = os.OpenFile(formFilePath+compactSuffix, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0644); nil != err {
+		return err
+	}
+	for indexID, indexPath := range indexPaths {
+		if indexFiles[indexID], err = os.OpenFile(indexPath+compactSuffix, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0644); nil != err {
+			return err
+		}
+		writers[indexID] = bufio.NewWriter(indexFiles[indexID])
+	}
+	if len(segments) > 0 {
+		if formOld, err = os.Open(formFilePath); nil != err {
+			return err
+		}
+	}
+	formWriter := bufio.NewWriter(formNew)
+	for _, segment := range segments {
+		data := make([]byte, segment.SeekLast)
+		if _, err = formOld.ReadAt(data, segment.SeekStart); nil != err {
+			return err
+		}
+		if _, err = formWriter.Write(data); nil != err {
+			return err
+		}
+		segment.SeekStart = seekNew
+		seekNew += int64(segment.SeekLast)
+		for _, write := range segment.Writes {
+			writer, exist := writers[write.IndexID]
+			if !exist {
+				return comm.ErrIndexNotFound
+			}
+			if _, err = writer.WriteString(indexRecord(segment.SeekStart, segment.SeekLast, write)); nil != err {
+				return err
+			}
+			write.SeekStartIndex = positions[write.IndexID]
+			positions[write.IndexID] += utils.LenIndex64
+		}
+	}
+	if err = formWriter.Flush(); nil != err {
+		return err
+	}
+	if err = formNew.Sync(); nil != err {
+		return err
+	}
+	for indexID, writer := range writers {
+		if err = writer.Flush(); nil != err {
+			return err
+		}
+		if err = indexFiles[indexID].Sync(); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeCompactFiles 移除压缩过程中的新文件
+func removeCompactFiles(newPaths []string) {
+	for _, newPath := range newPaths {
+		_ = os.Remove(newPath + compactSuffix)
+	}
+}
+
+// syncForm 将表已打开的数据及索引文件落盘
+func (s *Storage) syncForm(databaseID, formID string) error {
+	fm := s.engine.form(databaseID, formID, utils.PathFormFile(databaseID, formID))
+	defer fm.mu.RUnlock()
+	fm.mu.RLock()
+	if nil != fm.file {
+		if err := fm.file.Sync(); nil != err {
+			return err
+		}
+	}
+	for _, idx := range fm.indexes {
+		if nil != idx.file {
+			if err := idx.file.Sync(); nil != err {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// closeForm 关闭表已打开的数据及索引文件，下次写入时将重新打开
+func (s *Storage) closeForm(databaseID, formID string) {
+	s.closeFiles(s.engine.form(databaseID, formID, utils.PathFormFile(databaseID, formID)))
+}
+
+// closeFiles 关闭表已打开的数据及索引文件
+func (s *Storage) closeFiles(fm *form) {
+	defer fm.mu.Unlock()
+	fm.mu.Lock()
+	if nil != fm.file {
+		_ = fm.file.Close()
+		fm.file = nil
+		<-s.limitOpenFileChan
+	}
+	for _, idx := range fm.indexes {
+		idx.mu.Lock()
+		if nil != idx.file {
+			_ = idx.file.Close()
+			idx.file = nil
+			<-s.limitOpenFileChan
+		}
+		idx.mu.Unlock()
+	}
+}
+
+// recoverCompact 处理库下各表上次未完成的压缩
+//
+// 标记文件存在时表示新文件均已落盘，继续完成替换；否则清理不完整的新文件
+func recoverCompact(databaseID string) error {
+	databasePath := filepath.Join(config.Obtain().DataDir, databaseID)
+	infos, err := ioutil.ReadDir(databasePath)
+	if nil != err {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		formPath := filepath.Join(databasePath, info.Name())
+		markerPath := filepath.Join(formPath, compactMarker)
+		if gnomon.FilePathExists(markerPath) {
+			log.Info("recoverCompact", log.Field("form", info.Name()))
+			data, err := ioutil.ReadFile(markerPath)
+			if nil != err {
+				return err
+			}
+			for _, newPath := range strings.Split(string(data), "\n") {
+				if gnomon.FilePathExists(newPath + compactSuffix) {
+					if err = os.Rename(newPath+compactSuffix, newPath); nil != err {
+						return err
+					}
+				}
+			}
+			if err = os.Remove(markerPath); nil != err {
+				return err
+			}
+			continue
+		}
+		files, err := ioutil.ReadDir(formPath)
+		if nil != err {
+			return err
+		}
+		for _, file := range files {
+			if strings.HasSuffix(file.Name(), compactSuffix) {
+				if err = os.Remove(filepath.Join(formPath, file.Name())); nil != err && !os.IsNotExist(err) {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
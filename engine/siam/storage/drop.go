@@ -0,0 +1,118 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package storage
+
+import (
+	"github.com/aberic/lilydb/config"
+	"github.com/aberic/lilydb/engine/siam/utils"
+	"os"
+	"path/filepath"
+)
+
+// DropDatabase 删除库，关闭库下已打开的预写日志、数据及索引文件，并移除库存储目录
+//
+// databaseID 数据库唯一id
+func (s *Storage) DropDatabase(databaseID string) error {
+	s.engine.mu.Lock()
+	db := s.engine.databases[databaseID]
+	delete(s.engine.databases, databaseID)
+	s.engine.mu.Unlock()
+	if nil != db {
+		db.mu.Lock()
+		if nil != db.wal {
+			_ = db.wal.close()
+			db.wal = nil
+		}
+		for _, fm := range db.forms {
+			s.closeFiles(fm)
+		}
+		db.mu.Unlock()
+	}
+	return os.RemoveAll(filepath.Join(config.Obtain().DataDir, databaseID))
+}
+
+// DropForm 删除表，关闭表已打开的数据及索引文件，废弃预写日志中该表的条目，并移除表存储目录
+//
+// 调用方需保证删除期间不会有新的写入
+//
+// databaseID 数据库唯一id
+//
+// formID 表唯一id
+func (s *Storage) DropForm(databaseID, formID string) error {
+	s.closeForm(databaseID, formID)
+	w, err := s.wal(databaseID)
+	if nil != err {
+		return err
+	}
+	if err = w.discard(formID); nil != err { // 避免重启时重做该表未完成的写入
+		return err
+	}
+	db := s.engine.databases[databaseID]
+	db.mu.Lock()
+	delete(db.forms, formID)
+	db.mu.Unlock()
+	return os.RemoveAll(filepath.Dir(utils.PathFormFile(databaseID, formID)))
+}
+
+// DropIndex 删除索引，关闭已打开的索引文件并移除
+//
+// 删除前先将表文件落盘并废弃预写日志中该表的条目，避免重启时重做写入而重新生成索引文件
+//
+// 调用方需保证删除期间不会有新的写入
+//
+// databaseID 数据库唯一id
+//
+// formID 表唯一id
+//
+// indexID 表索引唯一id
+func (s *Storage) DropIndex(databaseID, formID, indexID string) error {
+	if err := s.syncForm(databaseID, formID); nil != err {
+		return err
+	}
+	w, err := s.wal(databaseID)
+	if nil != err {
+		return err
+	}
+	if err = w.discard(formID); nil != err {
+		return err
+	}
+	fm := s.engine.form(databaseID, formID, utils.PathFormFile(databaseID, formID))
+	fm.mu.Lock()
+	if idx, exist := fm.indexes[indexID]; exist {
+		idx.mu.Lock()
+		if nil != idx.file {
+			_ = idx.file.Close()
+			idx.file = nil
+			<-s.limitOpenFileChan
+		}
+		idx.mu.Unlock()
+		delete(fm.indexes, indexID)
+	}
+	fm.mu.Unlock()
+	if err = os.Remove(utils.PathFormIndexFile(databaseID, formID, indexID)); nil != err && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
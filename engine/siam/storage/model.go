@@ -47,6 +47,7 @@ type form struct {
 // database 表依赖链集合，表ID=表级操作对象
 type database struct {
 	forms map[string]*form
+	wal   *wal // 库级预写日志
 	mu    sync.RWMutex
 }
 
@@ -0,0 +1,116 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/aberic/lilydb/engine/comm"
+	"github.com/vmihailenco/msgpack"
+	"os"
+	"sort"
+)
+
+const (
+	// recordHeadLen 数据记录头长度 = 2位标识 + 4位内容长度 + 4位crc32校验
+	recordHeadLen = 10
+)
+
+// recordMagic 数据记录头标识，不存在该标识的记录为早期未带记录头的数据
+var recordMagic = []byte("LY")
+
+// encodeRecord 为数据内容添加记录头
+func encodeRecord(data []byte) []byte {
+	record := make([]byte, recordHeadLen+len(data))
+	copy(record[0:2], recordMagic)
+	binary.BigEndian.PutUint32(record[2:6], uint32(len(data)))
+	binary.BigEndian.PutUint32(record[6:10], uint32(comm.Hash(string(data))))
+	copy(record[recordHeadLen:], data)
+	return record
+}
+
+// decodeRecord 校验记录头并返回数据内容
+//
+// 单个msgpack值以'L'开头时长度必然为1，因此长度不小于记录头的早期数据不会被误判为带记录头的数据
+func decodeRecord(record []byte) ([]byte, error) {
+	if len(record) < recordHeadLen || !bytes.Equal(record[0:2], recordMagic) { // 早期未带记录头的数据
+		return record, nil
+	}
+	data := record[recordHeadLen:]
+	if binary.BigEndian.Uint32(record[2:6]) != uint32(len(data)) || binary.BigEndian.Uint32(record[6:10]) != uint32(comm.Hash(string(data))) {
+		return nil, comm.ErrDataCorrupt
+	}
+	return data, nil
+}
+
+// unmarshalRecord 校验并解析数据记录
+func unmarshalRecord(record []byte) (interface{}, error) {
+	data, err := decodeRecord(record)
+	if nil != err {
+		return nil, err
+	}
+	var value interface{}
+	if err = msgpack.Unmarshal(data, &value); nil != err {
+		return nil, comm.ErrDataCorrupt
+	}
+	return value, nil
+}
+
+// Verify 校验表数据文件中指定数据段，返回损坏数据段的起始位置
+//
+// filePath 表数据文件路径
+//
+// segments 待校验的数据段
+func (s *Storage) Verify(filePath string, segments []*Segment) ([]int64, error) {
+	var (
+		corrupts []int64
+		file     *os.File
+		err      error
+	)
+	if len(segments) == 0 {
+		return corrupts, nil
+	}
+	defer func() {
+		if nil != file {
+			<-s.limitOpenFileChan
+			_ = file.Close()
+		}
+	}()
+	if file, err = s.openFile(filePath, os.O_RDONLY); nil != err {
+		return nil, err
+	}
+	for _, segment := range segments {
+		record := make([]byte, segment.SeekLast)
+		if _, err = file.ReadAt(record, segment.SeekStart); nil != err {
+			corrupts = append(corrupts, segment.SeekStart)
+			continue
+		}
+		if _, err = unmarshalRecord(record); nil != err {
+			corrupts = append(corrupts, segment.SeekStart)
+		}
+	}
+	sort.Slice(corrupts, func(i, j int) bool { return corrupts[i] < corrupts[j] })
+	return corrupts, nil
+}
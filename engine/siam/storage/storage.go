@@ -89,54 +89,49 @@ func (s *Storage) Take(filePath string, seekStart int64, seekLast int) (interfac
 		//log.Error("read", log.Err(err))
 		return nil, err
 	}
-	inputReader := bufio.NewReader(file)
+	inputReader := bufio.NewReaderSize(file, seekLast)
 	var bytes []byte
 	if bytes, err = inputReader.Peek(seekLast); nil != err {
 		//log.Error("read", log.Err(err))
 		return nil, err
 	}
-	var value interface{}
-	if err = msgpack.Unmarshal(bytes, &value); nil != err {
-		//log.Error("read", log.Err(err))
-		return nil, err
-	}
-	return value, nil
+	return unmarshalRecord(bytes)
 }
 
 // Store 存储具体内容
 //
+// 先将数据及索引的写入位置与内容记入库级预写日志，再写入form.dat及索引文件，最后提交日志
+//
 // databaseID 数据库唯一id
 //
 // formID 表唯一id
 //
-// key 唯一key
-//
 // value 存储具体内容
 //
-// valid 存储有效性，如无效则表示该记录不可用，即删除
-//
 // writes 索引即将写入的参考坐标数组
-//
-// return []*Written 返回完成索引写入后的结果数组
 func (s *Storage) Store(databaseID, formID string, value interface{}, writes []*Write) error {
 	var (
 		formFilePath = utils.PathFormFile(databaseID, formID) // path 存储文件路径
 		file         *os.File
 		seekStart    int64
-		seekLast     int
 		data         []byte
 		err          error
 	)
 	if data, err = msgpack.Marshal(value); nil != err {
 		return err
 	}
+	data = encodeRecord(data) // 记录头包含内容长度及校验，读取时据此发现损坏
 	fm := s.engine.form(databaseID, formID, formFilePath)
+	idxes, err := s.indexFiles(databaseID, formID, formFilePath, writes) // 获取索引文件需在持有表级锁之前
+	if nil != err {
+		return err
+	}
+	defer fm.mu.Unlock()
 	fm.mu.Lock()
 	if nil == fm.file {
-		if file, err = s.openFile(formFilePath, os.O_CREATE|os.O_RDWR|os.O_APPEND); nil != err {
+		if file, err = s.openFile(formFilePath, os.O_CREATE|os.O_RDWR); nil != err {
 			//log.Error("storeData", log.Err(err))
 			<-s.limitOpenFileChan
-			fm.mu.Unlock()
 			return err
 		}
 		fm.file = file
@@ -146,93 +141,181 @@ func (s *Storage) Store(databaseID, formID string, value interface{}, writes []*
 		//log.Debug("storeData", log.Err(err))
 		return err
 	}
-	if seekLast, err = fm.file.Write(data); nil != err { // value最终存储在文件中的持续长度
-		//log.Debug("storeData", log.Err(err))
-		return err
-	}
-	fm.mu.Unlock()
-	var wg sync.WaitGroup
+	entry := &walEntry{FormID: formID, Data: data, SeekStart: seekStart}
+	return s.write(databaseID, fm, idxes, entry, len(data), writes)
+}
+
+// Remove 删除具体内容，将指定行在各索引文件中的记录覆写为墓碑记录
+//
+// databaseID 数据库唯一id
+//
+// formID 表唯一id
+//
+// seekStart 被删除value在文件中的起始位置
+//
+// seekLast 被删除value在文件中的持续长度
+//
+// writes 被删除行在各索引文件中的坐标数组，SeekStartIndex为原索引记录起始位置
+func (s *Storage) Remove(databaseID, formID string, seekStart int64, seekLast int, writes []*Write) error {
+	var removes []*Write
 	for _, write := range writes {
-		wg.Add(1)
-		go func(databaseID, formID, formFilePath string, form *form, seekStart int64, seekLast int, write *Write) {
-			defer wg.Done()
-			newErr := s.storeIndex(databaseID, formID, formFilePath, seekStart, seekLast, write)
-			if nil == err && nil != newErr {
-				err = newErr
-			}
-		}(databaseID, formID, formFilePath, fm, seekStart, seekLast, write)
+		if write.SeekStartIndex < 0 { // 尚未写入索引文件，无需覆写
+			continue
+		}
+		write.Version = utils.VersionTombstone
+		removes = append(removes, write)
+	}
+	if len(removes) == 0 {
+		return nil
 	}
-	wg.Wait()
-	return err
+	return s.StoreIndex(databaseID, formID, seekStart, seekLast, removes)
 }
 
-// storeIndex 存储索引文件
+// StoreIndex 仅写入索引记录，不写入数据，用于删除数据或为已存在的数据回填新建的索引
 //
 // databaseID 数据库唯一id
 //
 // formID 表唯一id
 //
-// form 表级操作结构
+// seekStart 索引所指value在文件中的起始位置
 //
-// seekStart value最终存储在文件中的起始位置
+// seekLast 索引所指value在文件中的持续长度
 //
-// seekLast value最终存储在文件中的持续长度
+// writes 索引即将写入的参考坐标数组
+func (s *Storage) StoreIndex(databaseID, formID string, seekStart int64, seekLast int, writes []*Write) error {
+	formFilePath := utils.PathFormFile(databaseID, formID)
+	fm := s.engine.form(databaseID, formID, formFilePath)
+	idxes, err := s.indexFiles(databaseID, formID, formFilePath, writes)
+	if nil != err {
+		return err
+	}
+	defer fm.mu.Unlock()
+	fm.mu.Lock()
+	entry := &walEntry{FormID: formID, SeekStart: seekStart}
+	return s.write(databaseID, fm, idxes, entry, seekLast, writes)
+}
+
+// write 计算各索引记录写入位置并记入预写日志，随后写入数据及索引，调用方需持有表级锁
+//
+// idxes 与writes一一对应的索引文件
 //
-// write 索引即将写入的参考坐标
+// entry 预写日志条目，已包含待写入的数据及其起始位置
 //
-// return Written 返回完成索引写入后的结果
-func (s *Storage) storeIndex(databaseID, formID, formFilePath string, seekStart int64, seekLast int, write *Write) error {
+// seekLast value最终存储在文件中的持续长度
+//
+// writes 索引即将写入的参考坐标数组
+func (s *Storage) write(databaseID string, fm *form, idxes []*index, entry *walEntry, seekLast int, writes []*Write) error {
 	var (
-		file *os.File
-		err  error
+		w   *wal
+		seq uint64
+		err error
 	)
-	idx := s.engine.index(databaseID, formID, write.IndexID, formFilePath, write.FormIndexFilePath)
-	defer idx.mu.Unlock()
-	idx.mu.Lock()
-	if nil == idx.file {
-		// 将获取到的索引存储位置传入。如果为0，则表示没有存储过；如果不为0，则覆盖旧的存储记录
-		if file, err = s.openFile(write.FormIndexFilePath, os.O_CREATE|os.O_RDWR); nil != err {
-			//log.Error("storeIndex", log.Err(err))
-			<-s.limitOpenFileChan
-			return err
+	for position, write := range writes {
+		var position64 int64
+		if write.SeekStartIndex < 0 { // 如果小于0，则表示没有存储过，追加至文件末尾；否则覆盖旧的存储记录
+			if position64, err = idxes[position].file.Seek(0, io.SeekEnd); nil != err {
+				return err
+			}
+		} else {
+			position64 = write.SeekStartIndex
 		}
-		idx.file = file
+		entry.Indexes = append(entry.Indexes, &walIndex{IndexID: write.IndexID, Position: position64, Record: indexRecord(entry.SeekStart, seekLast, write)})
 	}
-	// 11位hashKey + 16位md5Key = 27
-	appendStr := gnomon.StringBuild(gnomon.StringPrefixSupplementZero(gnomon.ScaleUint64ToDDuoString(write.HashKey), utils.LenHashKey), write.MD516Key)
-	//log.Debug("storeIndex", log.Field("md516Key", write.MD516Key), log.Field("appendStr", appendStr))
-	//log.Debug("storeIndex",
-	//	log.Field("appendStr", appendStr),
-	//	log.Field("formIndexFilePath", write.FormIndexFilePath),
-	//	log.Field("seekStartIndex", write.SeekStartIndex))
-	var seekEnd int64
-	//log.Debug("running", log.Field("type", "moldIndex"), log.Field("seekStartIndex", write.SeekStartIndex))
-	if write.SeekStartIndex <= 0 {
-		if seekEnd, err = idx.file.Seek(0, io.SeekEnd); nil != err {
-			//log.Error("storeIndex", log.Err(err))
+	if w, err = s.wal(databaseID); nil != err {
+		return err
+	}
+	if seq, err = w.begin(entry); nil != err {
+		return err
+	}
+	if len(entry.Data) > 0 {
+		if _, err = fm.file.WriteAt(entry.Data, entry.SeekStart); nil != err {
 			return err
 		}
-		//log.Debug("running", log.Field("it.link.seekStartIndex == -1", seekEnd))
-	} else {
-		if seekEnd, err = idx.file.Seek(write.SeekStartIndex, io.SeekStart); nil != err { // 寻址到原索引起始位置
-			//log.Error("storeIndex", log.Err(err))
+	}
+	for position, write := range writes {
+		idx, wi := idxes[position], entry.Indexes[position]
+		idx.mu.Lock()
+		_, err = idx.file.WriteAt([]byte(wi.Record), wi.Position)
+		idx.mu.Unlock()
+		if nil != err {
 			return err
 		}
-		//log.Debug("running", log.Field("seekStartIndex", write.SeekStartIndex), log.Field("it.link.seekStartIndex != -1", seekEnd))
+		if nil != write.Handler {
+			write.Handler(wi.Position, entry.SeekStart, seekLast)
+		}
+	}
+	return w.commit(seq, func() error { return s.syncDatabase(databaseID) })
+}
+
+// indexFiles 获取并按需打开索引文件，返回与writes一一对应的索引文件集合
+func (s *Storage) indexFiles(databaseID, formID, formFilePath string, writes []*Write) ([]*index, error) {
+	idxes := make([]*index, len(writes))
+	for position, write := range writes {
+		idx := s.engine.index(databaseID, formID, write.IndexID, formFilePath, write.FormIndexFilePath)
+		idx.mu.Lock()
+		if nil == idx.file {
+			file, err := s.openFile(write.FormIndexFilePath, os.O_CREATE|os.O_RDWR)
+			if nil != err {
+				//log.Error("storeIndex", log.Err(err))
+				<-s.limitOpenFileChan
+				idx.mu.Unlock()
+				return nil, err
+			}
+			idx.file = file
+		}
+		idx.mu.Unlock()
+		idxes[position] = idx
 	}
-	// 11位hashKey + 16位md5Key + 11位起始seek + 4位持续seek + 4位版本号 = 46
-	indexStr := gnomon.StringBuild(appendStr,
+	return idxes, nil
+}
+
+// indexRecord 组装单条索引记录
+//
+// 11位hashKey + 16位md5Key + 11位起始seek + 4位持续seek + 4位版本号 = 46
+func indexRecord(seekStart int64, seekLast int, write *Write) string {
+	return gnomon.StringBuild(
+		gnomon.StringPrefixSupplementZero(gnomon.ScaleUint64ToDDuoString(write.HashKey), utils.LenHashKey),
+		write.MD516Key,
 		gnomon.StringPrefixSupplementZero(gnomon.ScaleInt64ToDDuoString(seekStart), utils.LenSeekStart),
 		gnomon.StringPrefixSupplementZero(gnomon.ScaleIntToDDuoString(seekLast), utils.LenSeekLast),
 		gnomon.StringPrefixSupplementZero(gnomon.ScaleIntToDDuoString(write.Version), utils.LenVersion))
-	//log.Debug("storeIndex", log.Field("indexStr", indexStr))
-	if _, err = idx.file.WriteString(indexStr); nil != err {
-		//log.Error("running", log.Field("seekStartIndex", seekEnd), log.Err(err))
-		return err
+}
+
+// wal 获取库级预写日志
+func (s *Storage) wal(databaseID string) (*wal, error) {
+	s.engine.mkDatabase(databaseID)
+	db := s.engine.databases[databaseID]
+	defer db.mu.Unlock()
+	db.mu.Lock()
+	if nil == db.wal {
+		w, err := newWAL(databaseID)
+		if nil != err {
+			return nil, err
+		}
+		db.wal = w
+	}
+	return db.wal, nil
+}
+
+// syncDatabase 将库下所有已打开的数据及索引文件落盘
+func (s *Storage) syncDatabase(databaseID string) error {
+	db := s.engine.databases[databaseID]
+	defer db.mu.RUnlock()
+	db.mu.RLock()
+	for _, fm := range db.forms {
+		if nil != fm.file {
+			if err := fm.file.Sync(); nil != err {
+				return err
+			}
+		}
+		for _, idx := range fm.indexes {
+			if nil != idx.file {
+				if err := idx.file.Sync(); nil != err {
+					return err
+				}
+			}
+		}
 	}
-	//log.Debug("storeIndex", log.Field("ib.getKey()", write.Key), log.Field("md516Key", md516Key), log.Field("seekStartIndex", write.SeekStartIndex))
-	//log.Debug("running", log.Field("it.link.seekStartIndex", seekEnd), log.Err(err))
-	write.Handler(seekEnd, seekStart, seekLast)
 	return nil
 }
 
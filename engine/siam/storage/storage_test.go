@@ -26,11 +26,31 @@ package storage
 
 import (
 	"github.com/aberic/gnomon"
+	"github.com/aberic/lilydb/config"
 	"github.com/aberic/lilydb/engine/siam/utils"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 )
 
+// TestMain 将数据目录指向临时目录，测试结束后删除
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "lilydb-storage")
+	if nil != err {
+		panic(err)
+	}
+	conf := config.Obtain()
+	conf.RootDir = dir
+	conf.DataDir = filepath.Join(dir, "data")
+	conf.LilyLockFilePath = filepath.Join(dir, "lily.lock")
+	conf.LilyBootstrapFilePath = filepath.Join(conf.DataDir, "lily.sync")
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
 func TestObtain(t *testing.T) {
 	s := Obtain()
 	type Value struct {
@@ -111,3 +131,68 @@ func TestStorage_TakeFail(t *testing.T) {
 		t.Log(r)
 	}
 }
+
+func TestStorage_Replay(t *testing.T) {
+	w, err := Obtain().wal("databaseReplay")
+	if nil != err {
+		t.Fatal(err)
+	}
+	write := &Write{IndexID: "indexID", MD516Key: gnomon.HashMD516("key"), HashKey: 1}
+	if _, err = w.begin(&walEntry{FormID: "formID", Data: []byte("value"), SeekStart: 0, Indexes: []*walIndex{
+		{IndexID: "indexID", Position: 0, Record: indexRecord(0, 5, write)},
+	}}); nil != err {
+		t.Fatal(err)
+	}
+	if err = Obtain().Replay("databaseReplay"); nil != err {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(utils.PathFormIndexFile("databaseReplay", "formID", "indexID"))
+	if nil != err {
+		t.Fatal(err)
+	}
+	if len(data) != utils.LenIndex {
+		t.Error("replay index failed", string(data))
+	}
+	t.Log(string(data))
+}
+
+func TestStorage_ReplayCommitted(t *testing.T) {
+	var (
+		seekStart int64
+		seekLast  int
+		indexPath = utils.PathFormIndexFile("databaseReplayCommitted", "formID", "indexID")
+		formPath  = utils.PathFormFile("databaseReplayCommitted", "formID")
+	)
+	if err := Obtain().Store("databaseReplayCommitted", "formID", "value", []*Write{
+		{
+			IndexID:           "indexID",
+			FormIndexFilePath: indexPath,
+			MD516Key:          gnomon.HashMD516("key"),
+			HashKey:           1,
+			SeekStartIndex:    -1,
+			Handler: func(_ int64, start int64, last int) {
+				seekStart, seekLast = start, last
+			},
+		},
+	}); nil != err {
+		t.Fatal(err)
+	}
+	// 提交条目已写入，但数据及索引尚未到达检查点落盘，模拟宕机后丢失
+	if err := os.Truncate(formPath, 0); nil != err {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(indexPath, 0); nil != err {
+		t.Fatal(err)
+	}
+	if err := Obtain().Replay("databaseReplayCommitted"); nil != err {
+		t.Fatal(err)
+	}
+	value, err := Obtain().Take(formPath, seekStart, seekLast)
+	if nil != err || value != "value" {
+		t.Error("replay committed data failed", value, err)
+	}
+	data, err := ioutil.ReadFile(indexPath)
+	if nil != err || len(data) != utils.LenIndex {
+		t.Error("replay committed index failed", string(data), err)
+	}
+}
@@ -0,0 +1,335 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package storage
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"github.com/aberic/gnomon"
+	"github.com/aberic/gnomon/log"
+	"github.com/aberic/lilydb/config"
+	"github.com/aberic/lilydb/engine/comm"
+	"github.com/aberic/lilydb/engine/siam/utils"
+	"github.com/vmihailenco/msgpack"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// walBegin 事务开始，记录物理重做信息
+	walBegin uint8 = iota
+	// walCommit 事务提交，数据及索引均已写入但未必已落盘
+	walCommit
+	// walDiscard 废弃表此前所有事务，表文件被压缩或删除后原有坐标已失效
+	walDiscard
+)
+
+const (
+	// walFrameHeadLen 日志帧头长度 = 4位内容长度 + 4位crc32校验
+	walFrameHeadLen = 8
+	// walCheckpointSize 预写日志超过该长度且无未提交事务时执行检查点
+	walCheckpointSize int64 = 4 << 20
+)
+
+// errWALFrame 预写日志帧不完整或校验失败，通常由宕机时的不完整写入导致
+var errWALFrame = errors.New("wal frame broken")
+
+// walEntry 预写日志条目
+//
+// 记录写入form.dat及索引文件的物理位置及内容，重做时按位置覆写，可重复执行
+type walEntry struct {
+	Seq       uint64      `msgpack:"s"`  // 事务序号
+	Type      uint8       `msgpack:"t"`  // 条目类型
+	FormID    string      `msgpack:"f"`  // 表唯一ID
+	Data      []byte      `msgpack:"d"`  // 写入form.dat的内容，删除操作为空
+	SeekStart int64       `msgpack:"ss"` // Data在form.dat中的起始位置
+	Indexes   []*walIndex `msgpack:"i"`  // 索引记录集合
+}
+
+// walIndex 预写日志中的索引记录
+type walIndex struct {
+	IndexID  string `msgpack:"i"` // 索引唯一ID
+	Position int64  `msgpack:"p"` // 索引记录在索引文件中的起始位置
+	Record   string `msgpack:"r"` // 索引记录内容
+}
+
+// wal 库级预写日志
+//
+// 存储格式 {dataDir}/{databaseID}/lily.wal
+type wal struct {
+	databaseID string
+	file       *os.File
+	seq        uint64              // 最新事务序号
+	pending    map[uint64]struct{} // 尚未提交的事务序号集合
+	dirty      bool                // 是否存在尚未落盘的内容
+	stop       chan struct{}       // 停止定时落盘
+	mu         sync.Mutex
+}
+
+// newWAL 打开或新建库级预写日志，并根据配置启动定时落盘
+func newWAL(databaseID string) (*wal, error) {
+	filePath := utils.PathWALFile(databaseID)
+	if err := os.MkdirAll(gnomon.FileParentPath(filePath), os.ModePerm); nil != err {
+		return nil, err
+	}
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if nil != err {
+		return nil, err
+	}
+	w := &wal{databaseID: databaseID, file: file, pending: map[uint64]struct{}{}, stop: make(chan struct{})}
+	if conf := config.Obtain(); conf.WALSync == config.WALSyncInterval {
+		go w.syncInterval(time.Duration(conf.WALSyncInterval) * time.Millisecond)
+	}
+	return w, nil
+}
+
+// syncInterval 定时落盘
+func (w *wal) syncInterval(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.mu.Lock()
+			if w.dirty {
+				if err := w.file.Sync(); nil != err {
+					log.Error("walSyncInterval", log.Err(err))
+				} else {
+					w.dirty = false
+				}
+			}
+			w.mu.Unlock()
+		}
+	}
+}
+
+// begin 写入事务开始条目，返回事务序号
+func (w *wal) begin(entry *walEntry) (uint64, error) {
+	defer w.mu.Unlock()
+	w.mu.Lock()
+	w.seq++
+	entry.Seq = w.seq
+	entry.Type = walBegin
+	if err := w.append(entry); nil != err {
+		return 0, err
+	}
+	if config.Obtain().WALSync == config.WALSyncAlways {
+		if err := w.file.Sync(); nil != err {
+			return 0, err
+		}
+		w.dirty = false
+	}
+	w.pending[entry.Seq] = struct{}{}
+	return entry.Seq, nil
+}
+
+// commit 写入事务提交条目，无需立即落盘
+//
+// 数据及索引文件仅在检查点时落盘，重做时不以提交条目判断事务是否已落盘，提交条目仅用于判断能否执行检查点
+//
+// checkpoint 无未提交事务且日志过长时执行检查点的回调，负责将库下数据及索引文件落盘
+func (w *wal) commit(seq uint64, checkpoint func() error) error {
+	defer w.mu.Unlock()
+	w.mu.Lock()
+	if err := w.append(&walEntry{Seq: seq, Type: walCommit}); nil != err {
+		return err
+	}
+	delete(w.pending, seq)
+	if len(w.pending) > 0 {
+		return nil
+	}
+	size, err := w.file.Seek(0, io.SeekEnd)
+	if nil != err || size < walCheckpointSize {
+		return err
+	}
+	if err = checkpoint(); nil != err {
+		return err
+	}
+	return w.truncate()
+}
+
+// discard 废弃表此前所有事务并立即落盘
+//
+// 调用方需确保该表此前的写入均已落盘
+func (w *wal) discard(formID string) error {
+	defer w.mu.Unlock()
+	w.mu.Lock()
+	w.seq++
+	if err := w.append(&walEntry{Seq: w.seq, Type: walDiscard, FormID: formID}); nil != err {
+		return err
+	}
+	if err := w.file.Sync(); nil != err {
+		return err
+	}
+	w.dirty = false
+	return nil
+}
+
+// append 追加日志帧，帧格式为 4位内容长度 + 4位crc32校验 + msgpack内容
+func (w *wal) append(entry *walEntry) error {
+	data, err := msgpack.Marshal(entry)
+	if nil != err {
+		return err
+	}
+	frame := make([]byte, walFrameHeadLen+len(data))
+	binary.BigEndian.PutUint32(frame[0:4], uint32(len(data)))
+	binary.BigEndian.PutUint32(frame[4:8], uint32(comm.Hash(string(data))))
+	copy(frame[walFrameHeadLen:], data)
+	if _, err = w.file.Write(frame); nil != err {
+		return err
+	}
+	w.dirty = true
+	return nil
+}
+
+// truncate 清空预写日志
+func (w *wal) truncate() error {
+	if err := w.file.Truncate(0); nil != err {
+		return err
+	}
+	w.dirty = false
+	return w.file.Sync()
+}
+
+// close 停止定时落盘并关闭日志文件
+func (w *wal) close() error {
+	defer w.mu.Unlock()
+	w.mu.Lock()
+	close(w.stop)
+	return w.file.Close()
+}
+
+// readWAL 读取预写日志中所有完整的条目，遇到不完整或校验失败的帧即停止
+func readWAL(filePath string) ([]*walEntry, error) {
+	file, err := os.Open(filePath)
+	if nil != err {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	var (
+		entries []*walEntry
+		reader  = bufio.NewReader(file)
+		head    = make([]byte, walFrameHeadLen)
+	)
+	for {
+		if _, err = io.ReadFull(reader, head); nil != err {
+			break
+		}
+		data := make([]byte, binary.BigEndian.Uint32(head[0:4]))
+		if _, err = io.ReadFull(reader, data); nil != err {
+			break
+		}
+		if uint32(comm.Hash(string(data))) != binary.BigEndian.Uint32(head[4:8]) {
+			err = errWALFrame
+			break
+		}
+		entry := &walEntry{}
+		if err = msgpack.Unmarshal(data, entry); nil != err {
+			break
+		}
+		entries = append(entries, entry)
+	}
+	if io.EOF != err {
+		log.Warn("readWAL", log.Field("path", filePath), log.Field("entries", len(entries)), log.Err(err))
+	}
+	return entries, nil
+}
+
+// Replay 重做库预写日志中上次检查点之后的所有事务，完成后将相关文件落盘并清空预写日志
+//
+// 检查点之后的数据及索引写入即使已提交也可能未落盘，重做按位置覆写，对已落盘的事务重复执行无影响
+//
+// 需在恢复库下表索引之前调用
+//
+// databaseID 数据库唯一id
+func (s *Storage) Replay(databaseID string) error {
+	if err := recoverCompact(databaseID); nil != err { // 先完成上次未完成的压缩，随后的日志均基于压缩后的文件
+		return err
+	}
+	filePath := utils.PathWALFile(databaseID)
+	if !gnomon.FilePathExists(filePath) {
+		return nil
+	}
+	entries, err := readWAL(filePath)
+	if nil != err {
+		return err
+	}
+	var (
+		begins = map[uint64]*walEntry{}
+		redo   []*walEntry
+	)
+	for _, entry := range entries {
+		switch entry.Type {
+		case walBegin:
+			begins[entry.Seq] = entry
+		case walDiscard:
+			for seq, begin := range begins {
+				if begin.FormID == entry.FormID {
+					delete(begins, seq)
+				}
+			}
+		}
+	}
+	for _, entry := range begins {
+		redo = append(redo, entry)
+	}
+	sort.Slice(redo, func(i, j int) bool { return redo[i].Seq < redo[j].Seq })
+	for _, entry := range redo {
+		log.Info("replayWAL", log.Field("database", databaseID), log.Field("form", entry.FormID), log.Field("seq", entry.Seq))
+		if len(entry.Data) > 0 {
+			if err = writeAtSync(utils.PathFormFile(databaseID, entry.FormID), entry.Data, entry.SeekStart); nil != err {
+				return err
+			}
+		}
+		for _, idx := range entry.Indexes {
+			if err = writeAtSync(utils.PathFormIndexFile(databaseID, entry.FormID, idx.IndexID), []byte(idx.Record), idx.Position); nil != err {
+				return err
+			}
+		}
+	}
+	return os.Truncate(filePath, 0)
+}
+
+// writeAtSync 在指定文件的指定位置写入内容并落盘
+func writeAtSync(filePath string, data []byte, offset int64) error {
+	if err := os.MkdirAll(gnomon.FileParentPath(filePath), os.ModePerm); nil != err {
+		return err
+	}
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_RDWR, 0644)
+	if nil != err {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+	if _, err = file.WriteAt(data, offset); nil != err {
+		return err
+	}
+	return file.Sync()
+}
@@ -0,0 +1,283 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"github.com/aberic/lilydb/engine/comm"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	aggregateCount    = "count"    // aggregateCount 计数，Param为空时统计行数，否则统计该字段存在且不为空的行数
+	aggregateSum      = "sum"      // aggregateSum 求和，忽略非数值
+	aggregateAvg      = "avg"      // aggregateAvg 平均值，忽略非数值
+	aggregateMin      = "min"      // aggregateMin 最小值，数值、字符串及布尔值各自比较
+	aggregateMax      = "max"      // aggregateMax 最大值，数值、字符串及布尔值各自比较
+	aggregateDistinct = "distinct" // aggregateDistinct 不为空的不同值数量
+)
+
+// Aggregation 聚合方式，按分组字段对检索结果分组后计算各累加器
+type Aggregation struct {
+	// GroupBy 分组字段，由对象结构层级字段通过'.'组成，为空时所有检索结果为一组
+	GroupBy []string `json:"GroupBy"`
+	// Accumulators 累加器集合
+	Accumulators []*Accumulator `json:"Accumulators"`
+}
+
+// Accumulator 聚合累加器
+type Accumulator struct {
+	Name  string `json:"Name"`  // 结果中的字段名称，为空时取'Op_Param'，Param中的'.'替换为'_'
+	Op    string `json:"Op"`    // 累加方式 count/sum/avg/min/max/distinct
+	Param string `json:"Param"` // 被累加字段，由对象结构层级字段通过'.'组成，仅count可为空
+}
+
+// Verify 校验聚合方式，累加方式不支持或缺少被累加字段时返回错误
+func (a *Aggregation) Verify() error {
+	for _, acc := range a.Accumulators {
+		switch acc.Op {
+		default:
+			return comm.ErrAggregateNotSupport
+		case aggregateCount:
+		case aggregateSum, aggregateAvg, aggregateMin, aggregateMax, aggregateDistinct:
+			if acc.Param == "" {
+				return comm.ErrAggregateNotSupport
+			}
+		}
+	}
+	return nil
+}
+
+// name 累加结果在分组结果中的字段名称
+func (a *Accumulator) name() string {
+	if a.Name != "" {
+		return a.Name
+	}
+	if a.Param == "" {
+		return a.Op
+	}
+	return strings.Join([]string{a.Op, strings.ReplaceAll(a.Param, ".", "_")}, "_")
+}
+
+// NewAggregator 新建聚合计算器
+func NewAggregator(aggregation *Aggregation) *Aggregator {
+	aggregator := &Aggregator{aggregation: aggregation, groups: map[string]*aggregateGroup{}}
+	for _, field := range aggregation.GroupBy {
+		aggregator.groupBy = append(aggregator.groupBy, strings.Split(field, "."))
+	}
+	for _, acc := range aggregation.Accumulators {
+		if acc.Param == "" {
+			aggregator.params = append(aggregator.params, nil)
+		} else {
+			aggregator.params = append(aggregator.params, strings.Split(acc.Param, "."))
+		}
+	}
+	return aggregator
+}
+
+// Aggregator 聚合计算器，逐行累加检索结果，无需保留各行数据
+type Aggregator struct {
+	aggregation *Aggregation
+	groupBy     [][]string                 // 拆分后的分组字段
+	params      [][]string                 // 拆分后的各累加器字段
+	groups      map[string]*aggregateGroup // 分组key=分组累加状态
+	keys        []string                   // 各分组首次出现的顺序
+}
+
+// aggregateGroup 单个分组的累加状态
+type aggregateGroup struct {
+	values []interface{} // 各分组字段的值
+	exists []bool        // 各分组字段是否存在
+	states []*accumulatorState
+}
+
+// accumulatorState 单个累加器的累加状态
+type accumulatorState struct {
+	count    int64           // 参与累加的数量
+	sum      Number          // 数值之和
+	min, max interface{}     // 最小值及最大值
+	distinct map[string]bool // 已出现的不同值
+}
+
+// Add 累加一行检索结果
+func (a *Aggregator) Add(value interface{}) {
+	var (
+		keys   = make([]string, len(a.groupBy))
+		values = make([]interface{}, len(a.groupBy))
+		exists = make([]bool, len(a.groupBy))
+	)
+	for position, params := range a.groupBy {
+		values[position], exists[position] = ValueByParams(value, params)
+		keys[position] = aggregateKey(values[position], exists[position])
+	}
+	key := strings.Join(keys, "")
+	g, ok := a.groups[key]
+	if !ok {
+		g = &aggregateGroup{values: values, exists: exists, states: make([]*accumulatorState, len(a.params))}
+		for position := range g.states {
+			g.states[position] = &accumulatorState{distinct: map[string]bool{}}
+		}
+		a.groups[key] = g
+		a.keys = append(a.keys, key)
+	}
+	for position, acc := range a.aggregation.Accumulators {
+		a.accumulate(acc.Op, a.params[position], g.states[position], value)
+	}
+}
+
+// accumulate 按累加方式将检索结果累加至累加状态
+func (a *Aggregator) accumulate(op string, params []string, state *accumulatorState, value interface{}) {
+	if nil == params { // 仅count可无被累加字段，统计行数
+		state.count++
+		return
+	}
+	item, exist := ValueByParams(value, params)
+	if !exist || nil == item {
+		return
+	}
+	switch op {
+	case aggregateCount:
+		state.count++
+	case aggregateSum, aggregateAvg:
+		reflectValue := reflect.ValueOf(item)
+		if number, ok := ValueNumber(&reflectValue); ok {
+			state.sum = state.sum.Add(number)
+			state.count++
+		}
+	case aggregateMin:
+		if nil == state.min {
+			state.min = item
+		} else if compare, ok := CompareValue(item, state.min); ok && compare < 0 {
+			state.min = item
+		}
+	case aggregateMax:
+		if nil == state.max {
+			state.max = item
+		} else if compare, ok := CompareValue(item, state.max); ok && compare > 0 {
+			state.max = item
+		}
+	case aggregateDistinct:
+		state.distinct[aggregateKey(item, true)] = true
+	}
+}
+
+// Result 各分组的聚合结果，按分组首次出现的顺序排列
+//
+// 每个分组结果为map[string]interface{}，包含各分组字段的值及各累加器的结果，分组字段不存在时不包含该字段
+func (a *Aggregator) Result() []interface{} {
+	results := make([]interface{}, 0, len(a.keys))
+	for _, key := range a.keys {
+		g := a.groups[key]
+		result := map[string]interface{}{}
+		for position, params := range a.groupBy {
+			if g.exists[position] {
+				setByParams(result, params, g.values[position])
+			}
+		}
+		for position, acc := range a.aggregation.Accumulators {
+			result[acc.name()] = g.states[position].result(acc.Op)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// result 累加结果，avg、min及max无可累加值时为nil
+func (s *accumulatorState) result(op string) interface{} {
+	switch op {
+	default: // aggregateCount
+		return s.count
+	case aggregateSum:
+		return s.sum.Value()
+	case aggregateAvg:
+		if s.count == 0 {
+			return nil
+		}
+		return s.sum.Float64() / float64(s.count)
+	case aggregateMin:
+		return s.min
+	case aggregateMax:
+		return s.max
+	case aggregateDistinct:
+		return int64(len(s.distinct))
+	}
+}
+
+// aggregateKey 值的分组key，相同数值不论类型得到相同key，各值的key自带长度前缀，拼接后不会混淆
+func aggregateKey(value interface{}, exist bool) string {
+	var key string
+	switch v := value.(type) {
+	default:
+		reflectValue := reflect.ValueOf(value)
+		if number, ok := ValueNumber(&reflectValue); ok {
+			key = "d" + number.Key()
+		} else {
+			key = fmt.Sprintf("o%T:%v", value, value)
+		}
+	case nil:
+		if exist {
+			key = "n"
+		} else {
+			key = "m"
+		}
+	case string:
+		key = "s" + v
+	case bool:
+		key = "b" + strconv.FormatBool(v)
+	}
+	return strings.Join([]string{strconv.Itoa(len(key)), key}, ":")
+}
+
+// CompareValue 比较两个同类值的大小，小于、等于及大于b分别返回-1、0及1
+//
+// 数值之间不论类型按数值大小比较，字符串按字典序比较，布尔值false小于true，其它情况返回false表示不可比较
+func CompareValue(a, b interface{}) (int, bool) {
+	switch va := a.(type) {
+	case string:
+		if vb, ok := b.(string); ok {
+			return strings.Compare(va, vb), true
+		}
+		return 0, false
+	case bool:
+		if vb, ok := b.(bool); ok {
+			if va == vb {
+				return 0, true
+			} else if vb {
+				return -1, true
+			}
+			return 1, true
+		}
+		return 0, false
+	}
+	reflectA, reflectB := reflect.ValueOf(a), reflect.ValueOf(b)
+	numberA, okA := ValueNumber(&reflectA)
+	numberB, okB := ValueNumber(&reflectB)
+	if okA && okB {
+		return numberA.Compare(numberB), true
+	}
+	return 0, false
+}
@@ -43,4 +43,8 @@ const (
 	LenPeekOnce = 46000
 	// LenPeekOnce64 单次恢复索引长度 = 46000
 	LenPeekOnce64 int64 = 46000
+	// VersionTombstone 墓碑版本号，即4位版本号所能表示的最大值，索引记录版本号为该值时表示对应数据已被删除
+	VersionTombstone = 16777215
+	// KeyStructureSeparator 组合索引中各字段名称之间的分隔符，如'tenant,createdAt'
+	KeyStructureSeparator = ","
 )
@@ -0,0 +1,281 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package utils
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+const (
+	numberInt   byte = 'i' // numberInt 可由int64表示的整数
+	numberUint  byte = 'u' // numberUint 超出int64范围的uint64整数
+	numberFloat byte = 'f' // numberFloat 非整数、超出整数范围的浮点数及NaN、Inf
+)
+
+// Number 统一表示int、uint及float类型的数值，不同类型之间按数值大小无损比较
+//
+// 整数值的浮点数及不超过int64范围的uint均归一为int64，确保通过不同类型存入的相同数值得到相同索引
+type Number struct {
+	kind byte    // kind 数值类型标记
+	i    int64   // i 类型为numberInt时的值
+	u    uint64  // u 类型为numberUint时的值
+	f    float64 // f 类型为numberFloat时的值
+}
+
+// ValueNumber 通过反射对象获取数值，非int、uint及float类型返回false
+func ValueNumber(value *reflect.Value) (Number, bool) {
+	switch value.Kind() {
+	default:
+		return Number{}, false
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int, reflect.Int64:
+		return Number{kind: numberInt, i: value.Int()}, true
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return uint2Number(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return float2Number(value.Float()), true
+	}
+}
+
+// ParseNumber 将字符串解析为数值
+func ParseNumber(value string) (Number, bool) {
+	if i64, err := strconv.ParseInt(value, 10, 64); nil == err {
+		return Number{kind: numberInt, i: i64}, true
+	}
+	if ui64, err := strconv.ParseUint(value, 10, 64); nil == err {
+		return uint2Number(ui64), true
+	}
+	if f64, err := strconv.ParseFloat(value, 64); nil == err {
+		return float2Number(f64), true
+	}
+	return Number{}, false
+}
+
+// JSONNumber 将以json.Number解析的数字转换为对应的int64、uint64或float64原值，数组及对象内的数字逐一转换，其余值原样返回
+//
+// 以UseNumber解析json可避免超出2^53的整数经由float64丢失精度
+func JSONNumber(value interface{}) interface{} {
+	switch v := value.(type) {
+	default:
+		return value
+	case json.Number:
+		if number, ok := ParseNumber(string(v)); ok {
+			return number.Value()
+		}
+		return value
+	case []interface{}:
+		for i, item := range v {
+			v[i] = JSONNumber(item)
+		}
+		return v
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = JSONNumber(item)
+		}
+		return v
+	}
+}
+
+func uint2Number(ui64 uint64) Number {
+	if ui64 <= math.MaxInt64 {
+		return Number{kind: numberInt, i: int64(ui64)}
+	}
+	return Number{kind: numberUint, u: ui64}
+}
+
+func float2Number(f64 float64) Number {
+	if f64 == math.Trunc(f64) { // 整数值，NaN及Inf不满足该条件
+		if f64 >= math.MinInt64 && f64 < math.MaxInt64 {
+			return Number{kind: numberInt, i: int64(f64)}
+		}
+		if f64 >= 0 && f64 < math.MaxUint64 {
+			return Number{kind: numberUint, u: uint64(f64)}
+		}
+	}
+	return Number{kind: numberFloat, f: f64}
+}
+
+// Key 类型标记 + 8字节大端序编码，可无损还原数值，同类型数值的字典序与数值顺序一致
+func (n Number) Key() string {
+	var bytes [9]byte
+	bytes[0] = n.kind
+	switch n.kind {
+	case numberInt:
+		binary.BigEndian.PutUint64(bytes[1:], uint64(n.i)^1<<63)
+	case numberUint:
+		binary.BigEndian.PutUint64(bytes[1:], n.u)
+	case numberFloat:
+		binary.BigEndian.PutUint64(bytes[1:], float2sortable(n.f))
+	}
+	return string(bytes[:])
+}
+
+// HashKey 保序的hashKey
+//
+// 各类型数值均转换为float64后取可排序编码，数值越大hashKey越大，NaN排在+Inf之后；
+// 超出float64精度的整数可能得到相同hashKey并落入同一叶子节点，由叶子节点中各link的md516Key区分，检索时比对原值
+func (n Number) HashKey() uint64 {
+	switch n.kind {
+	default:
+		return float2sortable(n.f)
+	case numberInt:
+		return float2sortable(float64(n.i))
+	case numberUint:
+		return float2sortable(float64(n.u))
+	}
+}
+
+// Compare 按数值大小比较，小于、等于及大于other分别返回-1、0及1，NaN与NaN相等且大于其它任何数值
+func (n Number) Compare(other Number) int {
+	switch {
+	case n.kind == numberFloat:
+		return n.compareFloat(other)
+	case other.kind == numberFloat:
+		return -other.compareFloat(n)
+	case n.kind != other.kind: // numberUint总是大于numberInt
+		if n.kind == numberUint {
+			return 1
+		}
+		return -1
+	case n.kind == numberUint:
+		return compareUint64(n.u, other.u)
+	}
+	if n.i < other.i {
+		return -1
+	} else if n.i > other.i {
+		return 1
+	}
+	return 0
+}
+
+// compareFloat numberFloat与其它数值比较，numberFloat不会是可由整数表示的值
+func (n Number) compareFloat(other Number) int {
+	if math.IsNaN(n.f) {
+		if other.kind == numberFloat && math.IsNaN(other.f) {
+			return 0
+		}
+		return 1
+	}
+	switch other.kind {
+	case numberFloat:
+		if math.IsNaN(other.f) || n.f < other.f {
+			return -1
+		} else if n.f > other.f {
+			return 1
+		}
+		return 0
+	case numberInt:
+		if n.f >= math.MaxInt64 {
+			return 1
+		} else if n.f < math.MinInt64 {
+			return -1
+		} else if int64(math.Floor(n.f)) < other.i { // 非整数值，向下取整后小于other即小于other
+			return -1
+		}
+		return 1
+	default:
+		if n.f >= math.MaxUint64 {
+			return 1
+		} else if n.f < math.MaxInt64 {
+			return -1
+		} else if uint64(math.Floor(n.f)) < other.u {
+			return -1
+		}
+		return 1
+	}
+}
+
+// Add 两数值相加，均为int64且未溢出时结果仍为整数，否则以float64计算
+func (n Number) Add(other Number) Number {
+	if n.kind == numberInt && other.kind == numberInt {
+		if sum := n.i + other.i; (sum > n.i) == (other.i > 0) { // 未溢出
+			return Number{kind: numberInt, i: sum}
+		}
+	}
+	return float2Number(n.Float64() + other.Float64())
+}
+
+// Float64 转换为float64，超出float64精度的整数将丢失精度
+func (n Number) Float64() float64 {
+	switch n.kind {
+	default:
+		return n.f
+	case numberInt:
+		return float64(n.i)
+	case numberUint:
+		return float64(n.u)
+	}
+}
+
+// Value 返回数值对应的int64、uint64或float64原值
+func (n Number) Value() interface{} {
+	switch n.kind {
+	default:
+		return n.f
+	case numberInt:
+		return n.i
+	case numberUint:
+		return n.u
+	}
+}
+
+// String 数值的字符串表示
+func (n Number) String() string {
+	switch n.kind {
+	default:
+		return strconv.FormatFloat(n.f, 'E', -1, 64)
+	case numberInt:
+		return strconv.FormatInt(n.i, 10)
+	case numberUint:
+		return strconv.FormatUint(n.u, 10)
+	}
+}
+
+func compareUint64(a, b uint64) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+// float2sortable 将float64转换为可排序的uint64，-0与+0相同，所有NaN均为最大值
+func float2sortable(f64 float64) uint64 {
+	if math.IsNaN(f64) {
+		return math.MaxUint64
+	}
+	if f64 == 0 {
+		return 1 << 63
+	}
+	bits := math.Float64bits(f64)
+	if bits>>63 == 1 { // 负数取反，绝对值越大结果越小
+		return ^bits
+	}
+	return bits | 1<<63
+}
@@ -49,3 +49,15 @@ func PathFormIndexFile(databaseID, formID, indexID string) string {
 func PathFormFile(databaseID, formID string) string {
 	return filepath.Join(config.Obtain().DataDir, databaseID, formID, "form.dat")
 }
+
+// PathWALFile 库预写日志文件路径
+//
+// databaseID 数据库唯一id
+func PathWALFile(databaseID string) string {
+	return filepath.Join(config.Obtain().DataDir, databaseID, "lily.wal")
+}
+
+// PathSortDir 排序临时文件目录
+func PathSortDir() string {
+	return filepath.Join(config.Obtain().DataDir, "tmp")
+}
@@ -0,0 +1,116 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package utils
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Project 按字段投影对象，返回仅包含指定字段或去除指定字段后的map[string]interface{}
+//
+// value 被投影对象，支持map及结构体及其指针，其它类型原样返回
+//
+// fields 由对象结构层级字段通过'.'组成的字段名称，以'-'开头表示去除该字段；存在非去除字段时仅保留这些字段，否则保留去除字段以外的所有字段
+func Project(value interface{}, fields []string) interface{} {
+	var includes, excludes [][]string
+	for _, field := range fields {
+		if strings.HasPrefix(field, "-") {
+			excludes = append(excludes, strings.Split(field[1:], "."))
+		} else {
+			includes = append(includes, strings.Split(field, "."))
+		}
+	}
+	result, ok := toMap(value)
+	if !ok {
+		return value
+	}
+	if len(includes) > 0 {
+		result = map[string]interface{}{}
+		for _, params := range includes {
+			if item, exist := ValueByParams(value, params); exist {
+				setByParams(result, params, item)
+			}
+		}
+	}
+	for _, params := range excludes {
+		deleteByParams(result, params)
+	}
+	return result
+}
+
+// toMap 将map或结构体浅拷贝为map[string]interface{}，结构体仅包含可导出字段
+func toMap(value interface{}) (map[string]interface{}, bool) {
+	reflectValue := indirect(reflect.ValueOf(value))
+	switch reflectValue.Kind() {
+	default:
+		return nil, false
+	case reflect.Map:
+		result := make(map[string]interface{}, reflectValue.Len())
+		iter := reflectValue.MapRange()
+		for iter.Next() {
+			key, ok := indirect(iter.Key()).Interface().(string)
+			if !ok {
+				return nil, false
+			}
+			result[key] = iter.Value().Interface()
+		}
+		return result, true
+	case reflect.Struct:
+		result := make(map[string]interface{}, reflectValue.NumField())
+		for i := 0; i < reflectValue.NumField(); i++ {
+			if field := reflectValue.Field(i); field.CanInterface() {
+				result[reflectValue.Type().Field(i).Name] = field.Interface()
+			}
+		}
+		return result, true
+	}
+}
+
+// setByParams 按层级字段写入值，逐层创建不存在的map
+func setByParams(result map[string]interface{}, params []string, item interface{}) {
+	for _, param := range params[:len(params)-1] {
+		next, ok := result[param].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			result[param] = next
+		}
+		result = next
+	}
+	result[params[len(params)-1]] = item
+}
+
+// deleteByParams 按层级字段删除值，沿途的map或结构体被拷贝后替换，不影响原对象
+func deleteByParams(result map[string]interface{}, params []string) {
+	for _, param := range params[:len(params)-1] {
+		next, ok := toMap(result[param])
+		if !ok {
+			return
+		}
+		result[param] = next
+		result = next
+	}
+	delete(result, params[len(params)-1])
+}
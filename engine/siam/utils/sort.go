@@ -0,0 +1,357 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2020 aberic
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package utils
+
+import (
+	"bufio"
+	"container/heap"
+	"github.com/aberic/gnomon/log"
+	"github.com/aberic/lilydb/engine/comm"
+	"github.com/vmihailenco/msgpack"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	// NullsFirst 字段不存在或值为空的数据排在最前
+	NullsFirst = "first"
+	// NullsLast 字段不存在或值为空的数据排在最后
+	NullsLast = "last"
+)
+
+// SortKey 排序字段
+type SortKey struct {
+	params     []string // 由对象结构层级字段通过'.'拆分组成的参数
+	asc        bool     // 是否升序
+	nullsFirst bool     // 字段不存在或值为空的数据是否排在最前
+}
+
+// NewSortKey 新建排序字段
+//
+// param 参数名，由对象结构层级字段通过'.'组成
+//
+// asc 是否升序
+//
+// nulls 字段不存在或值为空的数据排在最前first或最后last，为空时视为最小值，即升序时在前、倒序时在后
+func NewSortKey(param string, asc bool, nulls string) (*SortKey, error) {
+	if param == "" {
+		return nil, comm.ErrSortNotSupport
+	}
+	key := &SortKey{params: strings.Split(param, "."), asc: asc}
+	switch nulls {
+	default:
+		return nil, comm.ErrSortNotSupport
+	case "":
+		key.nullsFirst = asc
+	case NullsFirst:
+		key.nullsFirst = true
+	case NullsLast:
+	}
+	return key, nil
+}
+
+// compare 比较两个字段值的先后，空值按nullsFirst排列，不同类型按布尔、数值、字符串及其它的顺序排列
+func (k *SortKey) compare(a, b interface{}) int {
+	nullA, nullB := nil == a, nil == b
+	switch {
+	case nullA && nullB:
+		return 0
+	case nullA:
+		if k.nullsFirst {
+			return -1
+		}
+		return 1
+	case nullB:
+		if k.nullsFirst {
+			return 1
+		}
+		return -1
+	}
+	result, ok := CompareValue(a, b)
+	if !ok {
+		result = typeOrder(a) - typeOrder(b)
+	}
+	if k.asc {
+		return result
+	}
+	return -result
+}
+
+// typeOrder 不同类型字段值的排列顺序
+func typeOrder(value interface{}) int {
+	switch value.(type) {
+	case bool:
+		return 1
+	case string:
+		return 3
+	}
+	reflectValue := reflect.ValueOf(value)
+	if _, ok := ValueNumber(&reflectValue); ok {
+		return 2
+	}
+	return 4
+}
+
+// SortKeys 多字段排序方式，按先后顺序依次比较
+type SortKeys []*SortKey
+
+// Compare 比较两条数据的先后，a在前返回负数，b在前返回正数，各字段均相同时返回0
+func (sk SortKeys) Compare(a, b interface{}) int {
+	return sk.compareValues(sk.values(a), sk.values(b))
+}
+
+// values 取出数据在各排序字段上的值，字段不存在时为nil
+func (sk SortKeys) values(value interface{}) []interface{} {
+	values := make([]interface{}, len(sk))
+	for position, key := range sk {
+		values[position], _ = ValueByParams(value, key.params)
+	}
+	return values
+}
+
+// compareValues 依次比较各排序字段上的值
+func (sk SortKeys) compareValues(a, b []interface{}) int {
+	for position, key := range sk {
+		if result := key.compare(a[position], b[position]); result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+// sortEntry 待排序数据在各排序字段上的值及其位置
+type sortEntry struct {
+	Keys     []interface{} `msgpack:"k"`
+	Position int           `msgpack:"p"`
+}
+
+// Sorter 外部归并排序器
+//
+// 内存中的数据超出预算时排序后写入DataDir下的临时文件，取出时将各临时文件与内存中的数据归并，相同排序值的数据保持加入时的顺序
+//
+// 排序器仅保留数据在各排序字段上的值及调用方指定的位置，取出时由调用方按位置取回数据，数据类型与是否写入临时文件无关
+type Sorter struct {
+	keys   SortKeys
+	budget int          // 内存中最多保留的数据条数，不大于0时全部保留在内存中
+	buffer []*sortEntry // 内存中尚未写入临时文件的数据
+	runs   []string     // 各已排序的临时文件路径
+	count  int          // 已加入的数据总条数
+}
+
+// NewSorter 新建外部归并排序器
+//
+// keys 多字段排序方式
+//
+// budget 内存中最多保留的数据条数，不大于0时不写入临时文件
+func NewSorter(keys SortKeys, budget int) *Sorter {
+	return &Sorter{keys: keys, budget: budget}
+}
+
+// Add 加入一条待排序的数据，写入临时文件失败时数据继续保留在内存中
+//
+// value 待排序的数据，仅用于取出各排序字段上的值
+//
+// position 调用方取回该数据的位置
+func (s *Sorter) Add(value interface{}, position int) {
+	s.count++
+	s.buffer = append(s.buffer, &sortEntry{Keys: s.keys.values(value), Position: position})
+	if s.budget > 0 && len(s.buffer) >= s.budget {
+		if err := s.spill(); nil != err {
+			log.Warn("sorter spill", log.Err(err))
+			s.budget = 0
+		}
+	}
+}
+
+// Count 已加入的数据总条数
+func (s *Sorter) Count() int {
+	return s.count
+}
+
+// Range 按排序结果依次处理数据，处理完成后删除临时文件
+//
+// skip 跳过的数据条数
+//
+// limit 最多处理的数据条数
+//
+// handler 数据处理方法，参数为加入时指定的位置，返回false时停止处理
+func (s *Sorter) Range(skip, limit uint32, handler func(position int) bool) error {
+	defer s.Close()
+	s.sort(s.buffer)
+	merger := &sortMerger{keys: s.keys}
+	for order, run := range s.runs {
+		file, err := os.Open(run)
+		if nil != err {
+			return err
+		}
+		defer func() { _ = file.Close() }()
+		if err = merger.push(&sortSource{order: order, decoder: msgpack.NewDecoder(bufio.NewReader(file))}); nil != err {
+			return err
+		}
+	}
+	if err := merger.push(&sortSource{order: len(s.runs), entries: s.buffer}); nil != err {
+		return err
+	}
+	var position, handled uint32
+	for merger.Len() > 0 && handled < limit {
+		source := merger.sources[0]
+		if position >= skip {
+			if !handler(source.head.Position) {
+				return nil
+			}
+			handled++
+		}
+		position++
+		more, err := source.next()
+		if nil != err {
+			return err
+		}
+		if more {
+			heap.Fix(merger, 0)
+		} else {
+			heap.Pop(merger)
+		}
+	}
+	return nil
+}
+
+// Close 删除排序过程中写入的临时文件
+func (s *Sorter) Close() {
+	for _, run := range s.runs {
+		_ = os.Remove(run)
+	}
+	s.runs, s.buffer = nil, nil
+}
+
+// sort 稳定排序内存中的数据
+func (s *Sorter) sort(entries []*sortEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return s.keys.compareValues(entries[i].Keys, entries[j].Keys) < 0
+	})
+}
+
+// spill 将内存中的数据排序后写入临时文件
+func (s *Sorter) spill() error {
+	dir := PathSortDir()
+	if err := os.MkdirAll(dir, os.ModePerm); nil != err {
+		return err
+	}
+	file, err := ioutil.TempFile(dir, "sort-*.run")
+	if nil != err {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+	s.sort(s.buffer)
+	writer := bufio.NewWriter(file)
+	encoder := msgpack.NewEncoder(writer)
+	for _, entry := range s.buffer {
+		if err = encoder.Encode(entry); nil != err {
+			break
+		}
+	}
+	if nil == err {
+		err = writer.Flush()
+	}
+	if nil != err {
+		_ = os.Remove(file.Name())
+		return err
+	}
+	s.runs = append(s.runs, file.Name())
+	s.buffer = nil
+	return nil
+}
+
+// sortSource 归并排序的一路已排序数据，来自临时文件或内存
+type sortSource struct {
+	order   int              // 来源先后，排序值相同时先加入的数据在前
+	entries []*sortEntry     // 内存中尚未取出的数据
+	decoder *msgpack.Decoder // 临时文件解码器，为nil时表示数据来自内存
+	head    *sortEntry       // 当前待取出的数据
+}
+
+// next 读取下一条数据作为head，已无数据时返回false
+func (ss *sortSource) next() (bool, error) {
+	if nil == ss.decoder {
+		if len(ss.entries) == 0 {
+			return false, nil
+		}
+		ss.head, ss.entries = ss.entries[0], ss.entries[1:]
+		return true, nil
+	}
+	entry := &sortEntry{}
+	if err := ss.decoder.Decode(entry); err == io.EOF {
+		return false, nil
+	} else if nil != err {
+		return false, err
+	}
+	ss.head = entry
+	return true, nil
+}
+
+// sortMerger 按各路head排序的小顶堆
+type sortMerger struct {
+	keys    SortKeys
+	sources []*sortSource
+}
+
+// push 读取首条数据后加入堆，无数据的来源直接忽略
+func (sm *sortMerger) push(source *sortSource) error {
+	more, err := source.next()
+	if nil != err || !more {
+		return err
+	}
+	heap.Push(sm, source)
+	return nil
+}
+
+func (sm *sortMerger) Len() int {
+	return len(sm.sources)
+}
+
+func (sm *sortMerger) Less(i, j int) bool {
+	result := sm.keys.compareValues(sm.sources[i].head.Keys, sm.sources[j].head.Keys)
+	if result == 0 {
+		return sm.sources[i].order < sm.sources[j].order
+	}
+	return result < 0
+}
+
+func (sm *sortMerger) Swap(i, j int) {
+	sm.sources[i], sm.sources[j] = sm.sources[j], sm.sources[i]
+}
+
+func (sm *sortMerger) Push(x interface{}) {
+	sm.sources = append(sm.sources, x.(*sortSource))
+}
+
+func (sm *sortMerger) Pop() interface{} {
+	last := sm.sources[len(sm.sources)-1]
+	sm.sources = sm.sources[:len(sm.sources)-1]
+	return last
+}
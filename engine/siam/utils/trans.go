@@ -25,52 +25,19 @@
 package utils
 
 import (
-	"github.com/aberic/gnomon"
-	"github.com/aberic/lilydb/engine/comm"
+	"encoding/binary"
+	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 )
 
 // Type2index 通过存储内容获取索引信息
+//
+// 与 ValueType2index 保持同一套转换规则，确保通过map与结构体存入的相同值得到相同索引
 func Type2index(value interface{}) (key string, hashKey uint64, support bool) {
-	support = true
-	switch value := value.(type) {
-	default:
-		return "", 0, false
-	case int:
-		i64, err := strconv.ParseInt(strconv.Itoa(value), 10, 64)
-		if nil != err {
-			return "", 0, false
-		}
-		key = strconv.FormatInt(i64, 10)
-		hashKey = uint64(i64 + 9223372036854775807 + 1)
-	case int8, int16, int32, int64:
-		i64 := value.(int64)
-		key = strconv.FormatInt(i64, 10)
-		hashKey = uint64(i64 + 9223372036854775807 + 1)
-	case uint8, uint16, uint32, uint, uint64, uintptr:
-		ui64 := value.(uint64)
-		key = strconv.FormatUint(ui64, 10)
-		if ui64 > 9223372036854775807 { // 9223372036854775808 = 1 << 63
-			return "", 0, false
-		}
-		hashKey = ui64 + 9223372036854775807 + 1
-	case float32, float64:
-		i64 := gnomon.ScaleFloat64toInt64(value.(float64), 4)
-		key = strconv.FormatInt(i64, 10)
-		hashKey = uint64(i64 + 9223372036854775807 + 1)
-	case string:
-		hashKey = comm.Hash(value)
-	case bool:
-		if value {
-			key = "true"
-			hashKey = 1
-		} else {
-			key = "false"
-			hashKey = 2
-		}
-	}
-	return
+	reflectValue := reflect.ValueOf(value)
+	return ValueType2index(&reflectValue)
 }
 
 // ValueType2index 通过存储内容获取索引信息
@@ -79,30 +46,20 @@ func ValueType2index(value *reflect.Value) (key string, hashKey uint64, support
 	switch value.Kind() {
 	default:
 		return "", 0, false
-	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int, reflect.Int64:
-		i64 := value.Int()
-		key = strconv.FormatInt(i64, 10)
-		hashKey = uint64(i64 + 9223372036854775807 + 1)
-	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint, reflect.Uint64, reflect.Uintptr:
-		ui64 := value.Uint()
-		key = strconv.FormatUint(ui64, 10)
-		if ui64 > 9223372036854775807 { // 9223372036854775808 = 1 << 63
-			return "", 0, false
-		}
-		hashKey = ui64 + 9223372036854775807 + 1
-	case reflect.Float32, reflect.Float64:
-		i64 := gnomon.ScaleFloat64toInt64(value.Float(), 4)
-		key = strconv.FormatInt(i64, 10)
-		hashKey = uint64(i64 + 9223372036854775807 + 1)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		number, _ := ValueNumber(value)
+		key = number.Key()
+		hashKey = number.HashKey()
 	case reflect.String:
 		key = value.String()
-		hashKey = comm.Hash(key)
+		hashKey = String2hashKey(key)
 	case reflect.Bool:
+		key = strconv.FormatBool(value.Bool())
 		if value.Bool() {
-			key = value.String()
 			hashKey = 1
 		} else {
-			key = value.String()
 			hashKey = 2
 		}
 	}
@@ -115,19 +72,13 @@ func Value2hashKey(value *reflect.Value) (hashKey uint64, support bool) {
 	switch value.Kind() {
 	default:
 		return 0, false
-	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int, reflect.Int64:
-		i64 := value.Int()
-		hashKey = uint64(i64 + 9223372036854775807 + 1)
-	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint, reflect.Uint64, reflect.Uintptr:
-		ui64 := value.Uint()
-		if ui64 > 9223372036854775808 { // 9223372036854775808 = 1 << 63
-			return 0, false
-		}
-		hashKey = ui64 + 9223372036854775807 + 1
-	case reflect.Float32, reflect.Float64:
-		hashKey = uint64(gnomon.ScaleFloat64toInt64(value.Float(), 4) + 9223372036854775807 + 1)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		number, _ := ValueNumber(value)
+		hashKey = number.HashKey()
 	case reflect.String:
-		hashKey = comm.Hash(value.String())
+		hashKey = String2hashKey(value.String())
 	case reflect.Bool:
 		if value.Bool() {
 			hashKey = 1
@@ -137,3 +88,102 @@ func Value2hashKey(value *reflect.Value) (hashKey uint64, support bool) {
 	}
 	return
 }
+
+// String2hashKey 将字符串转换为保序的hashKey
+//
+// 取字符串前8个字节按大端序组成hashKey，不足8个字节以0补齐，字符串的字典序与hashKey的顺序一致，
+// 前缀相同的字符串将得到相同hashKey并落入同一叶子节点，由叶子节点中各link的md516Key区分，检索时比对原值
+func String2hashKey(value string) uint64 {
+	var prefix [8]byte
+	copy(prefix[:], value)
+	return binary.BigEndian.Uint64(prefix[:])
+}
+
+// KeyStructures 拆分索引字段名称，组合索引按声明顺序返回各字段名称，单字段索引返回仅包含自身的集合
+func KeyStructures(keyStructure string) []string {
+	return strings.Split(keyStructure, KeyStructureSeparator)
+}
+
+// CompositeKeyStructure 由多个字段名称按序组成组合索引字段名称，如'tenant,createdAt'
+func CompositeKeyStructure(keyStructures ...string) string {
+	return strings.Join(keyStructures, KeyStructureSeparator)
+}
+
+// CompositeKey 组合多个字段的索引信息
+//
+// 各字段依次编码为定长16进制hashKey + 定长16进制key长度 + key，编码结果的字典序与各字段值组成元组的顺序一致，且不同元组不会得到相同编码
+//
+// 索引树仅能按单个hashKey排序，组合hashKey取首字段hashKey，使索引树按首字段有序并可由首字段条件检索，其余字段在叶子节点中过滤
+func CompositeKey(keys []string, hashKeys []uint64) (key string, hashKey uint64) {
+	var builder strings.Builder
+	for position, k := range keys {
+		builder.WriteString(fmt.Sprintf("%016x%016x", hashKeys[position], len(k)))
+		builder.WriteString(k)
+	}
+	return builder.String(), hashKeys[0]
+}
+
+// ValueByParams 根据参数获取对象中的值，参数由对象结构层级字段通过'.'拆分组成
+//
+// 字段存在但值为空时返回nil及true，字段不存在、无法获取或为未导出字段时返回false
+func ValueByParams(value interface{}, params []string) (interface{}, bool) {
+	reflectValue, ok := ReflectByParams(value, params)
+	if !ok || !reflectValue.CanInterface() {
+		return nil, false
+	}
+	if kind := reflectValue.Kind(); (kind == reflect.Ptr || kind == reflect.Interface) && reflectValue.IsNil() {
+		return nil, true
+	}
+	return reflectValue.Interface(), true
+}
+
+// ReflectByParams 根据参数获取对象中值的反射对象，参数由对象结构层级字段通过'.'拆分组成
+//
+// 支持键为字符串的map、msgpack解析得到的map[interface{}]interface{}、结构体及其指针，切片及数组按数字下标获取
+//
+// 字段存在但值为空指针或空接口时返回该空值的反射对象及true
+func ReflectByParams(value interface{}, params []string) (reflect.Value, bool) {
+	reflectValue := indirect(reflect.ValueOf(value))
+	for position, param := range params {
+		switch reflectValue.Kind() {
+		default:
+			return reflect.Value{}, false
+		case reflect.Map:
+			key := reflect.ValueOf(param)
+			if keyType := reflectValue.Type().Key(); keyType.Kind() == reflect.String {
+				key = key.Convert(keyType)
+			} else if keyType.Kind() != reflect.Interface {
+				return reflect.Value{}, false
+			}
+			reflectValue = reflectValue.MapIndex(key)
+		case reflect.Struct:
+			reflectValue = reflectValue.FieldByName(param)
+		case reflect.Slice, reflect.Array:
+			index, err := strconv.Atoi(param)
+			if nil != err || index < 0 || index >= reflectValue.Len() {
+				return reflect.Value{}, false
+			}
+			reflectValue = reflectValue.Index(index)
+		}
+		if !reflectValue.IsValid() { // 字段不存在
+			return reflect.Value{}, false
+		}
+		if next := indirect(reflectValue); next.IsValid() {
+			reflectValue = next
+		} else if position < len(params)-1 { // 中间层级为空，后续字段不存在
+			return reflect.Value{}, false
+		}
+	}
+	return reflectValue, reflectValue.IsValid()
+}
+
+// indirect 获取指针及接口所指向的反射对象，空指针返回无效的反射对象
+func indirect(reflectValue reflect.Value) reflect.Value {
+	for reflectValue.Kind() == reflect.Ptr || reflectValue.Kind() == reflect.Interface {
+		if reflectValue.IsNil() {
+			return reflect.Value{}
+		}
+		reflectValue = reflectValue.Elem()
+	}
+	return reflectValue
+}
@@ -25,11 +25,34 @@
 package utils
 
 import (
+	"encoding/json"
+	"github.com/aberic/lilydb/config"
 	"github.com/aberic/lilydb/engine/comm"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
+// TestMain 将数据目录指向临时目录，测试结束后删除
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "lilydb-utils")
+	if nil != err {
+		panic(err)
+	}
+	conf := config.Obtain()
+	conf.RootDir = dir
+	conf.DataDir = filepath.Join(dir, "data")
+	conf.LilyLockFilePath = filepath.Join(dir, "lily.lock")
+	conf.LilyBootstrapFilePath = filepath.Join(conf.DataDir, "lily.sync")
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
 func TestHash(t *testing.T) {
 	t.Log(comm.Hash("test"))
 }
@@ -91,3 +114,179 @@ func TestValue2hashKey(t *testing.T) {
 	reflectValue = reflect.ValueOf(struct{}{})
 	t.Log(Value2hashKey(&reflectValue))
 }
+
+func TestCompositeKey(t *testing.T) {
+	keyStructures := KeyStructures(CompositeKeyStructure("tenant", "createdAt"))
+	if len(keyStructures) != 2 || keyStructures[1] != "createdAt" {
+		t.Error("key structures split failed", keyStructures)
+	}
+	tuple := func(tenant string, createdAt int) (string, uint64) {
+		tenantKey, tenantHashKey, _ := Type2index(tenant)
+		createdAtKey, createdAtHashKey, _ := Type2index(createdAt)
+		return CompositeKey([]string{tenantKey, createdAtKey}, []uint64{tenantHashKey, createdAtHashKey})
+	}
+	key1, hashKey1 := tuple("a", -1)
+	key2, hashKey2 := tuple("a", 2)
+	if hashKey1 != hashKey2 || key1 >= key2 {
+		t.Error("composite key order failed", key1, key2)
+	}
+}
+
+func TestString2hashKey(t *testing.T) {
+	values := []string{"", "a", "ab", "abcdefgh", "abcdefghi", "b"}
+	for i := 1; i < len(values); i++ {
+		if String2hashKey(values[i-1]) > String2hashKey(values[i]) {
+			t.Error("string hash key order failed", values[i-1], values[i])
+		}
+	}
+	if String2hashKey("abcdefgh1") != String2hashKey("abcdefgh2") {
+		t.Error("string hash key prefix failed")
+	}
+}
+
+func TestNumber(t *testing.T) {
+	numbers := []interface{}{math.Inf(-1), int64(math.MinInt64), -1.5, -1, 0.00001, uint8(1), 1.00001, int64(1 << 53),
+		uint64(1<<53 + 1), int64(math.MaxInt64), uint64(math.MaxUint64), 1e30, math.Inf(1), math.NaN()}
+	for i := 1; i < len(numbers); i++ {
+		previous, current := reflect.ValueOf(numbers[i-1]), reflect.ValueOf(numbers[i])
+		numberPrevious, _ := ValueNumber(&previous)
+		numberCurrent, _ := ValueNumber(&current)
+		if numberPrevious.Compare(numberCurrent) != -1 || numberCurrent.Compare(numberPrevious) != 1 {
+			t.Error("number compare failed", numbers[i-1], numbers[i])
+		}
+		if numberPrevious.HashKey() > numberCurrent.HashKey() {
+			t.Error("number hash key order failed", numbers[i-1], numbers[i])
+		}
+	}
+	keyInt, _, _ := Type2index(1)
+	keyUint, _, _ := Type2index(uint64(1))
+	keyFloat, _, _ := Type2index(1.0)
+	if keyInt != keyUint || keyInt != keyFloat {
+		t.Error("number key identity failed")
+	}
+	if number, ok := ParseNumber("18446744073709551615"); !ok || number.String() != "18446744073709551615" {
+		t.Error("number parse failed", number)
+	}
+	values := JSONNumber([]interface{}{json.Number("9007199254740993"), json.Number("18446744073709551615"), json.Number("1.5"), "s"}).([]interface{})
+	if values[0] != int64(1<<53+1) || values[1] != uint64(math.MaxUint64) || values[2] != 1.5 || values[3] != "s" {
+		t.Error("json number failed", values)
+	}
+}
+
+func TestValueByParams(t *testing.T) {
+	type in struct {
+		S string
+	}
+	type ref struct {
+		I  int
+		In *in
+		L  []interface{}
+		M  map[interface{}]interface{}
+	}
+	value := &ref{I: 1, In: &in{S: "2"}, L: []interface{}{3, map[string]interface{}{"s": "4"}}, M: map[interface{}]interface{}{"i": 5}}
+	for params, want := range map[string]interface{}{"I": 1, "In.S": "2", "L.0": 3, "L.1.s": "4", "M.i": 5} {
+		if got, ok := ValueByParams(value, strings.Split(params, ".")); !ok || got != want {
+			t.Error("value by params failed", params, got)
+		}
+	}
+	for _, params := range []string{"J", "In.T", "L.2", "L.x", "M.j"} {
+		if got, ok := ValueByParams(value, strings.Split(params, ".")); ok {
+			t.Error("value by params should fail", params, got)
+		}
+	}
+	if _, ok := ValueByParams(&ref{}, []string{"In", "S"}); ok {
+		t.Error("value by params through nil pointer should fail")
+	}
+}
+
+func TestProject(t *testing.T) {
+	type in struct {
+		I int
+		S string
+	}
+	type ref struct {
+		I  int
+		In *in
+	}
+	value := &ref{I: 1, In: &in{I: 3, S: "4"}}
+	included := Project(value, []string{"In.S", "J"}).(map[string]interface{})
+	if len(included) != 1 || included["In"].(map[string]interface{})["S"] != "4" {
+		t.Error("project include failed", included)
+	}
+	origin := map[string]interface{}{"i": 1, "in": map[string]interface{}{"i": 3, "s": "4"}}
+	excluded := Project(origin, []string{"-in.s"}).(map[string]interface{})
+	if excluded["i"] != 1 || len(excluded["in"].(map[string]interface{})) != 1 {
+		t.Error("project exclude failed", excluded)
+	}
+	if len(origin["in"].(map[string]interface{})) != 2 {
+		t.Error("project exclude modified origin", origin)
+	}
+	if Project("value", []string{"i"}) != "value" {
+		t.Error("project scalar failed")
+	}
+}
+
+func TestAggregator(t *testing.T) {
+	aggregator := NewAggregator(&Aggregation{GroupBy: []string{"in.s"}, Accumulators: []*Accumulator{
+		{Op: "count"}, {Op: "sum", Param: "i"}, {Op: "min", Param: "i"}, {Op: "count", Param: "f"}, {Op: "avg", Param: "f"}}})
+	aggregator.Add(map[string]interface{}{"i": int8(1), "f": 0.5, "in": map[string]interface{}{"s": "a"}})
+	aggregator.Add(map[string]interface{}{"i": uint16(2), "in": map[string]interface{}{"s": "a"}})
+	aggregator.Add(map[string]interface{}{"i": math.MaxInt64, "f": 1.5, "in": map[string]interface{}{"s": "a"}})
+	aggregator.Add(map[string]interface{}{"i": 1.5})
+	results := aggregator.Result()
+	if len(results) != 2 {
+		t.Fatal("aggregate group failed", results)
+	}
+	group := results[0].(map[string]interface{})
+	if group["in"].(map[string]interface{})["s"] != "a" || group["count"] != int64(3) || group["min_i"] != int8(1) || group["count_f"] != int64(2) || group["avg_f"] != 1.0 {
+		t.Error("aggregate result failed", group)
+	}
+	if compare, ok := CompareValue(group["sum_i"], float64(math.MaxInt64)+3); !ok || compare != 0 { // 整数溢出后以float64计算
+		t.Error("aggregate sum overflow failed", group)
+	}
+	group = results[1].(map[string]interface{})
+	if _, exist := group["in"]; exist || group["sum_i"] != 1.5 || group["min_i"] != 1.5 || group["avg_f"] != nil {
+		t.Error("aggregate missing group failed", group)
+	}
+	if err := (&Aggregation{Accumulators: []*Accumulator{{Op: "sum"}}}).Verify(); nil == err {
+		t.Error("aggregate verify failed")
+	}
+}
+
+func TestSorter(t *testing.T) {
+	city, _ := NewSortKey("in.s", true, "")
+	age, _ := NewSortKey("i", false, NullsFirst)
+	sorter := NewSorter(SortKeys{city, age}, 3) // 每3条写入一个临时文件
+	var values []interface{}
+	for i := 0; i < 10; i++ {
+		value := map[string]interface{}{"in": map[string]interface{}{"s": []string{"b", "a"}[i%2]}}
+		if i != 4 {
+			value["i"] = i
+		}
+		values = append(values, value)
+	}
+	values = append(values, map[string]interface{}{"i": 100}) // 无in.s的数据升序时排在最前
+	for position, value := range values {
+		sorter.Add(value, position)
+	}
+	if len(sorter.runs) != 3 || sorter.Count() != 11 {
+		t.Fatal("sorter spill failed", sorter.runs, sorter.Count())
+	}
+	var ages []interface{}
+	if err := sorter.Range(1, 6, func(position int) bool {
+		ages = append(ages, values[position].(map[string]interface{})["i"]) // 按位置取回的数据保持原有类型
+		return true
+	}); nil != err {
+		t.Fatal(err)
+	}
+	expects := []interface{}{9, 7, 5, 3, 1, nil} // 跳过无in.s的数据后a组按i倒序，随后为b组中无i的数据
+	if !reflect.DeepEqual(ages, expects) {
+		t.Error("sorter order failed", ages)
+	}
+	if files, _ := ioutil.ReadDir(PathSortDir()); len(files) != 0 {
+		t.Error("sorter temp files not removed", files)
+	}
+	if _, err := NewSortKey("i", true, "middle"); nil == err {
+		t.Error("sort nulls should not be supported")
+	}
+}